@@ -0,0 +1,261 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config holds the user settings loaded from ~/.config/amtui/config.toml
+type Config struct {
+	Queue QueueConfig `toml:"queue"`
+	UI    UIConfig    `toml:"ui"`
+	Hooks HooksConfig `toml:"hooks"`
+	// Playback controls volume steps and how often the player is polled
+	Playback PlaybackConfig `toml:"playback"`
+	// Notifications controls heads-ups about upcoming tracks
+	Notifications NotificationsConfig `toml:"notifications"`
+	// Stations are internet radio streams and Apple Music stations listed in the sidebar
+	Stations []StationConfig `toml:"stations"`
+	// Keys remaps actions to keys, e.g. volume_up = ["=", "k"]. Unset actions keep their defaults.
+	Keys map[string][]string `toml:"keys"`
+	// Themes defines color schemes by name, selectable with ui.theme or at runtime
+	Themes map[string]ThemeConfig `toml:"themes"`
+}
+
+// QueueConfig controls how the amtui Queue is built when playing from a playlist
+type QueueConfig struct {
+	// Strategy is one of daemon.QueueStrategyNames() ("auto", "in-order", "album-shuffle", ...)
+	Strategy string `toml:"strategy"`
+	// Backend is "playlist" (the amtui Queue user playlist) or "native" (kept in amtui and
+	// played track by track, so no playlist is created in the library)
+	Backend string `toml:"backend"`
+	// SkipUnavailable leaves out tracks that can't be played (removed, missing, or offline)
+	SkipUnavailable bool `toml:"skip_unavailable"`
+	// CleanupPlayed removes tracks that finished playing from the amtui Queue, set with
+	// cleanup_played = true. Off by default: with it on, previous track can't go back
+	// and the queue overlay and saved queue lose the tracks already played.
+	CleanupPlayed bool `toml:"cleanup_played"`
+}
+
+// UIConfig controls optional parts of the interface
+type UIConfig struct {
+	// SidebarStats shows "(42 · 2h58m)" next to each playlist in the sidebar
+	SidebarStats bool `toml:"sidebar_stats"`
+	// AddedColumn shows how long ago each song was added to the library
+	AddedColumn bool `toml:"added_column"`
+	// Columns are the song list's columns, in order, as [[ui.columns]] entries
+	Columns []ColumnConfig `toml:"columns"`
+	// DefaultView is what amtui opens on: "playlists", "search" or "queue"
+	DefaultView string `toml:"default_view"`
+	// Artwork is how cover art is drawn: "auto" (detect the terminal), "kitty", "iterm",
+	// "sixel", "text" (colored half-block characters, works everywhere) or "off"
+	Artwork string `toml:"artwork"`
+	// Colors is how many colors to draw with: "auto" (detect the terminal, and none when
+	// NO_COLOR is set), "truecolor", "256", "16" or "none"
+	Colors string `toml:"colors"`
+	// Theme is the color scheme amtui starts with: a built-in theme ("spotify", "dracula",
+	// "nord", "gruvbox", "light", "high-contrast", "colorblind") or one defined under [themes]
+	Theme string `toml:"theme"`
+	// Visualizer is how the Now Playing view animates the playing track: "bars", "wave"
+	// or "off"
+	Visualizer string `toml:"visualizer"`
+	// Language is the language of the interface: "auto" (from LANG) or a language code
+	// like "fr". Text not translated yet stays in English.
+	Language string `toml:"language"`
+	// TerminalTitle sets the terminal's title to "♪ Track – Artist" while a track plays,
+	// and puts the old title back on exit
+	TerminalTitle bool `toml:"terminal_title"`
+}
+
+// ColumnConfig is a [[ui.columns]] entry
+type ColumnConfig struct {
+	// Name is one of "name", "artist", "album", "genre", "duration", "year", "plays",
+	// "rating" or "added"
+	Name string `toml:"name"`
+	// Width is the column's share of the room left by the fixed-width columns (duration,
+	// year, plays, rating and added), so it only applies to name, artist, album and genre.
+	// 0 keeps the column's default.
+	Width int `toml:"width"`
+}
+
+// PlaybackConfig controls the playback keys and status updates
+type PlaybackConfig struct {
+	// VolumeStep is how much volume_up and volume_down change the volume, in percent
+	VolumeStep int `toml:"volume_step"`
+	// FineVolumeStep is how much volume_up_fine and volume_down_fine change the volume, in percent
+	FineVolumeStep int `toml:"fine_volume_step"`
+	// PollInterval is how often the playback status is refreshed, e.g. "1s"
+	PollInterval time.Duration `toml:"poll_interval"`
+}
+
+// HooksConfig configures built-in automations
+type HooksConfig struct {
+	// PauseWhenRunning pauses Music while any of these apps (process names, e.g. "zoom.us")
+	// is running and resumes it when they quit
+	PauseWhenRunning []string `toml:"pause_when_running"`
+	// PollInterval is how often running apps are checked, e.g. "5s"
+	PollInterval time.Duration `toml:"poll_interval"`
+}
+
+// NotificationsConfig controls the "Up next" notice shown shortly before a track ends
+type NotificationsConfig struct {
+	// UpNext shows the upcoming track in the status area so it can be skipped or removed
+	UpNext bool `toml:"up_next"`
+	// Desktop also sends a macOS notification
+	Desktop bool `toml:"desktop"`
+	// NowPlaying sends a macOS notification whenever a new track starts
+	NowPlaying bool `toml:"now_playing"`
+}
+
+// ThemeConfig is a [themes.<name>] entry. Colors are hex ("#1DB954") or ANSI 256-color
+// numbers ("42"); any left out are taken from the Base theme.
+type ThemeConfig struct {
+	// Base is the built-in theme this one starts from, "spotify" if unset
+	Base string `toml:"base"`
+	// Primary colors titles
+	Primary string `toml:"primary"`
+	// Accent colors the selected and playing items
+	Accent string `toml:"accent"`
+	Text   string `toml:"text"`
+	// Muted colors secondary text, table headers and unfocused borders
+	Muted      string `toml:"muted"`
+	Background string `toml:"background"`
+	Sidebar    string `toml:"sidebar"`
+	// Border colors the border of the focused pane and of overlays
+	Border string `toml:"border"`
+	Link   string `toml:"link"`
+	// Input is the background of the search box
+	Input string `toml:"input"`
+	// Selection is the background of the selected song in tables
+	Selection string `toml:"selection"`
+	// Playback is the background of the "Up next" notice in the playback bar
+	Playback string `toml:"playback"`
+	Warning  string `toml:"warning"`
+	// Error is the background of the banner shown while Music is unreachable
+	Error string `toml:"error"`
+	// Overlay is the background of the queue overlay
+	Overlay string `toml:"overlay"`
+	// LyricsPast and LyricsUpcoming color synced lyrics before and after the current line
+	LyricsPast     string `toml:"lyrics_past"`
+	LyricsUpcoming string `toml:"lyrics_upcoming"`
+	// ProgressStart and ProgressEnd are the ends of the progress bar's gradient. With
+	// ANSI numbers the bar is filled with ProgressStart alone.
+	ProgressStart string `toml:"progress_start"`
+	ProgressEnd   string `toml:"progress_end"`
+}
+
+// StationConfig is a [[stations]] entry
+type StationConfig struct {
+	Name string `toml:"name"`
+	// URL is a stream (https://...) or an Apple Music station link (https://music.apple.com/...)
+	URL string `toml:"url"`
+}
+
+// Default returns the configuration used when no config file exists
+func Default() Config {
+	return Config{
+		Queue: QueueConfig{
+			Strategy:        "auto",
+			Backend:         "playlist",
+			SkipUnavailable: true,
+		},
+		UI: UIConfig{
+			DefaultView: "playlists",
+			Artwork:     "auto",
+			Colors:      "auto",
+			Theme:       "spotify",
+			Language:    "auto",
+			Visualizer:  "bars",
+			Columns: []ColumnConfig{
+				{Name: "name", Width: 40},
+				{Name: "artist", Width: 30},
+				{Name: "album", Width: 30},
+				{Name: "duration"},
+			},
+		},
+		Playback: PlaybackConfig{
+			VolumeStep:     10,
+			FineVolumeStep: 1,
+			PollInterval:   time.Second,
+		},
+		Hooks: HooksConfig{
+			PollInterval: 5 * time.Second,
+		},
+		Notifications: NotificationsConfig{
+			UpNext: true,
+		},
+	}
+}
+
+// Dir returns the amtui config directory, honoring $XDG_CONFIG_HOME
+func Dir() (string, error) {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "amtui"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "amtui"), nil
+}
+
+// StateDir returns the directory for state kept between runs, honoring $XDG_STATE_HOME
+func StateDir() (string, error) {
+	if xdg := os.Getenv("XDG_STATE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "amtui"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "state", "amtui"), nil
+}
+
+// CacheDir returns the directory for files that can be thrown away, like the log,
+// honoring $XDG_CACHE_HOME
+func CacheDir() (string, error) {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "amtui"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".cache", "amtui"), nil
+}
+
+// Path returns the location of the config file
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.toml"), nil
+}
+
+// Load reads the config file, falling back to defaults for anything it doesn't set.
+// A missing config file is not an error.
+func Load() (Config, error) {
+	path, err := Path()
+	if err != nil {
+		return Default(), err
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads the config at path on top of the defaults
+func LoadFile(path string) (Config, error) {
+	cfg := Default()
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return Default(), fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
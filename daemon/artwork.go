@@ -0,0 +1,100 @@
+package daemon
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // Music stores artwork as JPEG or PNG
+	_ "image/png"
+	"os"
+	"strings"
+)
+
+// ErrNoArtwork is returned for tracks without cover art
+var ErrNoArtwork = errors.New("track has no artwork")
+
+// GetArtwork returns the cover art of the library track with the given persistent ID, or of
+// the current track when persistentID is empty. It returns ErrNoArtwork if there is none.
+func (d *Daemon) GetArtwork(persistentID string) (image.Image, error) {
+	data, err := artwork_data(persistentID)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode artwork: %w", err)
+	}
+	return img, nil
+}
+
+// artwork_data has Music write the raw artwork bytes to a temporary file, as osascript
+// can't print binary data, and reads them back
+func artwork_data(persistentID string) ([]byte, error) {
+	file, err := os.CreateTemp("", "amtui-artwork-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create artwork file: %w", err)
+	}
+	path := file.Name()
+	file.Close()
+	defer os.Remove(path)
+
+	track := "current track"
+	if persistentID != "" {
+		track = fmt.Sprintf("(some track of library playlist 1 whose persistent ID is %s)", as_string(persistentID))
+	}
+
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+
+	try
+		set t to %s
+		if (count of artworks of t) = 0 then
+			return "INFO: No artwork"
+		end if
+		set artData to raw data of artwork 1 of t
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell
+
+try
+	set fileRef to open for access (POSIX file %s) with write permission
+	set eof fileRef to 0
+	write artData to fileRef
+	close access fileRef
+on error errMsg
+	try
+		close access (POSIX file %s)
+	end try
+	return "ERROR: " & errMsg
+end try
+return "SUCCESS"`, track, as_string(path), as_string(path))
+
+	out, err := get_script_output(script)
+	if err != nil {
+		return nil, fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+
+	output := strings.TrimSpace(string(out))
+	switch {
+	case strings.HasPrefix(output, "INFO:"):
+		return nil, ErrNoArtwork
+	case strings.HasPrefix(output, "ERROR:"):
+		return nil, fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	case !strings.HasPrefix(output, "SUCCESS"):
+		return nil, fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artwork: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, ErrNoArtwork
+	}
+	return data, nil
+}
@@ -0,0 +1,116 @@
+package daemon
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// TrackStatus reports whether a track can currently be played
+type TrackStatus int
+
+const (
+	TrackAvailable   TrackStatus = iota // Local file or downloaded track
+	TrackCloudOnly                      // Streams from Apple Music, playable only when online
+	TrackUnavailable                    // Removed from the catalog, missing file, or not downloaded while offline
+)
+
+// parse_track_status maps the status field emitted by GetPlaylist to a TrackStatus
+func parse_track_status(status string) TrackStatus {
+	switch status {
+	case "cloud":
+		return TrackCloudOnly
+	case "unavailable":
+		return TrackUnavailable
+	default:
+		return TrackAvailable
+	}
+}
+
+// Unavailable reports whether the track can't be played right now
+func (t Track) Unavailable() bool {
+	return t.Status == TrackUnavailable
+}
+
+// skipUnavailable controls whether unavailable tracks are left out when building queues
+var (
+	skipUnavailableMu sync.Mutex
+	skipUnavailable   = true
+)
+
+// SetSkipUnavailable sets whether queue construction skips unavailable tracks (on by default)
+func SetSkipUnavailable(skip bool) {
+	skipUnavailableMu.Lock()
+	defer skipUnavailableMu.Unlock()
+	skipUnavailable = skip
+}
+
+func skippingUnavailable() bool {
+	skipUnavailableMu.Lock()
+	defer skipUnavailableMu.Unlock()
+	return skipUnavailable
+}
+
+// playable_positions drops positions (1-based) of unavailable tracks from order
+func playable_positions(order []int, tracks []Track) []int {
+	playable := make([]int, 0, len(order))
+	for _, pos := range order {
+		if !tracks[pos-1].Unavailable() {
+			playable = append(playable, pos)
+		}
+	}
+	return playable
+}
+
+// mark_offline_tracks marks cloud-only tracks unavailable when Apple Music can't be reached
+func mark_offline_tracks(tracks []Track) {
+	cloudOnly := false
+	for _, track := range tracks {
+		if track.Status == TrackCloudOnly {
+			cloudOnly = true
+			break
+		}
+	}
+	if !cloudOnly || !connectivity.offline() {
+		return
+	}
+	for i := range tracks {
+		if tracks[i].Status == TrackCloudOnly {
+			tracks[i].Status = TrackUnavailable
+		}
+	}
+}
+
+// connectivityCacheTTL is how long a reachability check is trusted before probing again
+const connectivityCacheTTL = 30 * time.Second
+
+// connectivityCheck caches whether the Apple Music service is reachable
+type connectivityCheck struct {
+	mu        sync.Mutex
+	checkedAt time.Time
+	isOffline bool
+	now       func() time.Time
+	dial      func() error
+}
+
+var connectivity = &connectivityCheck{
+	now: time.Now,
+	dial: func() error {
+		conn, err := net.DialTimeout("tcp", "api.music.apple.com:443", 2*time.Second)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	},
+}
+
+func (c *connectivityCheck) offline() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.checkedAt.IsZero() && c.now().Sub(c.checkedAt) < connectivityCacheTTL {
+		return c.isOffline
+	}
+	c.isOffline = c.dial() != nil
+	c.checkedAt = c.now()
+	return c.isOffline
+}
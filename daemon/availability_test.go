@@ -0,0 +1,56 @@
+package daemon
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPlayablePositions(t *testing.T) {
+	tracks := []Track{
+		{Name: "One"},
+		{Name: "Two", Status: TrackUnavailable},
+		{Name: "Three", Status: TrackCloudOnly},
+		{Name: "Four", Status: TrackUnavailable},
+	}
+	got := playable_positions([]int{4, 3, 2, 1}, tracks)
+	if want := []int{3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("playable_positions() = %v, want %v", got, want)
+	}
+}
+
+func TestMarkOfflineTracks(t *testing.T) {
+	saved := connectivity
+	defer func() { connectivity = saved }()
+
+	now := time.Unix(0, 0)
+	dials := 0
+	connectivity = &connectivityCheck{
+		now:  func() time.Time { return now },
+		dial: func() error { dials++; return errors.New("no route to host") },
+	}
+
+	tracks := []Track{{Name: "Local"}, {Name: "Stream", Status: TrackCloudOnly}}
+	mark_offline_tracks(tracks)
+	if tracks[0].Unavailable() || !tracks[1].Unavailable() {
+		t.Errorf("mark_offline_tracks() = %+v, want only the cloud track unavailable", tracks)
+	}
+
+	// The reachability result is cached
+	mark_offline_tracks([]Track{{Status: TrackCloudOnly}})
+	if dials != 1 {
+		t.Errorf("dialed %d times within the cache TTL, want 1", dials)
+	}
+	now = now.Add(connectivityCacheTTL)
+	mark_offline_tracks([]Track{{Status: TrackCloudOnly}})
+	if dials != 2 {
+		t.Errorf("dialed %d times after the cache TTL, want 2", dials)
+	}
+
+	// Local-only playlists never probe the network
+	mark_offline_tracks([]Track{{Name: "Local"}})
+	if dials != 2 {
+		t.Errorf("dialed for a playlist without cloud tracks")
+	}
+}
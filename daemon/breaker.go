@@ -0,0 +1,127 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// CircuitThreshold is the number of consecutive osascript failures that trips the breaker
+	CircuitThreshold = 5
+	// CircuitProbeInterval is how often a single probe call is let through while the breaker is open
+	CircuitProbeInterval = 10 * time.Second
+)
+
+// ErrCircuitOpen is returned instead of running a script while Music.app is considered unreachable
+var ErrCircuitOpen = errors.New("Music.app is not responding, calls are paused")
+
+// CircuitState is a snapshot of the breaker used by the TUI to render its banner
+type CircuitState struct {
+	Open      bool
+	Failures  int
+	Diagnosis string    // Human readable explanation of the last failure
+	LastError error     // Last error reported by osascript
+	NextProbe time.Time // When the next recovery probe will be allowed (only set while open)
+}
+
+// circuitBreaker counts consecutive script failures and, once tripped, only lets
+// one probe call through every probeInterval until a call succeeds again
+type circuitBreaker struct {
+	mu            sync.Mutex
+	threshold     int
+	probeInterval time.Duration
+	failures      int
+	open          bool
+	lastProbe     time.Time
+	lastErr       error
+	now           func() time.Time
+}
+
+func newCircuitBreaker(threshold int, probeInterval time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold:     threshold,
+		probeInterval: probeInterval,
+		now:           time.Now,
+	}
+}
+
+// breaker guards every osascript invocation made by the daemon
+var breaker = newCircuitBreaker(CircuitThreshold, CircuitProbeInterval)
+
+// allow reports whether a script may run right now
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return true
+	}
+	// Half-open: let a single probe through once the interval has elapsed
+	if now := b.now(); !now.Before(b.lastProbe.Add(b.probeInterval)) {
+		b.lastProbe = now
+		return true
+	}
+	return false
+}
+
+// record feeds the outcome of a script run into the breaker
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.failures = 0
+		b.open = false
+		b.lastErr = nil
+		return
+	}
+
+	b.failures++
+	b.lastErr = err
+	if !b.open && b.failures >= b.threshold {
+		b.open = true
+		b.lastProbe = b.now()
+	}
+}
+
+func (b *circuitBreaker) state() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := CircuitState{
+		Open:      b.open,
+		Failures:  b.failures,
+		LastError: b.lastErr,
+		Diagnosis: diagnose(b.lastErr),
+	}
+	if b.open {
+		state.NextProbe = b.lastProbe.Add(b.probeInterval)
+	}
+	return state
+}
+
+// CircuitStatus returns the current state of the daemon's circuit breaker
+func CircuitStatus() CircuitState {
+	return breaker.state()
+}
+
+// diagnose turns a raw osascript failure into an actionable explanation
+func diagnose(err error) string {
+	if err == nil {
+		return ""
+	}
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "-1743") || strings.Contains(msg, "Not authorized"):
+		return "Automation permission for Music was revoked. Re-enable it in System Settings › Privacy & Security › Automation"
+	case strings.Contains(msg, "-600") || strings.Contains(msg, "not running"):
+		return "Music.app is not running. Launch it to resume playback control"
+	case strings.Contains(msg, "executable file not found"):
+		return "osascript is not available on this system"
+	default:
+		return fmt.Sprintf("osascript keeps failing: %s", msg)
+	}
+}
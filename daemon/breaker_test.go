@@ -0,0 +1,107 @@
+package daemon
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(3, 10*time.Second)
+	b.now = func() time.Time { return now }
+
+	failure := errors.New("execution error: Music got an error: Application isn't running. (-600)")
+
+	// Trip the breaker
+	for i := 0; i < 3; i++ {
+		if !b.allow() {
+			t.Fatalf("allow() = false before threshold (failure %d)", i)
+		}
+		b.record(failure)
+	}
+	state := b.state()
+	if !state.Open {
+		t.Fatalf("breaker should be open after %d failures", state.Failures)
+	}
+	if !strings.Contains(state.Diagnosis, "not running") {
+		t.Errorf("unexpected diagnosis %q", state.Diagnosis)
+	}
+
+	// Calls are short-circuited until the probe interval elapses
+	if b.allow() {
+		t.Errorf("allow() = true while open and before probe interval")
+	}
+	now = now.Add(10 * time.Second)
+	if !b.allow() {
+		t.Errorf("allow() = false after probe interval elapsed")
+	}
+	if b.allow() {
+		t.Errorf("only a single probe should be let through per interval")
+	}
+
+	// A successful probe closes the breaker
+	b.record(nil)
+	if state := b.state(); state.Open || state.Failures != 0 {
+		t.Errorf("breaker should be closed after success, got %+v", state)
+	}
+	if !b.allow() {
+		t.Errorf("allow() = false after breaker closed")
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "no error", err: nil, want: ""},
+		{name: "permission denied", err: errors.New("Not authorized to send Apple events to Music. (-1743)"), want: "Automation permission"},
+		{name: "music not running", err: errors.New("Music app is not running"), want: "not running"},
+		{name: "missing osascript", err: errors.New(`exec: "osascript": executable file not found in $PATH`), want: "not available"},
+		{name: "other failure", err: errors.New("boom"), want: "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diagnose(tt.err)
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("diagnose() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScriptFailure(t *testing.T) {
+	exitErr := func(stderr string) error {
+		return &exec.ExitError{Stderr: []byte(stderr)}
+	}
+	tests := []struct {
+		name    string
+		out     string
+		err     error
+		failure bool
+	}{
+		{name: "success", out: "SUCCESS"},
+		{name: "track not found in-band", out: "ERROR: Track not found"},
+		{name: "not running in-band", out: "ERROR: Music app is not running", failure: true},
+		{name: "denied in-band", out: "ERROR: Not authorized to send Apple events to Music. (-1743)", failure: true},
+		{name: "missing osascript", err: errors.New(`exec: "osascript": executable file not found in $PATH`), failure: true},
+		{name: "uncaught lookup error", err: exitErr("execution error: Music got an error: Can’t get playlist \"Nope\". (-1728)")},
+		{name: "uncaught not running", err: exitErr("execution error: Music got an error: Application isn’t running. (-600)"), failure: true},
+		{name: "timed out", err: exitErr("execution error: Music got an error: AppleEvent timed out. (-1712)"), failure: true},
+		{name: "killed", err: exitErr(""), failure: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := script_failure([]byte(tt.out), tt.err)
+			if (got != nil) != tt.failure {
+				t.Errorf("script_failure() = %v, want failure %v", got, tt.failure)
+			}
+		})
+	}
+}
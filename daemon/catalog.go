@@ -0,0 +1,86 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrNotInCatalog is returned for tracks the Apple Music catalog has no match for, e.g.
+// songs imported from CDs or files
+var ErrNotInCatalog = errors.New("track not found in the Apple Music catalog")
+
+// catalogSearchURL is the iTunes Search API, which looks up the public Apple Music catalog
+// without an API key
+const catalogSearchURL = "https://itunes.apple.com/search"
+
+var catalogClient = &http.Client{Timeout: 10 * time.Second}
+
+type catalogResult struct {
+	TrackName      string `json:"trackName"`
+	ArtistName     string `json:"artistName"`
+	CollectionName string `json:"collectionName"`
+	TrackViewURL   string `json:"trackViewUrl"`
+}
+
+// CatalogURL looks track up in the Apple Music catalog by name and artist and returns its
+// music.apple.com share link
+func (d *Daemon) CatalogURL(track Track) (string, error) {
+	params := url.Values{}
+	params.Add("term", track.Name+" "+track.Artist)
+	params.Add("media", "music")
+	params.Add("entity", "song")
+	params.Add("limit", "25")
+
+	resp, err := catalogClient.Get(catalogSearchURL + "?" + params.Encode())
+	if err != nil {
+		return "", fmt.Errorf("catalog lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("catalog lookup returned status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read catalog response: %w", err)
+	}
+	return parse_catalog_url(track, body)
+}
+
+// parse_catalog_url picks the search result matching track's name and artist, preferring
+// the one from its album, and returns its link without the tracking parameter
+func parse_catalog_url(track Track, body []byte) (string, error) {
+	var response struct {
+		Results []catalogResult `json:"results"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("failed to parse catalog response: %w", err)
+	}
+
+	var match *catalogResult
+	for i, result := range response.Results {
+		if !strings.EqualFold(result.TrackName, track.Name) || !strings.EqualFold(result.ArtistName, track.Artist) || result.TrackViewURL == "" {
+			continue
+		}
+		if match == nil || strings.EqualFold(result.CollectionName, track.Album) && !strings.EqualFold(match.CollectionName, track.Album) {
+			match = &response.Results[i]
+		}
+	}
+	if match == nil {
+		return "", ErrNotInCatalog
+	}
+
+	link, err := url.Parse(match.TrackViewURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid catalog link %q: %w", match.TrackViewURL, err)
+	}
+	query := link.Query()
+	query.Del("uo")
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
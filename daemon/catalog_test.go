@@ -0,0 +1,28 @@
+package daemon
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCatalogURL(t *testing.T) {
+	body := []byte(`{"resultCount": 3, "results": [
+		{"trackName": "Bohemian Rhapsody", "artistName": "Queen", "collectionName": "Greatest Hits", "trackViewUrl": "https://music.apple.com/us/album/bohemian-rhapsody/1?i=10&uo=4"},
+		{"trackName": "Bohemian Rhapsody", "artistName": "Panic! at the Disco", "collectionName": "A Night at the Opera", "trackViewUrl": "https://music.apple.com/us/album/bohemian-rhapsody/2?i=20&uo=4"},
+		{"trackName": "bohemian rhapsody", "artistName": "Queen", "collectionName": "A Night at the Opera", "trackViewUrl": "https://music.apple.com/us/album/bohemian-rhapsody/3?i=30&uo=4"}
+	]}`)
+
+	got, err := parse_catalog_url(Track{Name: "Bohemian Rhapsody", Artist: "Queen", Album: "A Night at the Opera"}, body)
+	if want := "https://music.apple.com/us/album/bohemian-rhapsody/3?i=30"; err != nil || got != want {
+		t.Errorf("parse_catalog_url() = %q, %v, want %q from the track's album", got, err, want)
+	}
+
+	got, err = parse_catalog_url(Track{Name: "Bohemian Rhapsody", Artist: "Queen", Album: "Live"}, body)
+	if want := "https://music.apple.com/us/album/bohemian-rhapsody/1?i=10"; err != nil || got != want {
+		t.Errorf("parse_catalog_url() = %q, %v, want the first match %q", got, err, want)
+	}
+
+	if _, err := parse_catalog_url(Track{Name: "Home Recording", Artist: "Me"}, body); !errors.Is(err, ErrNotInCatalog) {
+		t.Errorf("parse_catalog_url() error = %v, want ErrNotInCatalog", err)
+	}
+}
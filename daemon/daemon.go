@@ -3,24 +3,36 @@ package daemon
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"os/exec"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Daemon struct{}
 
+// clock returns the current time; tests replace it to get stable results
+var clock = time.Now
+
 type Track struct {
-	Id       string
-	Name     string
-	Artist   string
-	Album    string
-	Duration string
+	Id        string
+	Name      string
+	Artist    string
+	Album     string
+	Duration  string
+	Status    TrackStatus
+	Added     time.Time // When the track was added to the library, zero if unknown
+	Genre     string
+	Year      int // Release year, 0 if unknown
+	PlayCount int
+	Rating    int // 0-100, 20 per star
 }
 
 type Playlist struct {
 	Name   string
 	Tracks []Track
+	Smart  bool // Smart or Genius playlist, whose contents can't be edited directly
 }
 
 type QueueInfo struct {
@@ -31,12 +43,83 @@ type QueueInfo struct {
 	TotalTracks     int
 }
 
+// Editable reports whether the queue is one amtui manages, so its tracks can be moved and removed
+func (q *QueueInfo) Editable() bool {
+	return q.QueueName == "amtui Queue" || q.QueueName == NativeQueueName
+}
+
 func run_script(script string) error {
-	return exec.Command("osascript", "-e", script).Run()
+	_, err := get_script_output(script)
+	return err
 }
 
+// get_script_output runs script through the daemon's single script worker
 func get_script_output(script string) ([]byte, error) {
-	return exec.Command("osascript", "-e", script).Output()
+	return scripts.do(script, false)
+}
+
+// query_script_output is get_script_output for scripts that only read state, letting
+// concurrent identical queries share one run
+func query_script_output(script string) ([]byte, error) {
+	return scripts.do(script, true)
+}
+
+// exec_script runs a single osascript, see scriptWorker
+func exec_script(script string) ([]byte, error) {
+	// Don't hammer osascript while Music.app is known to be unreachable
+	if !breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+	out, err := exec.Command("osascript", "-e", script).Output()
+	failure := script_failure(out, err)
+	breaker.record(failure)
+	// Surface a missing Automation permission as such, whether or not the script caught it
+	if automation_denied(out, failure) {
+		return out, ErrAutomationDenied
+	}
+	return out, err
+}
+
+// script_failure extracts the failure (if any) from an osascript run for the circuit
+// breaker. Only failures to reach Music count: osascript not running, Music closed, Apple
+// events timing out or not allowed. A script that reached Music and failed on its own,
+// like a track that wasn't found, says nothing about whether Music is available.
+func script_failure(out []byte, err error) error {
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || len(exitErr.Stderr) == 0 {
+			return err
+		}
+		stderr := strings.TrimSpace(string(exitErr.Stderr))
+		if !unreachable(stderr) {
+			return nil
+		}
+		return fmt.Errorf("%w: %s", err, stderr)
+	}
+	// Most scripts guard against Music being closed and report it in-band, along with
+	// their own errors
+	if output := string(out); unreachable(output) {
+		if strings.Contains(output, "Music app is not running") {
+			return ErrMusicNotRunning
+		}
+		return errors.New(strings.TrimSpace(output))
+	}
+	return nil
+}
+
+// unreachable reports whether an osascript error means Music couldn't be reached
+func unreachable(msg string) bool {
+	for _, reason := range []string{
+		"-600", "not running", // Music isn't open
+		"-609",               // Connection to Music is gone
+		"-1712", "timed out", // Music didn't answer
+		"-1743", "Not authorized to send Apple events", // Automation permission
+	} {
+		if strings.Contains(msg, reason) {
+			return true
+		}
+	}
+	return false
 }
 
 func parse_queue_output(out []byte) (*QueueInfo, error) {
@@ -106,17 +189,23 @@ func (d *Daemon) Play() error {
 }
 
 func (d *Daemon) PlaySongById(id string) error {
-	script := fmt.Sprintf(`tell application "Music" to play (some track whose persistent ID is "%s")`, id)
+	script := fmt.Sprintf(`tell application "Music" to play (some track whose persistent ID is %s)`, as_string(id))
 	return run_script(script)
 }
 
 func (d *Daemon) PlaySongInPlaylist(songName, playlistName string) error {
-	script := fmt.Sprintf(`tell application "Music" to play (some track of playlist "%s" whose name is "%s")`, playlistName, songName)
+	script := fmt.Sprintf(`tell application "Music" to play (some track of playlist %s whose name is %s)`, as_string(playlistName), as_string(songName))
 	return run_script(script)
 }
 
 // PlaySongAtPosition plays a song at a specific position (1-based) in a playlist
 func (d *Daemon) PlaySongAtPosition(playlistName string, position int) error {
+	return d.PlaySongAtPositionWithStrategy(playlistName, position, nil)
+}
+
+// PlaySongAtPositionWithStrategy plays a song at a specific position (1-based) in a playlist,
+// building the rest of the queue with strategy (nil follows the current shuffle setting)
+func (d *Daemon) PlaySongAtPositionWithStrategy(playlistName string, position int, strategy QueueStrategy) error {
 	// Always validate position first
 	playlist, err := d.GetPlaylist(playlistName)
 	if err != nil {
@@ -126,12 +215,27 @@ func (d *Daemon) PlaySongAtPosition(playlistName string, position int) error {
 		return fmt.Errorf("invalid position %d for playlist with %d tracks", position, len(playlist.Tracks))
 	}
 	
-	// Create queue with selected song first and remaining tracks (shuffled or in order)
-	if err := d.CreateOrUpdateQueueWithSelectedFirst(playlistName, position); err != nil {
+	// The native backend keeps the queue in memory and plays it track by track
+	if usingNativeQueue() {
+		order, _, err := d.queueOrder(playlistName, playlist.Tracks, position, strategy)
+		if err != nil {
+			return fmt.Errorf("failed to create queue from playlist: %w", err)
+		}
+		upNext.replace(playlist_entries(playlistName, playlist.Tracks, order))
+		return d.playNative(0)
+	}
+	
+	// Create queue with the selected song and remaining tracks ordered by the strategy
+	if err := d.buildQueue(playlistName, playlist.Tracks, position, strategy); err != nil {
 		return fmt.Errorf("failed to create queue from playlist: %w", err)
 	}
 	
 	// Play the queue from the beginning
+	return play_amtui_queue()
+}
+
+// play_amtui_queue plays the amtui Queue from the beginning
+func play_amtui_queue() error {
 	script := `
 tell application "Music"
 	if it is not running then
@@ -223,7 +327,7 @@ func (d *Daemon) SetVolume(volume int) error {
 
 func (d *Daemon) GetVolume() (int, error) {
 	script := `tell application "Music" to sound volume`
-	out, err := get_script_output(script)
+	out, err := query_script_output(script)
 	if err != nil {
 		return 0, err
 	}
@@ -241,7 +345,7 @@ func (d *Daemon) SetRepeat(repeatType string) error {
 
 func (d *Daemon) GetRepeatMode() (string, error) {
 	script := `tell application "Music" to get song repeat`
-	out, err := get_script_output(script)
+	out, err := query_script_output(script)
 	if err != nil {
 		return "", err
 	}
@@ -259,7 +363,7 @@ func (d *Daemon) SetShuffle(isShuffle bool) error {
 
 func (d *Daemon) GetShuffle() (bool, error) {
 	script := `tell application "Music" to get shuffle enabled`
-	out, err := get_script_output(script)
+	out, err := query_script_output(script)
 	if err != nil {
 		return false, err
 	}
@@ -273,13 +377,15 @@ type PlaybackStatus struct {
 	Duration     float64 // Total duration in seconds
 	Volume       int
 	Shuffle      bool
+	ShuffleMode  ShuffleMode // What is shuffled when Shuffle is on
 	RepeatMode   string
 	PlayerState  string // "playing", "paused", "stopped"
+	PersistentId string // Persistent ID of the current track, as playlists identify tracks
 }
 
 func (d *Daemon) GetCurrentTrack() (Track, error) {
 	script := `tell application "Music" to get database ID of current track & "||" & name of current track & "||" & artist of current track & "||" & album of current track & "||" & duration of current track as string`
-	out, err := get_script_output(script)
+	out, err := query_script_output(script)
 	if err != nil {
 		return Track{}, err
 	}
@@ -308,6 +414,7 @@ tell application "Music"
 		set trackAlbum to ""
 		set trackDuration to 0
 		set trackId to ""
+		set trackPersistentId to ""
 		set currentPos to 0
 		
 		if playerState is not "stopped" then
@@ -318,6 +425,7 @@ tell application "Music"
 				set trackAlbum to album of currentTrack
 				set trackDuration to duration of currentTrack
 				set trackId to database ID of currentTrack
+				set trackPersistentId to persistent ID of currentTrack
 				set currentPos to player position
 			end try
 		end if
@@ -326,9 +434,10 @@ tell application "Music"
 		set currentVolume to sound volume
 		set isShuffled to shuffle enabled
 		set repeatSetting to song repeat as string
+		set shuffleSetting to shuffle mode as string
 		
 		-- Build result string
-		return playerState & "|" & trackId & "|" & trackName & "|" & trackArtist & "|" & trackAlbum & "|" & trackDuration & "|" & currentPos & "|" & currentVolume & "|" & isShuffled & "|" & repeatSetting
+		return playerState & "|" & trackId & "|" & trackName & "|" & trackArtist & "|" & trackAlbum & "|" & trackDuration & "|" & currentPos & "|" & currentVolume & "|" & isShuffled & "|" & repeatSetting & "|" & shuffleSetting & "|" & trackPersistentId
 		
 	on error errMsg
 		return "ERROR: " & errMsg
@@ -336,7 +445,7 @@ tell application "Music"
 end tell
 	`
 	
-	out, err := get_script_output(script)
+	out, err := query_script_output(script)
 	if err != nil {
 		return PlaybackStatus{}, fmt.Errorf("AppleScript execution failed: %w", err)
 	}
@@ -364,6 +473,14 @@ end tell
 	volume, _ := strconv.Atoi(parts[7])
 	isShuffled := parts[8] == "true"
 	repeatMode := parts[9]
+	shuffleMode := ShuffleSongs
+	if len(parts) > 10 {
+		shuffleMode = parse_shuffle_mode(parts[10])
+	}
+	persistentId := ""
+	if len(parts) > 11 {
+		persistentId = parts[11]
+	}
 	
 	return PlaybackStatus{
 		Track: Track{
@@ -373,32 +490,50 @@ end tell
 			Album:    trackAlbum,
 			Duration: parts[5], // Keep as string for compatibility
 		},
-		IsPlaying:   playerState == "playing",
-		Position:    currentPos,
-		Duration:    trackDuration,
-		Volume:      volume,
-		Shuffle:     isShuffled,
-		RepeatMode:  repeatMode,
-		PlayerState: playerState,
+		IsPlaying:    playerState == "playing",
+		Position:     currentPos,
+		Duration:     trackDuration,
+		Volume:       volume,
+		Shuffle:      isShuffled,
+		ShuffleMode:  shuffleMode,
+		RepeatMode:   repeatMode,
+		PlayerState:  playerState,
+		PersistentId: persistentId,
 	}, nil
 }
 
 func (d *Daemon) PlayPlaylist(playlist Playlist) error {
-	script := fmt.Sprintf(`tell application "Music" to play playlist "%s"`, playlist.Name)
+	script := fmt.Sprintf(`tell application "Music" to play playlist %s`, as_string(playlist.Name))
 	return run_script(script)
 }
 
+// AddSongToPlaylist duplicates song into playlist, finding it by persistent ID or else by
+// name and artist (see AddTracksToPlaylist)
 func (d *Daemon) AddSongToPlaylist(song Track, playlist Playlist) error {
-	script := fmt.Sprintf(`tell application "Music" to duplicate (first track whose name is "%s") to playlist "%s"`, song.Name, playlist.Name)
-	return run_script(script)
+	count, err := d.AddTracksToPlaylist([]Track{song}, playlist.Name)
+	if err == nil && count == 0 {
+		err = fmt.Errorf("'%s' wasn't found in the library", song.Name)
+	}
+	return err
 }
 
+// RemoveSongFromPlaylist removes song from playlist, leaving it in the library (see
+// RemoveTracksFromPlaylist)
 func (d *Daemon) RemoveSongFromPlaylist(song Track, playlist Playlist) error {
-	script := fmt.Sprintf(`tell application "Music" to delete (first track whose name is "%s") of playlist "%s"`, song.Name, playlist.Name)
-	return run_script(script)
+	count, err := d.RemoveTracksFromPlaylist([]Track{song}, playlist.Name)
+	if err == nil && count == 0 {
+		err = fmt.Errorf("'%s' isn't in %s", song.Name, playlist.Name)
+	}
+	return err
 }
 
 func (d *Daemon) GetPlaylist(playlistName string) (Playlist, error) {
+	return get_playlist(playlistName, "playlist "+as_string(playlistName))
+}
+
+// get_playlist reads the tracks of the playlist the AppleScript reference ref points to,
+// naming the result playlistName
+func get_playlist(playlistName, ref string) (Playlist, error) {
 	// Fetch all track data in a single AppleScript call (much faster!)
 	script := fmt.Sprintf(`
 tell application "Music"
@@ -407,14 +542,30 @@ tell application "Music"
 	end if
 	
 	try
-		set targetPlaylist to playlist "%s"
+		set targetPlaylist to %s
 		set trackCount to count of tracks of targetPlaylist
 		
+		-- Smart and Genius playlists are read-only, report them before the tracks
+		set isSmart to false
+		try
+			set isSmart to smart of targetPlaylist
+		end try
+		try
+			if genius of targetPlaylist then set isSmart to true
+		end try
+		
 		if trackCount = 0 then
-			return "NO_TRACKS"
+			return (isSmart as string) & "##NO_TRACKS"
 		end if
 		
-		set outputResult to ""
+		set outputResult to (isSmart as string) & "##"
+		set currentDate to current date
+		
+		-- One Apple Event per column rather than per track for the optional columns
+		set trackGenres to genre of every track of targetPlaylist
+		set trackYears to year of every track of targetPlaylist
+		set trackPlays to played count of every track of targetPlaylist
+		set trackRatings to rating of every track of targetPlaylist
 		
 		-- Get all tracks in one loop
 		repeat with i from 1 to trackCount
@@ -424,7 +575,29 @@ tell application "Music"
 			set trackAlbum to album of currentTrack
 			set trackDuration to duration of currentTrack as string
 			
-			set outputResult to outputResult & trackName & "~" & trackArtist & "~" & trackAlbum & "~" & trackDuration
+			-- Flag tracks that were pulled from the catalog, lost their file, or only stream
+			set trackStatus to "ok"
+			try
+				if (cloud status of currentTrack as string) is in {"removed", "error", "no longer available", "prerelease"} then
+					set trackStatus to "unavailable"
+				end if
+			end try
+			try
+				if class of currentTrack is file track then
+					if location of currentTrack is missing value then set trackStatus to "unavailable"
+				else if trackStatus is "ok" then
+					set trackStatus to "cloud"
+				end if
+			end try
+			
+			set outputResult to outputResult & trackName & "~" & trackArtist & "~" & trackAlbum & "~" & trackDuration & "~" & trackStatus & "~" & (persistent ID of currentTrack)
+			-- Report the age in seconds, which unlike a formatted date doesn't depend on the locale
+			set trackAge to ""
+			try
+				set trackAge to (currentDate - (date added of currentTrack)) as string
+			end try
+			set outputResult to outputResult & "~" & trackAge
+			set outputResult to outputResult & "~" & (item i of trackGenres) & "~" & (item i of trackYears) & "~" & (item i of trackPlays) & "~" & (item i of trackRatings)
 			if i < trackCount then set outputResult to outputResult & "||"
 		end repeat
 		
@@ -433,13 +606,25 @@ tell application "Music"
 	on error errMsg
 		return "Error: " & errMsg
 	end try
-end tell`, playlistName)
+end tell`, ref)
 
-	out, err := get_script_output(script)
+	out, err := query_script_output(script)
 	if err != nil {
 		return Playlist{}, err
 	}
 
+	playlist, err := parse_playlist_output(playlistName, out)
+	if err != nil {
+		return Playlist{}, err
+	}
+	mark_offline_tracks(playlist.Tracks)
+	return playlist, nil
+}
+
+// parse_playlist_output parses the "<smart>##name~artist~album~duration~status~id~age~genre~year~plays~rating||..."
+// output of GetPlaylist. Everything after the duration is optional.
+func parse_playlist_output(playlistName string, out []byte) (Playlist, error) {
+	now := clock()
 	outputStr := strings.TrimSpace(string(out))
 	if strings.HasPrefix(outputStr, "Error:") {
 		return Playlist{}, fmt.Errorf("AppleScript error: %s", outputStr)
@@ -447,8 +632,14 @@ end tell`, playlistName)
 	if strings.HasPrefix(outputStr, "Music app is not running") {
 		return Playlist{}, fmt.Errorf("Music app is not running")
 	}
+
+	isSmart := false
+	if header, rest, found := strings.Cut(outputStr, "##"); found {
+		isSmart = header == "true"
+		outputStr = rest
+	}
 	if outputStr == "NO_TRACKS" {
-		return Playlist{Name: playlistName, Tracks: []Track{}}, nil
+		return Playlist{Name: playlistName, Tracks: []Track{}, Smart: isSmart}, nil
 	}
 
 	// Parse the track data
@@ -457,43 +648,88 @@ end tell`, playlistName)
 		trackStrings := strings.Split(outputStr, "||")
 		for _, trackStr := range trackStrings {
 			trackParts := strings.Split(trackStr, "~")
-			if len(trackParts) == 4 {
-				tracks = append(tracks, Track{
+			if len(trackParts) >= 4 && len(trackParts) <= 11 {
+				track := Track{
 					Name:     trackParts[0],
 					Artist:   trackParts[1],
 					Album:    trackParts[2],
 					Duration: trackParts[3],
-				})
+				}
+				if len(trackParts) >= 5 {
+					track.Status = parse_track_status(trackParts[4])
+				}
+				if len(trackParts) >= 6 {
+					track.Id = trackParts[5]
+				}
+				if len(trackParts) >= 7 {
+					if age, err := strconv.ParseFloat(strings.Replace(trackParts[6], ",", ".", 1), 64); err == nil {
+						track.Added = now.Add(-time.Duration(age) * time.Second)
+					}
+				}
+				if len(trackParts) == 11 {
+					track.Genre = trackParts[7]
+					track.Year, _ = strconv.Atoi(trackParts[8])
+					track.PlayCount, _ = strconv.Atoi(trackParts[9])
+					track.Rating, _ = strconv.Atoi(trackParts[10])
+				}
+				tracks = append(tracks, track)
 			}
 		}
 	}
 
-	return Playlist{Name: playlistName, Tracks: tracks}, nil
+	return Playlist{Name: playlistName, Tracks: tracks, Smart: isSmart}, nil
 }
 
 func (d *Daemon) GetAllPlaylistNames() ([]string, error) {
-	script := `tell application "Music" to get name of playlists`
-	out, err := get_script_output(script)
+	// One name per line, since playlist names can contain the ", " AppleScript puts between list items
+	script := `
+tell application "Music"
+	set AppleScript's text item delimiters to linefeed
+	set output to (name of playlists) as string
+	set AppleScript's text item delimiters to ""
+	return output
+end tell`
+	out, err := query_script_output(script)
 	if err != nil {
 		return []string{}, err
 	}
-	output := strings.TrimSpace(string(out))
-	if output == "" {
-		return []string{}, nil
+	return parse_playlist_names(string(out)), nil
+}
+
+// parse_playlist_names parses the one-name-per-line output of GetAllPlaylistNames
+func parse_playlist_names(output string) []string {
+	names := []string{}
+	for _, name := range strings.Split(output, "\n") {
+		name = strings.TrimSuffix(name, "\r")
+		if name != "" {
+			names = append(names, name)
+		}
 	}
-	// AppleScript returns comma-separated list
-	return strings.Split(output, ", "), nil
+	return names
 }
 
 func (d *Daemon) GetAllPlaylists() ([]Playlist, error) {
+	return d.GetAllPlaylistsWithProgress(nil)
+}
+
+// GetAllPlaylistsWithProgress is GetAllPlaylists, calling progress after each playlist
+// with how many of the total have been fetched so far
+func (d *Daemon) GetAllPlaylistsWithProgress(progress func(loaded, total int)) ([]Playlist, error) {
 	//TODO: Cache these in local storage and on run, check if there are changes by looking at the length of names
 	names, err := d.GetAllPlaylistNames()
 	if err != nil {
 		return []Playlist{}, err
 	}
+	if len(names) < 2 {
+		return []Playlist{}, nil
+	}
+	names = names[2:]
 	playlists := make([]Playlist, 0, len(names))
-	for _, name := range names[2:] {
+	for i, name := range names {
 		playlist, err := d.GetPlaylist(name)
+		if progress != nil {
+			progress(i+1, len(names))
+		}
 		if err != nil {
 			continue
 		}
@@ -503,6 +739,9 @@ func (d *Daemon) GetAllPlaylists() ([]Playlist, error) {
 }
 
 func (d *Daemon) GetQueueInfo() (*QueueInfo, error) {
+	if usingNativeQueue() {
+		return upNext.info(), nil
+	}
 	script := `
 tell application "Music"
 	if it is not running then
@@ -560,7 +799,7 @@ tell application "Music"
 	end try
 end tell`
 
-	out, err := get_script_output(script)
+	out, err := query_script_output(script)
 	if err != nil {
 		return nil, err
 	}
@@ -586,20 +825,22 @@ func (d *Daemon) CycleRepeatMode() error {
 		return fmt.Errorf("failed to get current repeat mode: %w", err)
 	}
 	
-	var nextMode string
+	return d.SetRepeat(NextRepeatMode(currentMode))
+}
+
+// NextRepeatMode returns the repeat mode after currentMode in the off → all → one cycle
+func NextRepeatMode(currentMode string) string {
 	switch strings.ToLower(currentMode) {
 	case "off":
-		nextMode = "all"
+		return "all"
 	case "all":
-		nextMode = "one"
+		return "one"
 	case "one":
-		nextMode = "off"
+		return "off"
 	default:
 		// Default to "all" if we get an unexpected mode
-		nextMode = "all"
+		return "all"
 	}
-	
-	return d.SetRepeat(nextMode)
 }
 
 // CreateOrUpdateQueue creates or updates the amtui Queue playlist with tracks from the specified playlist
@@ -612,7 +853,7 @@ func (d *Daemon) CreateOrUpdateQueue(sourcePlaylist string) error {
 	}
 	
 	// Escape quotes in playlist name
-	escapedSourcePlaylist := strings.ReplaceAll(sourcePlaylist, `"`, `\"`)
+	escapedSourcePlaylist := as_string(sourcePlaylist)
 	
 	script := fmt.Sprintf(`
 	tell application "Music"
@@ -622,7 +863,7 @@ func (d *Daemon) CreateOrUpdateQueue(sourcePlaylist string) error {
 		
 		try
 			-- Check if source playlist exists
-			set sourcePlaylist to playlist "%s"
+			set sourcePlaylist to playlist %s
 			set sourceTracks to tracks of sourcePlaylist
 			set trackCount to count of sourceTracks
 			
@@ -670,9 +911,9 @@ func (d *Daemon) CreateOrUpdateQueue(sourcePlaylist string) error {
 		set shuffle enabled to false
 		
 		if isShuffled then
-			return "SUCCESS: Created shuffled amtui Queue with " & trackCount & " tracks from " & "%s" & " (shuffle disabled for queue playback)"
+			return "SUCCESS: Created shuffled amtui Queue with " & trackCount & " tracks from " & %s & " (shuffle disabled for queue playback)"
 		else
-			return "SUCCESS: Created amtui Queue with " & trackCount & " tracks from " & "%s" & " in order (shuffle disabled for queue playback)"
+			return "SUCCESS: Created amtui Queue with " & trackCount & " tracks from " & %s & " in order (shuffle disabled for queue playback)"
 		end if
 			
 		on error errMsg
@@ -702,111 +943,7 @@ end tell
 // If shuffle is enabled, selected song plays first followed by shuffled remaining tracks
 // If shuffle is disabled, plays from selected position to end in order
 func (d *Daemon) CreateOrUpdateQueueWithSelectedFirst(sourcePlaylist string, selectedPosition int) error {
-	// First, get the current shuffle state from the daemon
-	currentShuffle, err := d.GetShuffle()
-	if err != nil {
-		return fmt.Errorf("failed to get shuffle state: %w", err)
-	}
-	
-	// Escape quotes in playlist name
-	escapedSourcePlaylist := strings.ReplaceAll(sourcePlaylist, `"`, `\"`)
-	
-	script := fmt.Sprintf(`
-	tell application "Music"
-		if it is not running then
-			error "Music app is not running"
-		end if
-		
-		try
-			-- Check if source playlist exists
-			set sourcePlaylist to playlist "%s"
-			set sourceTracks to tracks of sourcePlaylist
-			set trackCount to count of sourceTracks
-			
-			if %d < 1 or %d > trackCount then
-				error "Invalid selected position: " & %d
-			end if
-			
-			-- Get the selected track
-			set selectedTrack to track %d of sourcePlaylist
-			
-			-- Use the shuffle state passed from Go
-			set isShuffled to %v
-			
-			-- Check if amtui Queue exists, create if it doesn't
-			try
-				set queuePlaylist to user playlist "amtui Queue"
-				-- Clear existing tracks from queue
-				delete tracks of queuePlaylist
-			on error
-				-- Create the playlist if it doesn't exist
-				set queuePlaylist to (make new user playlist with properties {name:"amtui Queue"})
-			end try
-			
-			-- First, add the selected track at the top
-			duplicate selectedTrack to queuePlaylist
-			
-			if isShuffled then
-				-- When shuffle is ON: shuffle all remaining tracks (before and after selected)
-				set remainingIndices to {}
-				repeat with i from 1 to trackCount
-					if i is not %d then
-						set end of remainingIndices to i
-					end if
-				end repeat
-				
-				-- Shuffle the remaining indices using Fisher-Yates algorithm
-				set remainingCount to count of remainingIndices
-				repeat with i from remainingCount to 2 by -1
-					set j to (random number from 1 to i)
-					set temp to item i of remainingIndices
-					set item i of remainingIndices to item j of remainingIndices
-					set item j of remainingIndices to temp
-				end repeat
-				
-				-- Add the shuffled remaining tracks
-				repeat with trackIndex in remainingIndices
-					set sourceTrack to track trackIndex of sourcePlaylist
-					duplicate sourceTrack to queuePlaylist
-				end repeat
-			else
-				-- When shuffle is OFF: only add tracks from selected position to end
-				repeat with i from (%d + 1) to trackCount
-					set sourceTrack to track i of sourcePlaylist
-					duplicate sourceTrack to queuePlaylist
-				end repeat
-			end if
-			
-			-- Disable shuffle for queue playback (queue is pre-ordered)
-			set shuffle enabled to false
-			
-			if isShuffled then
-				return "SUCCESS: Created amtui Queue with selected song first, followed by " & (trackCount - 1) & " shuffled tracks from " & "%s" & " (shuffle disabled for queue playback)"
-			else
-				return "SUCCESS: Created amtui Queue with " & (count of tracks of queuePlaylist) & " tracks from " & "%s" & " in order (shuffle disabled for queue playback)"
-			end if
-			
-		on error errMsg
-			error "Failed to create queue: " & errMsg
-	end try
-end tell
-	`, escapedSourcePlaylist, selectedPosition, selectedPosition, selectedPosition, selectedPosition, currentShuffle, selectedPosition, selectedPosition, escapedSourcePlaylist, escapedSourcePlaylist)
-	
-	out, err := get_script_output(script)
-	if err != nil {
-		return fmt.Errorf("AppleScript execution failed: %w", err)
-	}
-	
-	output := strings.TrimSpace(string(out))
-	if strings.HasPrefix(output, "Failed to create queue:") {
-		return fmt.Errorf("Queue creation failed: %s", output[23:]) // Remove "Failed to create queue: " prefix
-	}
-	
-	if !strings.HasPrefix(output, "SUCCESS:") {
-		return fmt.Errorf("unexpected AppleScript output: %s", output)
-	}
-	
-	return nil
+	return d.CreateQueueWithStrategy(sourcePlaylist, selectedPosition, nil)
 }
 
 // PlayQueuePlaylist plays the amtui Queue playlist and optionally creates it from a source playlist
@@ -853,6 +990,9 @@ func (d *Daemon) PlayQueuePlaylist(sourcePlaylist string) error {
 
 // SkipToQueuePosition skips to a specific position (1-based) in the current queue
 func (d *Daemon) SkipToQueuePosition(position int) error {
+	if usingNativeQueue() {
+		return d.playNative(position - 1)
+	}
 	script := fmt.Sprintf(`
 tell application "Music"
 	if it is not running then
@@ -930,14 +1070,11 @@ tell application "Music"
 		-- Get current track info
 		try
 			set currentTrack to current track
-			set currentTrackName to name of currentTrack
-			set currentTrackArtist to artist of currentTrack
 			
 			-- Find the position of current track in the queue
 			set currentTrackPosition to 0
 			repeat with i from 1 to totalTracks
-				set queueTrack to track i of queuePlaylist
-				if (name of queueTrack is currentTrackName) and (artist of queueTrack is currentTrackArtist) then
+				if track i of queuePlaylist is currentTrack then
 					set currentTrackPosition to i
 					exit repeat
 				end if
@@ -985,12 +1122,11 @@ end tell
 
 // PlayNext adds a track to play next using Apple Music's native Play Next functionality
 func (d *Daemon) PlayNext(track Track) error {
-	// Debug: Print track info being added
-	fmt.Printf("🔍 Adding Play Next: '%s' by '%s'\n", track.Name, track.Artist)
+	slog.Debug("adding play next", "track", track.Name, "artist", track.Artist)
 	
 	// Escape quotes in track details
-	trackName := strings.ReplaceAll(track.Name, `"`, `\"`)
-	trackArtist := strings.ReplaceAll(track.Artist, `"`, `\"`)
+	trackName := as_string(track.Name)
+	trackArtist := as_string(track.Artist)
 
 	script := fmt.Sprintf(`
 tell application "Music"
@@ -1000,13 +1136,13 @@ tell application "Music"
 	
 	try
 		-- Search for track by name first, then filter by artist
-		set foundTracks to (tracks whose name is "%s")
+		set foundTracks to (tracks whose name is %s)
 		set targetTrack to missing value
 		
 		-- If we have an artist specified, try to find exact match
-		if "%s" is not "" then
+		if %s is not "" then
 			repeat with candidateTrack in foundTracks
-				if artist of candidateTrack is "%s" then
+				if artist of candidateTrack is %s then
 					set targetTrack to candidateTrack
 					exit repeat
 				end if
@@ -1019,7 +1155,7 @@ tell application "Music"
 		end if
 		
 		if targetTrack is missing value then
-			error "Track '" & "%s" & "' not found in your library"
+			error "Track '" & %s & "' not found in your library"
 		end if
 		
 		-- Use Apple Music's native "play next" functionality
@@ -1046,7 +1182,7 @@ end tell
 	}
 	
 	if strings.HasPrefix(output, "SUCCESS:") {
-		fmt.Printf("✅ %s\n", output[9:]) // Remove "SUCCESS: " prefix and print
+		slog.Debug(output[9:]) // Without the "SUCCESS: " prefix
 		return nil
 	}
 
@@ -1056,12 +1192,11 @@ end tell
 // AddToQueueAtPosition adds a track to the amtui Queue at a specific position (1-based)
 // It recreates the entire queue with the new track inserted at the correct position
 func (d *Daemon) AddToQueueAtPosition(track Track, position int) error {
-	// Debug: Print track info being added
-	fmt.Printf("🔍 Attempting to add to queue at position %d: '%s' by '%s'\n", position, track.Name, track.Artist)
+	slog.Debug("adding to queue", "position", position, "track", track.Name, "artist", track.Artist)
 	
 	// Escape quotes in track details
-	trackName := strings.ReplaceAll(track.Name, `"`, `\"`)
-	trackArtist := strings.ReplaceAll(track.Artist, `"`, `\"`)
+	trackName := as_string(track.Name)
+	trackArtist := as_string(track.Artist)
 
 script := fmt.Sprintf(`
 tell application "Music"
@@ -1071,13 +1206,13 @@ tell application "Music"
 	
 	try
 		-- Search for track by name first, then filter by artist
-		set foundTracks to (tracks whose name is "%s")
+		set foundTracks to (tracks whose name is %s)
 		set targetTrack to missing value
 		
 		-- If we have an artist specified, try to find exact match
-		if "%s" is not "" then
+		if %s is not "" then
 			repeat with candidateTrack in foundTracks
-				if artist of candidateTrack is "%s" then
+				if artist of candidateTrack is %s then
 					set targetTrack to candidateTrack
 					exit repeat
 				end if
@@ -1090,7 +1225,7 @@ tell application "Music"
 		end if
 		
 		if targetTrack is missing value then
-			error "Track '" & "%s" & "' not found in your library"
+			error "Track '" & %s & "' not found in your library"
 		end if
 		
 		-- Check if amtui Queue exists, create if it doesn't
@@ -1168,52 +1303,111 @@ end tell
 	}
 	
 	if strings.HasPrefix(output, "SUCCESS:") {
-		fmt.Printf("✅ %s\n", output[9:]) // Remove "SUCCESS: " prefix and print
+		slog.Debug(output[9:]) // Without the "SUCCESS: " prefix
 		return nil
 	}
 
 	return fmt.Errorf("unexpected AppleScript output: %s", output)
 }
 
-// AddToQueue adds a track to the end of the amtui Queue
-func (d *Daemon) AddToQueue(track Track) error {
-	// Debug: Print track info being added
-	fmt.Printf("🔍 Attempting to add to queue: '%s' by '%s'\n", track.Name, track.Artist)
-	
-	// Escape quotes in track details
-	trackName := strings.ReplaceAll(track.Name, `"`, `\"`)
-	trackArtist := strings.ReplaceAll(track.Artist, `"`, `\"`)
+// QueueAddResult reports whether a single track made it into the amtui Queue
+type QueueAddResult struct {
+	Track  Track
+	Added  bool
+	Reason string // Why the track wasn't added, e.g. "not in library"
+}
+
+// ReasonAmbiguous is the QueueAddResult reason for tracks without a persistent ID that
+// match several library tracks (live, remaster, single...). Use FindTrackMatches to pick one.
+const ReasonAmbiguous = "ambiguous"
+
+// Ambiguous returns the results that need the user to pick between several library tracks
+func (r QueueAddResults) Ambiguous() []QueueAddResult {
+	var ambiguous []QueueAddResult
+	for _, result := range r {
+		if result.Reason == ReasonAmbiguous {
+			ambiguous = append(ambiguous, result)
+		}
+	}
+	return ambiguous
+}
+
+// QueueAddResults is the outcome of a batched AddToQueue call
+type QueueAddResults []QueueAddResult
+
+// AddedCount returns how many tracks were added
+func (r QueueAddResults) AddedCount() int {
+	count := 0
+	for _, result := range r {
+		if result.Added {
+			count++
+		}
+	}
+	return count
+}
+
+// Summary describes the batch for the UI, e.g. "Added 8 of 9 tracks (1 not in library)"
+func (r QueueAddResults) Summary() string {
+	added := r.AddedCount()
+	if len(r) == 1 {
+		if added == 1 {
+			return fmt.Sprintf("Added '%s' to queue", r[0].Track.Name)
+		}
+		return fmt.Sprintf("Couldn't add '%s' to queue (%s)", r[0].Track.Name, r[0].Reason)
+	}
+
+	summary := fmt.Sprintf("Added %d of %d tracks", added, len(r))
+	if added == len(r) {
+		return summary
+	}
+
+	// Group failures by reason, keeping the order reasons first appear in
+	var reasons []string
+	counts := make(map[string]int)
+	for _, result := range r {
+		if result.Added {
+			continue
+		}
+		if counts[result.Reason] == 0 {
+			reasons = append(reasons, result.Reason)
+		}
+		counts[result.Reason]++
+	}
+	details := make([]string, len(reasons))
+	for i, reason := range reasons {
+		details[i] = fmt.Sprintf("%d %s", counts[reason], reason)
+	}
+	return summary + " (" + strings.Join(details, ", ") + ")"
+}
+
+// AddToQueue appends tracks to the end of the amtui Queue in a single AppleScript call
+// and reports per-track success. The error is only set if the batch as a whole failed.
+func (d *Daemon) AddToQueue(tracks []Track) (QueueAddResults, error) {
+	if len(tracks) == 0 {
+		return QueueAddResults{}, nil
+	}
+	if usingNativeQueue() {
+		upNext.append(track_entries(tracks)...)
+		results := make(QueueAddResults, len(tracks))
+		for i, track := range tracks {
+			results[i] = QueueAddResult{Track: track, Added: true}
+		}
+		return results, nil
+	}
+
+	// Build an AppleScript list of {persistent ID, name, artist} triples
+	items := make([]string, len(tracks))
+	for i, track := range tracks {
+		items[i] = as_list([]string{track.Id, track.Name, track.Artist})
+	}
 
 	script := fmt.Sprintf(`
 	tell application "Music"
 		if it is not running then
-			error "Music app is not running"
+			return "ERROR: Music app is not running"
 		end if
 		
 		try
-			-- Search for track by name first, then filter by artist
-			set foundTracks to (tracks whose name is "%s")
-			set targetTrack to missing value
-			
-			-- If we have an artist specified, try to find exact match
-			if "%s" is not "" then
-				repeat with candidateTrack in foundTracks
-					if artist of candidateTrack is "%s" then
-						set targetTrack to candidateTrack
-						exit repeat
-					end if
-				end repeat
-			end if
-			
-			-- If no exact artist match, take first track with matching name
-			if targetTrack is missing value and (count of foundTracks) > 0 then
-				set targetTrack to item 1 of foundTracks
-			end if
-			
-			if targetTrack is missing value then
-				error "Track '" & "%s" & "' not found in your library"
-			end if
-			
 			-- Check if amtui Queue exists, create if it doesn't
 			try
 				set targetPlaylist to user playlist "amtui Queue"
@@ -1222,167 +1416,567 @@ func (d *Daemon) AddToQueue(track Track) error {
 				set targetPlaylist to (make new user playlist with properties {name:"amtui Queue"})
 			end try
 			
-			-- Add track using duplicate
-			duplicate targetTrack to targetPlaylist
+			set resultLines to {}
+			repeat with requested in {%s}
+				set trackID to item 1 of requested
+				set trackName to item 2 of requested
+				set trackArtist to item 3 of requested
+				try
+					set targetTrack to missing value
+					set ambiguous to false
+					
+					-- Prefer the exact track by persistent ID
+					if trackID is not "" then
+						set foundTracks to (tracks of library playlist 1 whose persistent ID is trackID)
+						if (count of foundTracks) > 0 then set targetTrack to item 1 of foundTracks
+					end if
+					
+					-- Otherwise match by name and artist, refusing to guess between versions
+					if targetTrack is missing value then
+						set foundTracks to (tracks of library playlist 1 whose name is trackName)
+						if trackArtist is not "" then
+							set artistTracks to {}
+							repeat with candidateTrack in foundTracks
+								if artist of candidateTrack is trackArtist then set end of artistTracks to contents of candidateTrack
+							end repeat
+							if (count of artistTracks) > 0 then set foundTracks to artistTracks
+						end if
+						if (count of foundTracks) = 1 then
+							set targetTrack to item 1 of foundTracks
+						else if (count of foundTracks) > 1 then
+							set ambiguous to true
+						end if
+					end if
+					
+					if ambiguous then
+						set end of resultLines to "AMBIGUOUS"
+					else if targetTrack is missing value then
+						set end of resultLines to "MISSING"
+					else
+						duplicate targetTrack to targetPlaylist
+						set end of resultLines to "OK"
+					end if
+				on error errMsg
+					set end of resultLines to "FAILED: " & errMsg
+				end try
+			end repeat
 			
-			set trackInfo to (name of targetTrack) & " by " & (artist of targetTrack)
-			return "SUCCESS: Added " & trackInfo & " to amtui Queue"
+			set AppleScript's text item delimiters to linefeed
+			set output to resultLines as string
+			set AppleScript's text item delimiters to ""
+			return output
 			
 		on error errMsg
 			return "ERROR: " & errMsg
 		end try
 	end tell
-	`, trackName, trackArtist, trackArtist, trackName)
+	`, strings.Join(items, ", "))
 	
 	out, err := get_script_output(script)
 	if err != nil {
-		return fmt.Errorf("AppleScript execution failed: %w", err)
+		return nil, fmt.Errorf("AppleScript execution failed: %w", err)
 	}
 
 	output := strings.TrimSpace(string(out))
-	
 	if strings.HasPrefix(output, "ERROR:") {
-		return fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+		return nil, fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
 	}
-	
-	if strings.HasPrefix(output, "SUCCESS:") {
-		fmt.Printf("✅ %s\n", output[9:]) // Remove "SUCCESS: " prefix and print
-		return nil
+
+	return parse_queue_add_output(tracks, output)
+}
+
+// parse_queue_add_output matches the per-track status lines of AddToQueue to the requested tracks
+func parse_queue_add_output(tracks []Track, output string) (QueueAddResults, error) {
+	lines := strings.Split(output, "\n")
+	if len(lines) != len(tracks) {
+		return nil, fmt.Errorf("unexpected AppleScript output: expected %d results, got %d", len(tracks), len(lines))
+	}
+
+	results := make(QueueAddResults, len(tracks))
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		results[i] = QueueAddResult{Track: tracks[i]}
+		switch {
+		case line == "OK":
+			results[i].Added = true
+		case line == "MISSING":
+			results[i].Reason = "not in library"
+		case line == "AMBIGUOUS":
+			results[i].Reason = ReasonAmbiguous
+		case strings.HasPrefix(line, "FAILED: "):
+			results[i].Reason = strings.TrimPrefix(line, "FAILED: ")
+		default:
+			results[i].Reason = "unexpected result"
+		}
 	}
+	return results, nil
+}
 
-	return fmt.Errorf("unexpected AppleScript output: %s", output)
+// FindTrackMatches lists the library tracks matching a track's name (and artist, when any
+// match it), with persistent IDs so the right version can be added to the queue
+func (d *Daemon) FindTrackMatches(track Track) ([]Track, error) {
+	trackName := as_string(track.Name)
+	trackArtist := as_string(track.Artist)
+	selector := fmt.Sprintf(`set sourceTracks to (tracks of library playlist 1 whose name is %s)
+		set artistTracks to {}
+		repeat with candidateTrack in sourceTracks
+			if artist of candidateTrack is %s then set end of artistTracks to contents of candidateTrack
+		end repeat
+		if (count of artistTracks) > 0 then set sourceTracks to artistTracks`, trackName, trackArtist)
+	return selected_tracks(selector)
 }
 
-// SearchTracks searches for tracks in the Music library by name
-// Note: This searches your personal music library. To search the full Apple Music catalog,
-// you would need to add songs to your library first using the Music app.
-func (d *Daemon) SearchTracks(query string) ([]Track, error) {
-	// Validate and clean the search query
-	query = strings.TrimSpace(query)
-	if query == "" {
-		return []Track{}, nil
+// MoveQueueTrack moves the track at position from to position to (both 1-based) in the amtui Queue.
+// Only the tracks from the first affected position onwards are rebuilt, so the
+// currently playing track is left alone as long as both positions are after it.
+func (d *Daemon) MoveQueueTrack(from, to int) error {
+	if from == to {
+		return nil
 	}
-	
-	// Escape special characters for AppleScript
-	escapedQuery := strings.ReplaceAll(query, `\`, `\\`) // Escape backslashes first
-	escapedQuery = strings.ReplaceAll(escapedQuery, `"`, `\"`) // Escape double quotes
-	escapedQuery = strings.ReplaceAll(escapedQuery, `'`, `\'`) // Escape single quotes
-	escapedQuery = strings.ReplaceAll(escapedQuery, "\n", " ") // Replace newlines with spaces
-	escapedQuery = strings.ReplaceAll(escapedQuery, "\r", " ") // Replace carriage returns
-	
-	// Limit query length to prevent issues
-	if len(escapedQuery) > 100 {
-		escapedQuery = escapedQuery[:100]
+	if usingNativeQueue() {
+		return upNext.move(from, to)
 	}
-	
+	startPos := min(from, to)
+
 	script := fmt.Sprintf(`
 tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+	
 	try
-		if it is not running then
-			return "ERROR: Music app is not running. Please open the Music app and try again."
+		set queuePlaylist to user playlist "amtui Queue"
+		set trackCount to count of tracks of queuePlaylist
+		
+		if %d < 1 or %d > trackCount or %d < 1 or %d > trackCount then
+			return "ERROR: Position out of range for queue of " & trackCount & " tracks"
 		end if
 		
-		-- Search for tracks that contain the query in their name
-		-- Use "contains" for partial matching instead of exact matching
-		set foundTracks to (tracks whose name contains "%s")
-		set trackCount to count of foundTracks
+		-- Remember the tail of the queue by persistent ID
+		set tailIDs to {}
+		repeat with i from %d to trackCount
+			set end of tailIDs to persistent ID of track i of queuePlaylist
+		end repeat
 		
-		if trackCount = 0 then
-			return "NO_RESULTS"
-		end if
+		-- Take the moved track out and insert it at its new position
+		set movedIndex to %d - %d + 1
+		set insertIndex to %d - %d + 1
+		set movedID to item movedIndex of tailIDs
+		set remainingIDs to {}
+		repeat with i from 1 to count of tailIDs
+			if i is not movedIndex then set end of remainingIDs to item i of tailIDs
+		end repeat
+		set orderedIDs to {}
+		repeat with i from 1 to count of remainingIDs
+			if i = insertIndex then set end of orderedIDs to movedID
+			set end of orderedIDs to item i of remainingIDs
+		end repeat
+		if insertIndex > (count of remainingIDs) then set end of orderedIDs to movedID
+		
+		-- Rebuild the tail in the new order
+		repeat with i from trackCount to %d by -1
+			delete track i of queuePlaylist
+		end repeat
+		repeat with trackID in orderedIDs
+			duplicate (first track of library playlist 1 whose persistent ID is (contents of trackID)) to queuePlaylist
+		end repeat
 		
-		-- Limit results to prevent overwhelming output (max 50 tracks)
-		set maxResults to 50
-		if trackCount > maxResults then
-			set trackCount to maxResults
+		return "SUCCESS: Moved track " & %d & " to position " & %d
+		
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell
+	`, from, from, to, to, startPos, from, startPos, to, startPos, startPos, from, to)
+	
+	out, err := get_script_output(script)
+	if err != nil {
+		return fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+	
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	
+	if !strings.HasPrefix(output, "SUCCESS:") {
+		return fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	
+	return nil
+}
+
+// InsertNextInQueue inserts a track into the amtui Queue right after the currently playing
+// track, finding it by persistent ID or else by name and artist. Only the tracks after the
+// insertion point are rebuilt so playback isn't interrupted. When the queue isn't playing,
+// the track goes to the front.
+func (d *Daemon) InsertNextInQueue(track Track) error {
+	if usingNativeQueue() {
+		upNext.insertNext(nativeEntry{Track: track})
+		return nil
+	}
+
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+	
+	try
+		set matchingTracks to %s
+		if (count of matchingTracks) = 0 then
+			return "ERROR: Track '" & %s & "' not found in your library"
 		end if
+		set targetTrack to item 1 of matchingTracks
 		
-		set resultString to ""
-		set validTracks to 0
+		-- Check if amtui Queue exists, create if it doesn't
+		try
+			set queuePlaylist to user playlist "amtui Queue"
+		on error
+			set queuePlaylist to (make new user playlist with properties {name:"amtui Queue"})
+		end try
+		set trackCount to count of tracks of queuePlaylist
 		
-		repeat with i from 1 to trackCount
-			set currentTrack to item i of foundTracks
-			
-			try
-				set trackName to name of currentTrack
-				set trackArtist to artist of currentTrack
-				set trackAlbum to album of currentTrack
-				set trackDuration to duration of currentTrack
-				set trackId to persistent ID of currentTrack
-				
-				-- Only include tracks with valid data
-				if trackName is not missing value and trackArtist is not missing value then
-					-- Clean up any problematic characters in track data
-					if trackAlbum is missing value then set trackAlbum to "Unknown Album"
-					if trackDuration is missing value then set trackDuration to 0
-					
-					-- Format: trackId~trackName~trackArtist~trackAlbum~trackDuration
-					set trackInfo to trackId & "~" & trackName & "~" & trackArtist & "~" & trackAlbum & "~" & trackDuration
-					
-					set validTracks to validTracks + 1
-					if validTracks = 1 then
-						set resultString to trackInfo
-					else
-						set resultString to resultString & "|" & trackInfo
+		-- Find the currently playing track in the queue
+		set currentPosition to 0
+		try
+			if name of current playlist is "amtui Queue" then
+				set currentTrack to current track
+				repeat with i from 1 to trackCount
+					if track i of queuePlaylist is currentTrack then
+						set currentPosition to i
+						exit repeat
 					end if
-				end if
-			on error
-				-- Skip tracks that cause errors (might be unavailable or corrupted)
-				-- AppleScript will automatically continue to next iteration
-			end try
+				end repeat
+			end if
+		end try
+		set insertPosition to currentPosition + 1
+		
+		-- Remember the tail of the queue by persistent ID
+		set tailIDs to {}
+		repeat with i from insertPosition to trackCount
+			set end of tailIDs to persistent ID of track i of queuePlaylist
 		end repeat
 		
-		if validTracks = 0 then
-			return "NO_RESULTS"
-		else
-			return resultString
-		end if
+		-- Rebuild the tail with the new track at its head
+		repeat with i from trackCount to insertPosition by -1
+			delete track i of queuePlaylist
+		end repeat
+		duplicate targetTrack to queuePlaylist
+		repeat with trackID in tailIDs
+			duplicate (first track of library playlist 1 whose persistent ID is (contents of trackID)) to queuePlaylist
+		end repeat
+		
+		return "SUCCESS: Inserted " & (name of targetTrack) & " at position " & insertPosition
 		
 	on error errMsg
 		return "ERROR: " & errMsg
 	end try
 end tell
-	`, escapedQuery)
+	`, library_tracks_matching(track), as_string(track.Name))
 	
 	out, err := get_script_output(script)
 	if err != nil {
-		return nil, fmt.Errorf("AppleScript execution failed: %w", err)
+		return fmt.Errorf("AppleScript execution failed: %w", err)
 	}
 	
 	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
 	
-	if output == "NO_RESULTS" {
-		return []Track{}, nil // Return empty slice for no results
+	if !strings.HasPrefix(output, "SUCCESS:") {
+		return fmt.Errorf("unexpected AppleScript output: %s", output)
 	}
 	
+	slog.Debug(output[9:]) // Without the "SUCCESS: " prefix
+	return nil
+}
+
+// AddPlaylistToQueue appends every track of a playlist to the amtui Queue in one call
+// and returns the number of tracks added
+func (d *Daemon) AddPlaylistToQueue(playlistName string) (int, error) {
+	if usingNativeQueue() {
+		playlist, err := d.GetPlaylist(playlistName)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get playlist: %w", err)
+		}
+		positions := make([]int, len(playlist.Tracks))
+		for i := range positions {
+			positions[i] = i + 1
+		}
+		if skippingUnavailable() {
+			positions = playable_positions(positions, playlist.Tracks)
+		}
+		upNext.append(playlist_entries(playlistName, playlist.Tracks, positions)...)
+		return len(positions), nil
+	}
+	return add_tracks_to_queue(fmt.Sprintf(`set sourceTracks to every track of playlist %s`, as_string(playlistName)))
+}
+
+// AddAlbumToQueue appends every library track of an album to the amtui Queue in one call,
+// in disc and track order, and returns the number of tracks added. An empty artist matches
+// the album by name only.
+func (d *Daemon) AddAlbumToQueue(album, artist string) (int, error) {
+	escapedAlbum := as_string(album)
+	escapedArtist := as_string(artist)
+	selector := fmt.Sprintf(`set sourceTracks to every track of library playlist 1 whose album is %s`, escapedAlbum)
+	if artist != "" {
+		selector = fmt.Sprintf(`set sourceTracks to every track of library playlist 1 whose album is %s and (album artist is %s or artist is %s)`, escapedAlbum, escapedArtist, escapedArtist)
+	}
+	selector += `
+		
+		-- Put the album back in disc and track order
+		set sortKeys to {}
+		repeat with sourceTrack in sourceTracks
+			set end of sortKeys to (disc number of sourceTrack) * 1000 + (track number of sourceTrack)
+		end repeat
+		repeat with i from 2 to count of sourceTracks
+			set j to i
+			repeat while j > 1 and (item (j - 1) of sortKeys) > (item j of sortKeys)
+				set {item (j - 1) of sortKeys, item j of sortKeys} to {item j of sortKeys, item (j - 1) of sortKeys}
+				set {item (j - 1) of sourceTracks, item j of sourceTracks} to {item j of sourceTracks, item (j - 1) of sourceTracks}
+				set j to j - 1
+			end repeat
+		end repeat`
+	if usingNativeQueue() {
+		tracks, err := selected_tracks(selector)
+		if err != nil {
+			return 0, err
+		}
+		upNext.append(track_entries(tracks)...)
+		return len(tracks), nil
+	}
+	return add_tracks_to_queue(selector)
+}
+
+// selected_tracks runs selector (which must set sourceTracks) and returns the selected
+// tracks with their persistent IDs
+func selected_tracks(selector string) ([]Track, error) {
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+	
+	try
+		%s
+		
+		set trackLines to {}
+		repeat with sourceTrack in sourceTracks
+			set end of trackLines to (persistent ID of sourceTrack) & "~" & (name of sourceTrack) & "~" & (artist of sourceTrack) & "~" & (album of sourceTrack) & "~" & (duration of sourceTrack as string)
+		end repeat
+		
+		set AppleScript's text item delimiters to "||"
+		set output to trackLines as string
+		set AppleScript's text item delimiters to ""
+		return "SUCCESS:" & output
+		
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell
+	`, selector)
+	
+	out, err := query_script_output(script)
+	if err != nil {
+		return nil, fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+	
+	output := strings.TrimSpace(string(out))
 	if strings.HasPrefix(output, "ERROR:") {
 		return nil, fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
 	}
+	if !strings.HasPrefix(output, "SUCCESS:") {
+		return nil, fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
 	
-	// Parse the results
 	var tracks []Track
-	trackStrings := strings.Split(output, "|")
-	
-	for _, trackString := range trackStrings {
-		if trackString == "" {
+	for _, trackStr := range strings.Split(output[8:], "||") {
+		trackParts := strings.Split(trackStr, "~")
+		if len(trackParts) != 5 {
 			continue
 		}
+		tracks = append(tracks, Track{
+			Id:       trackParts[0],
+			Name:     trackParts[1],
+			Artist:   trackParts[2],
+			Album:    trackParts[3],
+			Duration: trackParts[4],
+		})
+	}
+	if len(tracks) == 0 {
+		return nil, errors.New("No tracks found")
+	}
+	return tracks, nil
+}
+
+// add_tracks_to_queue runs selector (which must set sourceTracks) and duplicates the
+// selected tracks into the amtui Queue in a single AppleScript call
+func add_tracks_to_queue(selector string) (int, error) {
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+	
+	try
+		%s
 		
-		parts := strings.Split(trackString, "~")
-		if len(parts) != 5 {
-			continue // Skip malformed entries
-		}
+		if (count of sourceTracks) = 0 then
+			return "ERROR: No tracks found"
+		end if
 		
-		track := Track{
-			Id:       parts[0],
-			Name:     parts[1],
-			Artist:   parts[2],
-			Album:    parts[3],
-			Duration: parts[4],
-		}
+		-- Check if amtui Queue exists, create if it doesn't
+		try
+			set queuePlaylist to user playlist "amtui Queue"
+		on error
+			set queuePlaylist to (make new user playlist with properties {name:"amtui Queue"})
+		end try
 		
-		tracks = append(tracks, track)
+		repeat with sourceTrack in sourceTracks
+			duplicate sourceTrack to queuePlaylist
+		end repeat
+		
+		return "SUCCESS: " & (count of sourceTracks)
+		
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell
+	`, selector)
+	
+	out, err := get_script_output(script)
+	if err != nil {
+		return 0, fmt.Errorf("AppleScript execution failed: %w", err)
 	}
 	
-	return tracks, nil
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return 0, fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	
+	if !strings.HasPrefix(output, "SUCCESS:") {
+		return 0, fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	
+	count, err := strconv.Atoi(strings.TrimSpace(output[8:]))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	return count, nil
 }
+
+// RemoveFromQueue deletes the track at position (1-based) from the amtui Queue
+func (d *Daemon) RemoveFromQueue(position int) error {
+	if usingNativeQueue() {
+		return upNext.remove(position)
+	}
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+	
+	try
+		set queuePlaylist to user playlist "amtui Queue"
+		set trackCount to count of tracks of queuePlaylist
+		
+		if %d < 1 or %d > trackCount then
+			return "ERROR: Position out of range for queue of " & trackCount & " tracks"
+		end if
+		
+		set trackName to name of track %d of queuePlaylist
+		delete track %d of queuePlaylist
+		
+		return "SUCCESS: Removed " & trackName & " from queue"
+		
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell
+	`, position, position, position, position)
+	
+	out, err := get_script_output(script)
+	if err != nil {
+		return fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+	
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	
+	if !strings.HasPrefix(output, "SUCCESS:") {
+		return fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	
+	return nil
+}
+
+// ClearUpcomingQueue deletes every track after the currently playing one from the amtui
+// Queue. When the queue isn't playing, all of its tracks are deleted.
+func (d *Daemon) ClearUpcomingQueue() error {
+	if usingNativeQueue() {
+		upNext.clearUpcoming()
+		return nil
+	}
+	script := `
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+	
+	try
+		set queuePlaylist to user playlist "amtui Queue"
+		set trackCount to count of tracks of queuePlaylist
+		
+		-- Find the currently playing track in the queue
+		set currentPosition to 0
+		try
+			if name of current playlist is "amtui Queue" then
+				set currentTrack to current track
+				repeat with i from 1 to trackCount
+					if track i of queuePlaylist is currentTrack then
+						set currentPosition to i
+						exit repeat
+					end if
+				end repeat
+			end if
+		end try
+		
+		repeat with i from trackCount to currentPosition + 1 by -1
+			delete track i of queuePlaylist
+		end repeat
+		
+		return "SUCCESS: Cleared " & (trackCount - currentPosition) & " tracks"
+		
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell
+	`
+	
+	out, err := get_script_output(script)
+	if err != nil {
+		return fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+	
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	
+	if !strings.HasPrefix(output, "SUCCESS:") {
+		return fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	
+	return nil
+}
+
+// Notify shows a macOS desktop notification
+func (d *Daemon) Notify(title, message string) error {
+	script := fmt.Sprintf(`display notification %s with title %s`, as_string(message), as_string(title))
+	return run_script(script)
+}
+
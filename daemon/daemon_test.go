@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 // Mock helper for testing osascript commands
@@ -657,6 +658,36 @@ func TestGetAllPlaylistNames(t *testing.T) {
 	}
 }
 
+func TestNextRepeatMode(t *testing.T) {
+	tests := map[string]string{"off": "all", "all": "one", "one": "off", "ONE": "off", "": "all", "weird": "all"}
+	for current, want := range tests {
+		if got := NextRepeatMode(current); got != want {
+			t.Errorf("NextRepeatMode(%q) = %q, want %q", current, got, want)
+		}
+	}
+}
+
+func TestParsePlaylistNames(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{"empty", "", []string{}},
+		{"trailing newline", "Library\nMusic\n", []string{"Library", "Music"}},
+		{"commas in names", "Library\nRock, Pop & More\nA, B, C\n", []string{"Library", "Rock, Pop & More", "A, B, C"}},
+		{"crlf", "Library\r\nFavourites\r\n", []string{"Library", "Favourites"}},
+		{"blank lines", "Library\n\nMusic", []string{"Library", "Music"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse_playlist_names(tt.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parse_playlist_names() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGetAllPlaylists(t *testing.T) {
 	d := &Daemon{}
 	got, err := d.GetAllPlaylists()
@@ -672,3 +703,141 @@ func TestDaemon_GetQueueInfo(t *testing.T) {
 		t.Errorf("GetQueueInfo() error = %v, got %v", err, got)
 	}
 }
+
+func TestParsePlaylistOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    Playlist
+		wantErr bool
+	}{
+		{
+			name:   "regular playlist",
+			output: "false##After Dark~Mr.Kitty~Time~259.147003173828||Sunset~The Midnight~Days of Thunder~301\n",
+			want: Playlist{Name: "Mix", Tracks: []Track{
+				{Name: "After Dark", Artist: "Mr.Kitty", Album: "Time", Duration: "259.147003173828"},
+				{Name: "Sunset", Artist: "The Midnight", Album: "Days of Thunder", Duration: "301"},
+			}},
+		},
+		{
+			name:   "smart playlist",
+			output: "true##After Dark~Mr.Kitty~Time~259",
+			want:   Playlist{Name: "Mix", Smart: true, Tracks: []Track{{Name: "After Dark", Artist: "Mr.Kitty", Album: "Time", Duration: "259"}}},
+		},
+		{
+			name:   "track availability",
+			output: "false##Gone~A~B~200~unavailable~01||Stream~A~B~180~cloud~02||Local~A~B~240~ok~03",
+			want: Playlist{Name: "Mix", Tracks: []Track{
+				{Id: "01", Name: "Gone", Artist: "A", Album: "B", Duration: "200", Status: TrackUnavailable},
+				{Id: "02", Name: "Stream", Artist: "A", Album: "B", Duration: "180", Status: TrackCloudOnly},
+				{Id: "03", Name: "Local", Artist: "A", Album: "B", Duration: "240", Status: TrackAvailable},
+			}},
+		},
+		{
+			name:   "date added",
+			output: "false##New~A~B~200~ok~01~86400||Unknown~A~B~180~ok~02~",
+			want: Playlist{Name: "Mix", Tracks: []Track{
+				{Id: "01", Name: "New", Artist: "A", Album: "B", Duration: "200", Added: time.Date(2024, 4, 30, 12, 0, 0, 0, time.UTC)},
+				{Id: "02", Name: "Unknown", Artist: "A", Album: "B", Duration: "180"},
+			}},
+		},
+		{
+			name:   "genre, year, plays and rating",
+			output: "false##Rated~A~B~200~ok~01~~Synthwave~2016~42~80||Unrated~A~B~180~ok~02~~~0~0~0",
+			want: Playlist{Name: "Mix", Tracks: []Track{
+				{Id: "01", Name: "Rated", Artist: "A", Album: "B", Duration: "200", Genre: "Synthwave", Year: 2016, PlayCount: 42, Rating: 80},
+				{Id: "02", Name: "Unrated", Artist: "A", Album: "B", Duration: "180"},
+			}},
+		},
+		{
+			name:   "empty smart playlist",
+			output: "true##NO_TRACKS",
+			want:   Playlist{Name: "Mix", Smart: true, Tracks: []Track{}},
+		},
+		{
+			name:    "apple script error",
+			output:  "Error: Can't get playlist \"Mix\".",
+			wantErr: true,
+		},
+	}
+
+	saved := clock
+	defer func() { clock = saved }()
+	clock = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parse_playlist_output("Mix", []byte(tt.output))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parse_playlist_output() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parse_playlist_output() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueueAddOutput(t *testing.T) {
+	tracks := []Track{{Name: "One"}, {Name: "Two"}, {Name: "Three"}, {Name: "Four"}}
+
+	results, err := parse_queue_add_output(tracks, "OK\nMISSING\nFAILED: can't duplicate\nAMBIGUOUS")
+	if err != nil {
+		t.Fatalf("parse_queue_add_output() error = %v", err)
+	}
+	want := QueueAddResults{
+		{Track: tracks[0], Added: true},
+		{Track: tracks[1], Reason: "not in library"},
+		{Track: tracks[2], Reason: "can't duplicate"},
+		{Track: tracks[3], Reason: ReasonAmbiguous},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("parse_queue_add_output() = %+v, want %+v", results, want)
+	}
+	if ambiguous := results.Ambiguous(); len(ambiguous) != 1 || ambiguous[0].Track.Name != "Four" {
+		t.Errorf("Ambiguous() = %+v, want only Four", ambiguous)
+	}
+
+	if _, err := parse_queue_add_output(tracks, "OK\nOK"); err == nil {
+		t.Errorf("parse_queue_add_output() expected error for mismatched result count")
+	}
+}
+
+func TestQueueAddResultsSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		results QueueAddResults
+		want    string
+	}{
+		{
+			name:    "single track added",
+			results: QueueAddResults{{Track: Track{Name: "Song"}, Added: true}},
+			want:    "Added 'Song' to queue",
+		},
+		{
+			name:    "single track missing",
+			results: QueueAddResults{{Track: Track{Name: "Song"}, Reason: "not in library"}},
+			want:    "Couldn't add 'Song' to queue (not in library)",
+		},
+		{
+			name:    "all added",
+			results: QueueAddResults{{Added: true}, {Added: true}},
+			want:    "Added 2 of 2 tracks",
+		},
+		{
+			name: "partial failure",
+			results: QueueAddResults{
+				{Added: true}, {Added: true}, {Reason: "not in library"}, {Reason: "not in library"}, {Reason: "timeout"},
+			},
+			want: "Added 2 of 5 tracks (2 not in library, 1 timeout)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.results.Summary(); got != tt.want {
+				t.Errorf("Summary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package daemon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// volumeWindow is how long volume changes are collected before being sent to Music
+const volumeWindow = 80 * time.Millisecond
+
+// deltaBatch collects deltas sent within one window and the outcome of applying them
+type deltaBatch struct {
+	delta  int
+	done   chan struct{}
+	result int
+	err    error
+}
+
+// deltaBatcher sums relative changes (e.g. volume steps from key-repeat) arriving
+// within a window and applies them with a single call. The window starts at the first
+// delta and isn't extended, so a held key still takes effect every window.
+type deltaBatcher struct {
+	mu     sync.Mutex
+	batch  *deltaBatch
+	window time.Duration
+	apply  func(delta int) (int, error)
+	after  func(d time.Duration, f func()) // time.AfterFunc, replaced in tests
+}
+
+func newDeltaBatcher(window time.Duration, apply func(delta int) (int, error)) *deltaBatcher {
+	return &deltaBatcher{
+		window: window,
+		apply:  apply,
+		after:  func(d time.Duration, f func()) { time.AfterFunc(d, f) },
+	}
+}
+
+// add queues delta and waits for the batch it joined to be applied, returning its result
+func (b *deltaBatcher) add(delta int) (int, error) {
+	b.mu.Lock()
+	batch := b.batch
+	if batch == nil {
+		batch = &deltaBatch{done: make(chan struct{})}
+		b.batch = batch
+		b.after(b.window, func() { b.flush(batch) })
+	}
+	batch.delta += delta
+	b.mu.Unlock()
+
+	<-batch.done
+	return batch.result, batch.err
+}
+
+func (b *deltaBatcher) flush(batch *deltaBatch) {
+	b.mu.Lock()
+	if b.batch == batch {
+		b.batch = nil
+	}
+	delta := batch.delta
+	b.mu.Unlock()
+
+	batch.result, batch.err = b.apply(delta)
+	close(batch.done)
+}
+
+// volumeChanges batches AdjustVolume calls
+var volumeChanges = newDeltaBatcher(volumeWindow, adjust_volume)
+
+// AdjustVolume changes the volume by delta percentage points, clamped to 0-100, and
+// returns the new volume. Calls made in quick succession are sent to Music as one change.
+func (d *Daemon) AdjustVolume(delta int) (int, error) {
+	return volumeChanges.add(delta)
+}
+
+// adjust_volume applies a summed volume change in a single script
+func adjust_volume(delta int) (int, error) {
+	if delta == 0 {
+		return (&Daemon{}).GetVolume()
+	}
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+
+	try
+		set newVolume to (sound volume) + (%d)
+		if newVolume > 100 then set newVolume to 100
+		if newVolume < 0 then set newVolume to 0
+		set sound volume to newVolume
+		return "SUCCESS:" & newVolume
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, delta)
+
+	out, err := get_script_output(script)
+	if err != nil {
+		return 0, fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return 0, fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	if !strings.HasPrefix(output, "SUCCESS:") {
+		return 0, fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	volume, err := strconv.Atoi(output[8:])
+	if err != nil {
+		return 0, fmt.Errorf("invalid volume %q: %w", output[8:], err)
+	}
+	return volume, nil
+}
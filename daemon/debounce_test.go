@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDeltaBatcherSumsDeltasWithinWindow(t *testing.T) {
+	var applied []int
+	b := newDeltaBatcher(time.Second, func(delta int) (int, error) {
+		applied = append(applied, delta)
+		return 50 + delta, nil
+	})
+	fire := make(chan func(), 1)
+	b.after = func(_ time.Duration, f func()) { fire <- f }
+
+	var wg sync.WaitGroup
+	results := make([]int, 3)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = b.add(10)
+		}()
+	}
+	// Let all three presses land in the batch before the window closes
+	flush := <-fire
+	for {
+		b.mu.Lock()
+		delta := b.batch.delta
+		b.mu.Unlock()
+		if delta == 30 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	flush()
+	wg.Wait()
+
+	if len(applied) != 1 || applied[0] != 30 {
+		t.Errorf("applied %v, want a single change of 30", applied)
+	}
+	for i, result := range results {
+		if result != 80 {
+			t.Errorf("add() #%d = %d, want 80", i, result)
+		}
+	}
+
+	// The next delta starts a new batch
+	go func() { (<-fire)() }()
+	if result, _ := b.add(-10); result != 40 {
+		t.Errorf("add(-10) = %d, want 40", result)
+	}
+}
@@ -0,0 +1,128 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SlowLatency is the script round trip above which Diagnose reports Music.app as slow
+const SlowLatency = time.Second
+
+// PermissionStatus is whether macOS lets amtui control Music
+type PermissionStatus int
+
+const (
+	PermissionUnknown PermissionStatus = iota // Music wasn't running, so it couldn't be checked
+	PermissionGranted
+	PermissionDenied
+)
+
+func (p PermissionStatus) String() string {
+	switch p {
+	case PermissionGranted:
+		return "granted"
+	case PermissionDenied:
+		return "denied"
+	default:
+		return "unknown"
+	}
+}
+
+// Diagnostics is a health report of amtui's connection to Music.app
+type Diagnostics struct {
+	Running    bool
+	Version    string // Music.app version
+	Permission PermissionStatus
+	Tracks     int           // Tracks in the library
+	Playlists  int           // Playlists, including Library and smart playlists
+	Latency    time.Duration // Round trip of a trivial script, osascript start-up included
+	Circuit    CircuitState
+	Problems   []string // Everything that looks wrong, in plain words
+}
+
+// Healthy reports whether Diagnose found nothing wrong
+func (d Diagnostics) Healthy() bool {
+	return len(d.Problems) == 0
+}
+
+// Diagnose checks whether Music.app is running, answers AppleScript and lets amtui control
+// it, and how long a round trip takes. Its scripts bypass the circuit breaker and the
+// script queue, so it still reports while amtui has stopped talking to Music.
+// Diagnose never launches Music.app.
+func (d *Daemon) Diagnose() Diagnostics {
+	diag := Diagnostics{Circuit: CircuitStatus()}
+	if diag.Circuit.Open {
+		diag.Problems = append(diag.Problems, fmt.Sprintf("Calls to Music are paused after %d failures: %s", diag.Circuit.Failures, diag.Circuit.Diagnosis))
+	}
+
+	running, _, err := diagnostic_script(`application "Music" is running`)
+	if err != nil {
+		diag.Problems = append(diag.Problems, fmt.Sprintf("osascript failed: %v", err))
+		return diag
+	}
+	diag.Running = running == "true"
+	if !diag.Running {
+		diag.Problems = append(diag.Problems, "Music.app is not running")
+		return diag
+	}
+
+	version, latency, err := diagnostic_script(`tell application "Music" to get version`)
+	diag.Latency = latency
+	switch {
+	case errors.Is(err, ErrAutomationDenied):
+		diag.Permission = PermissionDenied
+		diag.Problems = append(diag.Problems, "macOS doesn't allow amtui to control Music (Automation permission)")
+		return diag
+	case err != nil:
+		diag.Problems = append(diag.Problems, fmt.Sprintf("Music.app doesn't answer AppleScript: %v", err))
+		return diag
+	}
+	diag.Permission = PermissionGranted
+	diag.Version = version
+	if latency > SlowLatency {
+		diag.Problems = append(diag.Problems, fmt.Sprintf("Music.app is slow to answer (%s per call)", latency.Round(time.Millisecond)))
+	}
+
+	counts, _, err := diagnostic_script(`tell application "Music" to return ((count of tracks of library playlist 1) as string) & "~" & ((count of playlists) as string)`)
+	if err == nil {
+		diag.Tracks, diag.Playlists, err = parse_library_counts(counts)
+	}
+	if err != nil {
+		diag.Problems = append(diag.Problems, fmt.Sprintf("Couldn't read the library: %v", err))
+	}
+	return diag
+}
+
+// diagnostic_script runs script directly, returning its trimmed output and how long it took
+func diagnostic_script(script string) (string, time.Duration, error) {
+	start := time.Now()
+	out, err := exec.Command("osascript", "-e", script).Output()
+	elapsed := time.Since(start)
+	failure := script_failure(out, err)
+	if automation_denied(out, failure) {
+		return "", elapsed, ErrAutomationDenied
+	}
+	if failure != nil {
+		return "", elapsed, failure
+	}
+	return strings.TrimSpace(string(out)), elapsed, nil
+}
+
+// parse_library_counts parses the "tracks~playlists" output of the library check
+func parse_library_counts(output string) (tracks, playlists int, err error) {
+	parts := strings.Split(output, "~")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("unexpected output: %s", output)
+	}
+	if tracks, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, fmt.Errorf("invalid track count: %w", err)
+	}
+	if playlists, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, fmt.Errorf("invalid playlist count: %w", err)
+	}
+	return tracks, playlists, nil
+}
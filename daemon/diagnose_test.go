@@ -0,0 +1,28 @@
+package daemon
+
+import "testing"
+
+func TestParseLibraryCounts(t *testing.T) {
+	tracks, playlists, err := parse_library_counts("4217~38")
+	if err != nil {
+		t.Fatalf("parse_library_counts() error = %v", err)
+	}
+	if tracks != 4217 || playlists != 38 {
+		t.Errorf("parse_library_counts() = %d, %d, want 4217, 38", tracks, playlists)
+	}
+
+	for _, output := range []string{"", "4217", "many~38", "4217~some", "1~2~3"} {
+		if _, _, err := parse_library_counts(output); err == nil {
+			t.Errorf("parse_library_counts(%q) returned no error", output)
+		}
+	}
+}
+
+func TestDiagnosticsHealthy(t *testing.T) {
+	if !(Diagnostics{Running: true}).Healthy() {
+		t.Error("Healthy() = false for a report without problems")
+	}
+	if (Diagnostics{Problems: []string{"Music.app is not running"}}).Healthy() {
+		t.Error("Healthy() = true for a report with problems")
+	}
+}
@@ -0,0 +1,141 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrNotDownloaded is returned for tracks that only stream and have no file on disk
+var ErrNotDownloaded = errors.New("track is not downloaded")
+
+// TrackLocation returns the POSIX path of a track's file, found by persistent ID or
+// else by name and artist. It returns ErrNotDownloaded for tracks without a file.
+func (d *Daemon) TrackLocation(track Track) (string, error) {
+	selector := library_tracks_matching(track)
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+
+	try
+		set matchingTracks to %s
+		if (count of matchingTracks) = 0 then
+			return "ERROR: Track not found in library"
+		end if
+		set t to item 1 of matchingTracks
+		if class of t is not file track then
+			return "INFO: Not downloaded"
+		end if
+		if location of t is missing value then
+			return "INFO: Not downloaded"
+		end if
+		return "SUCCESS:" & POSIX path of (location of t)
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, selector)
+
+	out, err := query_script_output(script)
+	if err != nil {
+		return "", fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+
+	output := strings.TrimSpace(string(out))
+	switch {
+	case strings.HasPrefix(output, "INFO:"):
+		return "", ErrNotDownloaded
+	case strings.HasPrefix(output, "ERROR:"):
+		return "", fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	case !strings.HasPrefix(output, "SUCCESS:"):
+		return "", fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	return output[8:], nil
+}
+
+// library_tracks_matching is an AppleScript reference to the library tracks with track's
+// persistent ID, or with its name and artist when it has none
+func library_tracks_matching(track Track) string {
+	if track.Id != "" {
+		return fmt.Sprintf(`(tracks of library playlist 1 whose persistent ID is %s)`, as_string(track.Id))
+	}
+	return fmt.Sprintf(`(tracks of library playlist 1 whose name is %s and artist is %s)`,
+		as_string(track.Name), as_string(track.Artist))
+}
+
+// RevealInMusic brings Music.app to the front with track selected in the library
+func (d *Daemon) RevealInMusic(track Track) error {
+	script := fmt.Sprintf(`
+tell application "Music"
+	try
+		set matchingTracks to %s
+		if (count of matchingTracks) = 0 then
+			return "ERROR: Track not found in library"
+		end if
+		reveal item 1 of matchingTracks
+		activate
+		return "SUCCESS:"
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, library_tracks_matching(track))
+	return reveal(script)
+}
+
+// RevealPlayingInMusic brings Music.app to the front with the playing track selected
+func (d *Daemon) RevealPlayingInMusic() error {
+	return reveal(`
+tell application "Music"
+	try
+		if player state is stopped then
+			return "ERROR: Nothing is playing"
+		end if
+		reveal current track
+		activate
+		return "SUCCESS:"
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`)
+}
+
+// reveal runs one of the Reveal scripts
+func reveal(script string) error {
+	out, err := get_script_output(script)
+	if err != nil {
+		return fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return fmt.Errorf("AppleScript error: %s", strings.TrimSpace(output[6:]))
+	}
+	return nil
+}
+
+// OpenURL opens url with its default app, e.g. a web page in the browser
+func OpenURL(url string) error {
+	if err := exec.Command("open", url).Run(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", url, err)
+	}
+	return nil
+}
+
+// RevealInFinder opens a Finder window with the file at path selected
+func RevealInFinder(path string) error {
+	if err := exec.Command("open", "-R", path).Run(); err != nil {
+		return fmt.Errorf("failed to reveal %s: %w", path, err)
+	}
+	return nil
+}
+
+// CopyToClipboard puts text on the macOS clipboard
+func CopyToClipboard(text string) error {
+	cmd := exec.Command("pbcopy")
+	cmd.Stdin = strings.NewReader(text)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+	return nil
+}
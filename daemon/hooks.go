@@ -0,0 +1,97 @@
+package daemon
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// HookAction is what an automation hook wants done with playback
+type HookAction int
+
+const (
+	HookNone HookAction = iota
+	HookPause
+	HookResume
+)
+
+// PauseWhileRunningHook pauses Music while any watched app (e.g. zoom.us) is running
+// and resumes it once they have all quit, but only if the hook was what paused it
+type PauseWhileRunningHook struct {
+	Apps   []string
+	paused bool // Music was paused by the hook and should resume afterwards
+	// The user resumed playback while a watched app runs, so the hook leaves Music
+	// alone until they have all quit
+	overridden bool
+}
+
+// Step advances the hook given whether a watched app is running and whether Music is playing
+func (h *PauseWhileRunningHook) Step(appRunning, playing bool) HookAction {
+	switch {
+	case !appRunning:
+		h.overridden = false
+		if h.paused {
+			h.paused = false
+			if !playing {
+				return HookResume
+			}
+		}
+	case h.overridden || !playing:
+	case h.paused:
+		// The user resumed playback during the meeting, leave it alone from now on
+		h.paused = false
+		h.overridden = true
+	default:
+		h.paused = true
+		return HookPause
+	}
+	return HookNone
+}
+
+// RunningApps returns which of names currently have a running process
+func RunningApps(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	out, err := exec.Command("ps", "-Ac", "-o", "command=").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+	return running_apps(string(out), names), nil
+}
+
+// running_apps matches process names from ps output against names, case-insensitively
+func running_apps(psOutput string, names []string) []string {
+	processes := make(map[string]bool)
+	for _, line := range strings.Split(psOutput, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			processes[strings.ToLower(filepath.Base(line))] = true
+		}
+	}
+
+	var running []string
+	for _, name := range names {
+		if processes[strings.ToLower(name)] {
+			running = append(running, name)
+		}
+	}
+	return running
+}
+
+// IsPlaying reports whether Music is currently playing
+func (d *Daemon) IsPlaying() (bool, error) {
+	script := `
+tell application "Music"
+	if it is not running then
+		return "stopped"
+	end if
+	return player state as string
+end tell`
+	out, err := query_script_output(script)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(out)) == "playing", nil
+}
@@ -0,0 +1,67 @@
+package daemon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPauseWhileRunningHook(t *testing.T) {
+	type step struct {
+		appRunning, playing bool
+		want                HookAction
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "pauses during meeting and resumes afterwards",
+			steps: []step{
+				{appRunning: false, playing: true, want: HookNone},
+				{appRunning: true, playing: true, want: HookPause},
+				{appRunning: true, playing: false, want: HookNone},
+				{appRunning: false, playing: false, want: HookResume},
+				{appRunning: false, playing: true, want: HookNone},
+			},
+		},
+		{
+			name: "doesn't resume music that wasn't playing",
+			steps: []step{
+				{appRunning: true, playing: false, want: HookNone},
+				{appRunning: false, playing: false, want: HookNone},
+			},
+		},
+		{
+			name: "respects the user resuming during the meeting",
+			steps: []step{
+				{appRunning: true, playing: true, want: HookPause},
+				{appRunning: true, playing: true, want: HookNone},
+				{appRunning: true, playing: true, want: HookNone},
+				{appRunning: true, playing: false, want: HookNone},
+				{appRunning: true, playing: true, want: HookNone},
+				// Only once the app has quit is the next meeting paused for again
+				{appRunning: false, playing: true, want: HookNone},
+				{appRunning: true, playing: true, want: HookPause},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &PauseWhileRunningHook{Apps: []string{"zoom.us"}}
+			for i, s := range tt.steps {
+				if got := hook.Step(s.appRunning, s.playing); got != s.want {
+					t.Fatalf("step %d: Step(%v, %v) = %v, want %v", i, s.appRunning, s.playing, got, s.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRunningApps(t *testing.T) {
+	ps := "launchd\nzoom.us\n/Applications/Slack.app/Contents/MacOS/Slack\nMusic\n"
+	got := running_apps(ps, []string{"zoom.us", "slack", "Microsoft Teams"})
+	if want := []string{"zoom.us", "slack"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("running_apps() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ErrMusicNotReady is returned when Music.app doesn't become scriptable in time
+var ErrMusicNotReady = errors.New("Music.app didn't start in time")
+
+// ErrMusicNotRunning is the failure recorded when a script finds Music.app closed
+var ErrMusicNotRunning = errors.New("Music app is not running")
+
+// launchPollInterval is how often EnsureRunning checks whether Music.app is ready
+const launchPollInterval = 500 * time.Millisecond
+
+// IsRunning reports whether Music.app is open, without launching it
+func (d *Daemon) IsRunning() (bool, error) {
+	out, err := query_script_output(`application "Music" is running`)
+	if err != nil {
+		return false, fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+	return strings.TrimSpace(string(out)) == "true", nil
+}
+
+// NotRunning reports whether err means Music.app is closed. While calls are paused
+// after the circuit breaker tripped, it tells from the failure that tripped it.
+func NotRunning(err error) bool {
+	if errors.Is(err, ErrCircuitOpen) {
+		err = CircuitStatus().LastError
+	}
+	if err == nil {
+		return false
+	}
+	// Scripts report it in-band, osascript itself as error -600
+	return errors.Is(err, ErrMusicNotRunning) || strings.Contains(err.Error(), "not running") ||
+		strings.Contains(err.Error(), "-600")
+}
+
+// Ready reports whether Music.app is open and answers AppleScript, without launching
+// it. Like EnsureRunning it bypasses the circuit breaker, and closes it once Music answers.
+func (d *Daemon) Ready() bool {
+	running, _, err := diagnostic_script(`application "Music" is running`)
+	if err != nil || running != "true" || music_scriptable() != nil {
+		return false
+	}
+	breaker.record(nil)
+	return true
+}
+
+// EnsureRunning launches Music.app if it isn't open and waits up to timeout
+// until it answers AppleScript. It returns ErrMusicNotReady on timeout.
+func (d *Daemon) EnsureRunning(timeout time.Duration) error {
+	switch err := music_scriptable(); {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrAutomationDenied):
+		// Music is running but won't take orders from us, launching again won't help
+		return err
+	}
+	// open -g launches Music in the background, so the terminal keeps focus
+	if err := exec.Command("open", "-g", "-a", "Music").Run(); err != nil {
+		return fmt.Errorf("failed to launch Music.app: %w", err)
+	}
+	if err := wait_until_ready(music_scriptable, timeout, launchPollInterval, time.Sleep); err != nil {
+		return err
+	}
+	// Music is back, so clear failures recorded while it was closed
+	breaker.record(nil)
+	return nil
+}
+
+// music_scriptable checks that Music.app answers AppleScript. It bypasses the
+// circuit breaker, as failures are expected while the app is still launching.
+func music_scriptable() error {
+	out, err := exec.Command("osascript", "-e", `tell application "Music" to get version`).Output()
+	failure := script_failure(out, err)
+	if automation_denied(out, failure) {
+		return ErrAutomationDenied
+	}
+	if failure != nil {
+		return failure
+	}
+	if strings.TrimSpace(string(out)) == "" {
+		return errors.New("Music.app returned no version")
+	}
+	return nil
+}
+
+// wait_until_ready calls ready every interval until it succeeds or timeout has passed
+func wait_until_ready(ready func() error, timeout, interval time.Duration, sleep func(time.Duration)) error {
+	var waited time.Duration
+	for {
+		err := ready()
+		if err == nil {
+			return nil
+		}
+		if waited >= timeout {
+			return fmt.Errorf("%w: %v", ErrMusicNotReady, err)
+		}
+		sleep(interval)
+		waited += interval
+	}
+}
@@ -0,0 +1,66 @@
+package daemon
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitUntilReady(t *testing.T) {
+	var slept time.Duration
+	sleep := func(d time.Duration) { slept += d }
+
+	calls := 0
+	ready := func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("Connection is invalid")
+		}
+		return nil
+	}
+	if err := wait_until_ready(ready, 5*time.Second, time.Second, sleep); err != nil {
+		t.Fatalf("wait_until_ready() error = %v", err)
+	}
+	if calls != 3 || slept != 2*time.Second {
+		t.Errorf("wait_until_ready() made %d calls and slept %v, want 3 calls and 2s", calls, slept)
+	}
+
+	slept = 0
+	never := func() error { return errors.New("Connection is invalid") }
+	err := wait_until_ready(never, 3*time.Second, time.Second, sleep)
+	if !errors.Is(err, ErrMusicNotReady) {
+		t.Errorf("wait_until_ready() error = %v, want ErrMusicNotReady", err)
+	}
+	if slept != 3*time.Second {
+		t.Errorf("wait_until_ready() slept %v, want 3s", slept)
+	}
+}
+
+func TestNotRunning(t *testing.T) {
+	saved := breaker
+	defer func() { breaker = saved }()
+	breaker = newCircuitBreaker(1, time.Minute)
+
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: nil, want: false},
+		{err: ErrMusicNotRunning, want: true},
+		{err: errors.New("AppleScript error: Music app is not running"), want: true},
+		{err: errors.New("execution error: Music got an error: Application isn't running. (-600)"), want: true},
+		{err: ErrAutomationDenied, want: false},
+		{err: ErrCircuitOpen, want: false},
+	}
+	for _, tt := range tests {
+		if got := NotRunning(tt.err); got != tt.want {
+			t.Errorf("NotRunning(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+
+	// Once the breaker trips on Music being closed, paused calls count as not running
+	breaker.record(ErrMusicNotRunning)
+	if !NotRunning(ErrCircuitOpen) {
+		t.Error("NotRunning(ErrCircuitOpen) = false after tripping on a closed Music")
+	}
+}
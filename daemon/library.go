@@ -0,0 +1,360 @@
+package daemon
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DeleteTrackFromLibrary removes the track with the given persistent ID from the library,
+// and with it from every playlist. Downloaded files are left for Music to clean up.
+func (d *Daemon) DeleteTrackFromLibrary(id string) error {
+	if id == "" {
+		return fmt.Errorf("track has no persistent ID")
+	}
+
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+
+	try
+		set matchingTracks to (tracks of library playlist 1 whose persistent ID is %s)
+		if (count of matchingTracks) = 0 then
+			return "ERROR: Track not found in library"
+		end if
+		set trackName to name of item 1 of matchingTracks
+		delete item 1 of matchingTracks
+		return "SUCCESS: Deleted " & trackName
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, as_string(id))
+
+	out, err := get_script_output(script)
+	if err != nil {
+		return fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	if !strings.HasPrefix(output, "SUCCESS:") {
+		return fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	library_search.invalidate()
+	return nil
+}
+
+// TrackMetadata holds the tags that can be edited from amtui
+type TrackMetadata struct {
+	Name   string
+	Artist string
+	Album  string
+	Genre  string
+	Year   int // 0 clears the year
+}
+
+// trackProperty is one property assignment, with value already an AppleScript literal
+type trackProperty struct {
+	name  string
+	value string
+}
+
+// property_assignments renders "set <property> of t to <value>" lines for a track variable t
+func property_assignments(props []trackProperty) string {
+	lines := make([]string, len(props))
+	for i, prop := range props {
+		lines[i] = fmt.Sprintf("set %s of t to %s", prop.name, prop.value)
+	}
+	return strings.Join(lines, "\n\t\t")
+}
+
+// set_track_properties changes properties of the library track with the given persistent ID
+func set_track_properties(id string, props []trackProperty) error {
+	if id == "" {
+		return fmt.Errorf("track has no persistent ID")
+	}
+
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+
+	try
+		set matchingTracks to (tracks of library playlist 1 whose persistent ID is %s)
+		if (count of matchingTracks) = 0 then
+			return "ERROR: Track not found in library"
+		end if
+		set t to item 1 of matchingTracks
+		%s
+		return "SUCCESS"
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, as_string(id), property_assignments(props))
+
+	out, err := get_script_output(script)
+	if err != nil {
+		return fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	if !strings.HasPrefix(output, "SUCCESS") {
+		return fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	library_search.invalidate()
+	return nil
+}
+
+// metadata_properties lists the assignments that write meta to a track
+func metadata_properties(meta TrackMetadata) []trackProperty {
+	return []trackProperty{
+		{"name", as_string(meta.Name)},
+		{"artist", as_string(meta.Artist)},
+		{"album", as_string(meta.Album)},
+		{"genre", as_string(meta.Genre)},
+		{"year", fmt.Sprintf("%d", meta.Year)},
+	}
+}
+
+// SetTrackMetadata writes all editable tags of a track in a single call
+func (d *Daemon) SetTrackMetadata(id string, meta TrackMetadata) error {
+	if strings.TrimSpace(meta.Name) == "" {
+		return fmt.Errorf("track name can't be empty")
+	}
+	if meta.Year < 0 {
+		return fmt.Errorf("invalid year %d", meta.Year)
+	}
+	return set_track_properties(id, metadata_properties(meta))
+}
+
+// SetTrackName renames a track
+func (d *Daemon) SetTrackName(id, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("track name can't be empty")
+	}
+	return set_track_properties(id, []trackProperty{{"name", as_string(name)}})
+}
+
+// SetTrackArtist changes a track's artist
+func (d *Daemon) SetTrackArtist(id, artist string) error {
+	return set_track_properties(id, []trackProperty{{"artist", as_string(artist)}})
+}
+
+// SetTrackAlbum changes a track's album
+func (d *Daemon) SetTrackAlbum(id, album string) error {
+	return set_track_properties(id, []trackProperty{{"album", as_string(album)}})
+}
+
+// SetTrackGenre changes a track's genre
+func (d *Daemon) SetTrackGenre(id, genre string) error {
+	return set_track_properties(id, []trackProperty{{"genre", as_string(genre)}})
+}
+
+// SetTrackYear changes a track's year; 0 clears it
+func (d *Daemon) SetTrackYear(id string, year int) error {
+	if year < 0 {
+		return fmt.Errorf("invalid year %d", year)
+	}
+	return set_track_properties(id, []trackProperty{{"year", fmt.Sprintf("%d", year)}})
+}
+
+// LibraryName is the name of the Playlist GetLibrary returns
+const LibraryName = "Library"
+
+// GetLibrary reads every track in the library, for browsing it by album, artist or song
+func (d *Daemon) GetLibrary() (Playlist, error) {
+	return get_playlist(LibraryName, "library playlist 1")
+}
+
+// TrackGroup is an album or artist and its tracks, in library order
+type TrackGroup struct {
+	Name   string
+	Tracks []Track
+}
+
+// GroupByAlbum groups tracks by album, sorted by name ignoring case
+func GroupByAlbum(tracks []Track) []TrackGroup {
+	return group_tracks(tracks, "Unknown Album", func(t Track) string { return t.Album })
+}
+
+// GroupByArtist groups tracks by artist, sorted by name ignoring case
+func GroupByArtist(tracks []Track) []TrackGroup {
+	return group_tracks(tracks, "Unknown Artist", func(t Track) string { return t.Artist })
+}
+
+func group_tracks(tracks []Track, unknown string, key func(Track) string) []TrackGroup {
+	index := make(map[string]int)
+	var groups []TrackGroup
+	for _, track := range tracks {
+		name := strings.TrimSpace(key(track))
+		if name == "" {
+			name = unknown
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, TrackGroup{Name: name})
+		}
+		groups[i].Tracks = append(groups[i].Tracks, track)
+	}
+	sort.SliceStable(groups, func(a, b int) bool {
+		return strings.ToLower(groups[a].Name) < strings.ToLower(groups[b].Name)
+	})
+	return groups
+}
+
+// PlayTracksWithStrategy plays the track at position (1-based) of tracks, which needn't
+// belong to one playlist, building the rest of the queue with strategy (nil follows the
+// current shuffle setting). name describes the tracks in errors.
+func (d *Daemon) PlayTracksWithStrategy(name string, tracks []Track, position int, strategy QueueStrategy) error {
+	order, strategy, err := d.queueOrder(name, tracks, position, strategy)
+	if err != nil {
+		return err
+	}
+	ordered := make([]Track, len(order))
+	for i, pos := range order {
+		ordered[i] = tracks[pos-1]
+	}
+
+	if usingNativeQueue() {
+		upNext.replace(track_entries(ordered))
+		return d.playNative(0)
+	}
+
+	ids := make([]string, len(ordered))
+	for i, track := range ordered {
+		ids[i] = track.Id
+	}
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+
+	try
+		try
+			set queuePlaylist to user playlist "amtui Queue"
+			delete tracks of queuePlaylist
+		on error
+			set queuePlaylist to (make new user playlist with properties {name:"amtui Queue"})
+		end try
+
+		-- Tracks were ordered by the %s strategy in Go
+		repeat with trackID in %s
+			try
+				duplicate (first track of library playlist 1 whose persistent ID is (contents of trackID)) to queuePlaylist
+			end try
+		end repeat
+
+		return "SUCCESS: Created amtui Queue with " & (count of tracks of queuePlaylist) & " tracks"
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, strategy.Name(), as_list(ids))
+
+	out, err := get_script_output(script)
+	if err != nil {
+		return fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	if !strings.HasPrefix(output, "SUCCESS:") {
+		return fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	return play_amtui_queue()
+}
+
+// GetLibraryPage reads up to limit library tracks starting at offset (0-based), along with
+// how many tracks the library has in all. Properties are read for the whole page at once
+// and the availability checks of GetPlaylist are skipped, so large libraries can be paged
+// through quickly.
+func (d *Daemon) GetLibraryPage(offset, limit int) (tracks []Track, total int, err error) {
+	if offset < 0 || limit < 1 {
+		return nil, 0, fmt.Errorf("invalid library page %d+%d", offset, limit)
+	}
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "Music app is not running"
+	end if
+
+	try
+		set lib to library playlist 1
+		set total to count of tracks of lib
+		set firstIndex to %d
+		if firstIndex > total then
+			return (total as string) & "##false##NO_TRACKS"
+		end if
+		set lastIndex to %d
+		if lastIndex > total then set lastIndex to total
+
+		set pageTracks to a reference to (tracks firstIndex thru lastIndex of lib)
+		set trackNames to name of pageTracks
+		set trackArtists to artist of pageTracks
+		set trackAlbums to album of pageTracks
+		set trackDurations to duration of pageTracks
+		set trackIDs to persistent ID of pageTracks
+		set trackAdded to date added of pageTracks
+		set trackGenres to genre of pageTracks
+		set trackYears to year of pageTracks
+		set trackPlays to played count of pageTracks
+		set trackRatings to rating of pageTracks
+		set currentDate to current date
+
+		set rows to {}
+		repeat with i from 1 to count of trackNames
+			-- Report the age in seconds, which unlike a formatted date doesn't depend on the locale
+			set trackAge to ""
+			try
+				set trackAge to (currentDate - (item i of trackAdded)) as string
+			end try
+			set end of rows to (item i of trackNames) & "~" & (item i of trackArtists) & "~" & (item i of trackAlbums) & "~" & ((item i of trackDurations) as string) & "~ok~" & (item i of trackIDs) & "~" & trackAge & "~" & (item i of trackGenres) & "~" & (item i of trackYears) & "~" & (item i of trackPlays) & "~" & (item i of trackRatings)
+		end repeat
+
+		set AppleScript's text item delimiters to "||"
+		set outputResult to (total as string) & "##false##" & (rows as string)
+		set AppleScript's text item delimiters to ""
+		return outputResult
+	on error errMsg
+		set AppleScript's text item delimiters to ""
+		return "Error: " & errMsg
+	end try
+end tell`, offset+1, offset+limit)
+
+	out, err := query_script_output(script)
+	if err != nil {
+		return nil, 0, err
+	}
+	return parse_library_page(out)
+}
+
+// parse_library_page parses the "<total>##" prefixed playlist output of GetLibraryPage
+func parse_library_page(out []byte) ([]Track, int, error) {
+	header, rest, found := strings.Cut(strings.TrimSpace(string(out)), "##")
+	total, err := strconv.Atoi(header)
+	if !found || err != nil {
+		// Errors come without the total, in the same shape as GetPlaylist's
+		_, err := parse_playlist_output(LibraryName, out)
+		if err == nil {
+			err = fmt.Errorf("unexpected AppleScript output: %s", strings.TrimSpace(string(out)))
+		}
+		return nil, 0, err
+	}
+	page, err := parse_playlist_output(LibraryName, []byte(rest))
+	if err != nil {
+		return nil, 0, err
+	}
+	return page.Tracks, total, nil
+}
@@ -0,0 +1,121 @@
+package daemon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LibraryStats summarizes the whole Music library
+type LibraryStats struct {
+	Tracks   int
+	Albums   int // Distinct album and album artist pairs
+	Artists  int // Distinct track artists
+	Duration time.Duration
+	Size     int64 // Bytes on disk, streamed-only tracks count as 0
+}
+
+// GetLibraryStats counts the tracks, albums and artists in the library and adds up their
+// length and size. Properties are fetched as whole lists, which is much faster than
+// walking the tracks in AppleScript, and the counting happens in Go.
+func (d *Daemon) GetLibraryStats() (LibraryStats, error) {
+	script := `
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+
+	try
+		set libraryTracks to tracks of library playlist 1
+		set AppleScript's text item delimiters to character id 31
+		set artistList to (artist of libraryTracks) as string
+		set albumArtistList to (album artist of libraryTracks) as string
+		set albumList to (album of libraryTracks) as string
+		set durationList to (duration of libraryTracks) as string
+		set sizeList to (size of libraryTracks) as string
+		set AppleScript's text item delimiters to ""
+		set sectionBreak to character id 30
+		return "SUCCESS:" & artistList & sectionBreak & albumArtistList & sectionBreak & albumList & sectionBreak & durationList & sectionBreak & sizeList
+
+	on error errMsg
+		set AppleScript's text item delimiters to ""
+		return "ERROR: " & errMsg
+	end try
+end tell`
+
+	out, err := query_script_output(script)
+	if err != nil {
+		return LibraryStats{}, fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return LibraryStats{}, fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	if !strings.HasPrefix(output, "SUCCESS:") {
+		return LibraryStats{}, fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	return parse_library_stats(output[8:])
+}
+
+// Separators of the GetLibraryStats output, the ASCII record and unit separators, which
+// unlike "||" or line breaks can't turn up in names
+const (
+	statsSectionSeparator = "\x1e"
+	statsValueSeparator   = "\x1f"
+)
+
+// parse_library_stats parses the "artists␞album artists␞albums␞durations␞sizes" output of
+// GetLibraryStats, where each section holds one value per track, separated by ␟
+func parse_library_stats(output string) (LibraryStats, error) {
+	sections := strings.Split(output, statsSectionSeparator)
+	if len(sections) != 5 {
+		return LibraryStats{}, fmt.Errorf("invalid library stats output: %s", output)
+	}
+	lines := func(section string) []string {
+		if section == "" {
+			return nil
+		}
+		return strings.Split(section, statsValueSeparator)
+	}
+	artists, albumArtists, albums := lines(sections[0]), lines(sections[1]), lines(sections[2])
+	durations, sizes := lines(sections[3]), lines(sections[4])
+	if len(albumArtists) != len(artists) || len(albums) != len(artists) || len(durations) != len(artists) || len(sizes) != len(artists) {
+		return LibraryStats{}, fmt.Errorf("library stats lists have different lengths")
+	}
+
+	stats := LibraryStats{Tracks: len(artists)}
+	distinctArtists := make(map[string]bool)
+	distinctAlbums := make(map[[2]string]bool)
+	var seconds float64
+	for i, artist := range artists {
+		if artist != "" {
+			distinctArtists[artist] = true
+		}
+		if albums[i] != "" {
+			// Compilations credit each track to a different artist, so group by album artist when set
+			albumArtist := albumArtists[i]
+			if albumArtist == "" {
+				albumArtist = artist
+			}
+			distinctAlbums[[2]string{albumArtist, albums[i]}] = true
+		}
+		seconds += parse_real(durations[i])
+		stats.Size += int64(parse_real(sizes[i]))
+	}
+	stats.Artists = len(distinctArtists)
+	stats.Albums = len(distinctAlbums)
+	stats.Duration = time.Duration(seconds * float64(time.Second))
+	return stats, nil
+}
+
+// parse_real reads an AppleScript number, which may use a decimal comma or exponent
+// notation depending on locale and size. Missing or malformed values count as 0.
+func parse_real(value string) float64 {
+	n, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(value), ",", ".", 1), 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
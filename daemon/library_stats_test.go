@@ -0,0 +1,51 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLibraryStats(t *testing.T) {
+	// Names may hold "||" and line breaks
+	output := strings.Join([]string{
+		"Daft Punk\x1fDaft Punk\x1fQueen\x1fVarious",
+		"Daft Punk\x1fDaft Punk\x1f\x1fVarious Artists",
+		"Discovery\x1fDiscovery\x1fGreatest || Hits\x1fNow\n42",
+		"320.5\x1f212,25\x1f354\x1f180",
+		"8000000\x1f6000000\x1f0\x1f1.2E+7",
+	}, "\x1e")
+
+	got, err := parse_library_stats(output)
+	if err != nil {
+		t.Fatalf("parse_library_stats() error = %v", err)
+	}
+	want := LibraryStats{
+		Tracks:   4,
+		Albums:   3,
+		Artists:  3,
+		Duration: time.Duration((320.5 + 212.25 + 354 + 180) * float64(time.Second)),
+		Size:     26000000,
+	}
+	if got != want {
+		t.Errorf("parse_library_stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseLibraryStatsEmptyLibrary(t *testing.T) {
+	got, err := parse_library_stats("\x1e\x1e\x1e\x1e")
+	if err != nil {
+		t.Fatalf("parse_library_stats() error = %v", err)
+	}
+	if got != (LibraryStats{}) {
+		t.Errorf("parse_library_stats() = %+v, want zero stats", got)
+	}
+}
+
+func TestParseLibraryStatsInvalid(t *testing.T) {
+	for _, output := range []string{"", "a\x1eb", "A\x1fB\x1eA\x1eX\x1fY\x1e1\x1f2\x1e1\x1f2"} {
+		if _, err := parse_library_stats(output); err == nil {
+			t.Errorf("parse_library_stats(%q) returned no error", output)
+		}
+	}
+}
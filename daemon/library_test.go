@@ -0,0 +1,63 @@
+package daemon
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestPropertyAssignments(t *testing.T) {
+	got := property_assignments(metadata_properties(TrackMetadata{
+		Name:   `Say "Hi"`,
+		Artist: `AC\DC`,
+		Album:  "Live",
+		Genre:  "Rock",
+		Year:   1991,
+	}))
+	want := `set name of t to "Say \"Hi\""
+		set artist of t to "AC\\DC"
+		set album of t to "Live"
+		set genre of t to "Rock"
+		set year of t to 1991`
+	if got != want {
+		t.Errorf("property_assignments() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGroupByAlbum(t *testing.T) {
+	tracks := []Track{
+		{Name: "1", Album: "zebra"},
+		{Name: "2", Album: "Apple"},
+		{Name: "3", Album: ""},
+		{Name: "4", Album: "zebra"},
+	}
+	groups := GroupByAlbum(tracks)
+	var got []string
+	for _, group := range groups {
+		got = append(got, group.Name+":"+strconv.Itoa(len(group.Tracks)))
+	}
+	want := []string{"Apple:1", "Unknown Album:1", "zebra:2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupByAlbum() = %v, want %v", got, want)
+	}
+	if groups[2].Tracks[0].Name != "1" || groups[2].Tracks[1].Name != "4" {
+		t.Errorf("GroupByAlbum() changed the order of tracks: %v", groups[2].Tracks)
+	}
+}
+
+func TestParseLibraryPage(t *testing.T) {
+	tracks, total, err := parse_library_page([]byte("52000##false##One~A~X~200~ok~ID1~||Two~B~Y~180.5~ok~ID2~~Pop~1999~3~60\n"))
+	if err != nil {
+		t.Fatalf("parse_library_page() error = %v", err)
+	}
+	if total != 52000 || len(tracks) != 2 || tracks[1].Name != "Two" || tracks[1].Id != "ID2" || tracks[1].Year != 1999 {
+		t.Errorf("parse_library_page() = %v, %d", tracks, total)
+	}
+
+	if tracks, total, err := parse_library_page([]byte("3##false##NO_TRACKS")); err != nil || total != 3 || len(tracks) != 0 {
+		t.Errorf("parse_library_page() past the end = %v, %d, %v", tracks, total, err)
+	}
+	if _, _, err := parse_library_page([]byte("Music app is not running")); err == nil {
+		t.Errorf("parse_library_page() should fail when Music isn't running")
+	}
+}
@@ -0,0 +1,278 @@
+package daemon
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Queue backends, as used in the config file
+const (
+	// QueueBackendPlaylist keeps the queue in the "amtui Queue" user playlist
+	QueueBackendPlaylist = "playlist"
+	// QueueBackendNative keeps the queue in amtui and plays it track by track with
+	// Music's "play ... with once", so no playlist is created in the library
+	QueueBackendNative = "native"
+)
+
+// NativeQueueName is reported as the queue name while the native backend is playing
+const NativeQueueName = "Up Next (amtui)"
+
+var (
+	queueBackendMu sync.Mutex
+	queueBackend   = QueueBackendPlaylist
+)
+
+// QueueBackendNames returns the names accepted by SetQueueBackend
+func QueueBackendNames() []string {
+	return []string{QueueBackendPlaylist, QueueBackendNative}
+}
+
+// SetQueueBackend selects where the queue lives. An empty name selects the playlist backend.
+func SetQueueBackend(name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = QueueBackendPlaylist
+	}
+	if name != QueueBackendPlaylist && name != QueueBackendNative {
+		return fmt.Errorf("unknown queue backend %q (valid: %s)", name, strings.Join(QueueBackendNames(), ", "))
+	}
+	queueBackendMu.Lock()
+	defer queueBackendMu.Unlock()
+	queueBackend = name
+	return nil
+}
+
+func usingNativeQueue() bool {
+	queueBackendMu.Lock()
+	defer queueBackendMu.Unlock()
+	return queueBackend == QueueBackendNative
+}
+
+// nativeEntry is a queued track and how to find it again in Music
+type nativeEntry struct {
+	Track
+	playlist string // Source playlist, or "" to look the track up in the library
+	index    int    // 1-based position in playlist
+}
+
+// nativeQueue is the in-memory queue used by the native backend
+type nativeQueue struct {
+	mu      sync.Mutex
+	entries []nativeEntry
+	current int  // Index into entries of the playing track, -1 before playback starts
+	started bool // A track was started and hasn't been seen to finish yet
+	// The started track was seen playing. Until then a "stopped" status is from a poll
+	// made before the play script ran, not the end of the track.
+	confirmed bool
+}
+
+var upNext = &nativeQueue{current: -1}
+
+// replace swaps in a new queue that starts playing at its first entry
+func (q *nativeQueue) replace(entries []nativeEntry) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.entries = entries
+	q.current = -1
+	q.started = false
+	q.confirmed = false
+}
+
+func (q *nativeQueue) append(entries ...nativeEntry) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.entries = append(q.entries, entries...)
+}
+
+// insertNext puts entry right after the playing track (or at the front before playback)
+func (q *nativeQueue) insertNext(entry nativeEntry) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	at := q.current + 1
+	q.entries = append(q.entries[:at], append([]nativeEntry{entry}, q.entries[at:]...)...)
+}
+
+// remove deletes the entry at position (1-based); the playing track can't be removed
+func (q *nativeQueue) remove(position int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	i := position - 1
+	if i < 0 || i >= len(q.entries) {
+		return fmt.Errorf("position %d out of range for queue of %d tracks", position, len(q.entries))
+	}
+	if i == q.current {
+		return fmt.Errorf("can't remove the playing track")
+	}
+	q.entries = append(q.entries[:i], q.entries[i+1:]...)
+	if i < q.current {
+		q.current--
+	}
+	return nil
+}
+
+// clearUpcoming deletes every entry after the playing track, or all of them before playback
+func (q *nativeQueue) clearUpcoming() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.entries = q.entries[:q.current+1]
+}
+
+// move reorders the entry at from to to (both 1-based), keeping the playing track's index in sync
+func (q *nativeQueue) move(from, to int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	f, t := from-1, to-1
+	if f < 0 || f >= len(q.entries) || t < 0 || t >= len(q.entries) {
+		return fmt.Errorf("position out of range for queue of %d tracks", len(q.entries))
+	}
+	entry := q.entries[f]
+	q.entries = append(q.entries[:f], q.entries[f+1:]...)
+	q.entries = append(q.entries[:t], append([]nativeEntry{entry}, q.entries[t:]...)...)
+
+	switch {
+	case q.current == f:
+		q.current = t
+	case f < q.current && t >= q.current:
+		q.current--
+	case f > q.current && t <= q.current:
+		q.current++
+	}
+	return nil
+}
+
+// start marks the entry at index as playing and returns it
+func (q *nativeQueue) start(index int) (nativeEntry, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if index < 0 || index >= len(q.entries) {
+		return nativeEntry{}, fmt.Errorf("position %d out of range for queue of %d tracks", index+1, len(q.entries))
+	}
+	q.current = index
+	q.started = true
+	q.confirmed = false
+	return q.entries[index], nil
+}
+
+// finished reports the index to play next once the started track has been seen
+// playing and has then stopped, or -1
+func (q *nativeQueue) finished(status PlaybackStatus) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.started {
+		return -1
+	}
+	if !q.confirmed {
+		// Tracks found by name have no ID to compare, any playing track confirms them
+		id := q.entries[q.current].Id
+		q.confirmed = status.PlayerState != "stopped" && (id == "" || status.PersistentId == id)
+		return -1
+	}
+	if status.PlayerState != "stopped" {
+		return -1
+	}
+	q.started = false
+	if q.current+1 >= len(q.entries) {
+		return -1
+	}
+	return q.current + 1
+}
+
+// info describes the queue in the same shape GetQueueInfo uses for playlists
+func (q *nativeQueue) info() *QueueInfo {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	tracks := make([]Track, len(q.entries))
+	for i, entry := range q.entries {
+		tracks[i] = entry.Track
+	}
+	info := &QueueInfo{
+		QueueName:       NativeQueueName,
+		Tracks:          tracks,
+		CurrentPosition: q.current + 1,
+		TotalTracks:     len(tracks),
+	}
+	if q.current >= 0 && q.current < len(tracks) {
+		current := tracks[q.current]
+		info.CurrentTrack = &current
+	}
+	return info
+}
+
+// playlist_entries turns positions of a playlist's tracks into queue entries
+func playlist_entries(playlistName string, tracks []Track, positions []int) []nativeEntry {
+	entries := make([]nativeEntry, len(positions))
+	for i, pos := range positions {
+		entries[i] = nativeEntry{Track: tracks[pos-1], playlist: playlistName, index: pos}
+	}
+	return entries
+}
+
+// track_entries queues tracks found by persistent ID, or by name and artist
+func track_entries(tracks []Track) []nativeEntry {
+	entries := make([]nativeEntry, len(tracks))
+	for i, track := range tracks {
+		entries[i] = nativeEntry{Track: track}
+	}
+	return entries
+}
+
+// playNative starts the entry at index (0-based) and lets Music stop when it ends
+func (d *Daemon) playNative(index int) error {
+	entry, err := upNext.start(index)
+	if err != nil {
+		return err
+	}
+
+	var target string
+	switch {
+	case entry.Id != "":
+		target = fmt.Sprintf(`some track of library playlist 1 whose persistent ID is %s`, as_string(entry.Id))
+	case entry.playlist != "":
+		target = fmt.Sprintf(`track %d of playlist %s`, entry.index, as_string(entry.playlist))
+	default:
+		target = fmt.Sprintf(`first track of library playlist 1 whose name is %s and artist is %s`,
+			as_string(entry.Name), as_string(entry.Artist))
+	}
+
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+
+	try
+		set shuffle enabled to false
+		play (%s) with once
+		return "SUCCESS"
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, target)
+
+	out, err := get_script_output(script)
+	if err != nil {
+		return fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	if !strings.HasPrefix(output, "SUCCESS") {
+		return fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	return nil
+}
+
+// AdvanceNativeQueue plays the next queued track once the previous one has finished.
+// Call it with every playback status poll; it does nothing with the playlist backend.
+func (d *Daemon) AdvanceNativeQueue(status PlaybackStatus) error {
+	if !usingNativeQueue() {
+		return nil
+	}
+	next := upNext.finished(status)
+	if next < 0 {
+		return nil
+	}
+	return d.playNative(next)
+}
@@ -0,0 +1,139 @@
+package daemon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func nativeQueueNames(q *nativeQueue) []string {
+	var names []string
+	for _, entry := range q.entries {
+		names = append(names, entry.Name)
+	}
+	return names
+}
+
+func TestNativeQueueEditing(t *testing.T) {
+	q := &nativeQueue{current: -1}
+	q.replace(track_entries([]Track{{Name: "A"}, {Name: "B"}, {Name: "C"}, {Name: "D"}}))
+	if _, err := q.start(1); err != nil {
+		t.Fatalf("start() error = %v", err)
+	}
+
+	q.insertNext(nativeEntry{Track: Track{Name: "N"}})
+	if got, want := nativeQueueNames(q), []string{"A", "B", "N", "C", "D"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("after insertNext = %v, want %v", got, want)
+	}
+
+	// Moving a track across the playing one keeps the playing index on "B"
+	if err := q.move(1, 4); err != nil {
+		t.Fatalf("move() error = %v", err)
+	}
+	if got, want := nativeQueueNames(q), []string{"B", "N", "C", "A", "D"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("after move = %v, want %v", got, want)
+	}
+	if q.entries[q.current].Name != "B" {
+		t.Errorf("current = %s, want B", q.entries[q.current].Name)
+	}
+
+	if err := q.remove(1); err == nil {
+		t.Errorf("remove() of the playing track should fail")
+	}
+	if err := q.remove(3); err != nil {
+		t.Fatalf("remove() error = %v", err)
+	}
+	if got, want := nativeQueueNames(q), []string{"B", "N", "A", "D"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after remove = %v, want %v", got, want)
+	}
+
+	info := q.info()
+	if info.CurrentPosition != 1 || info.CurrentTrack == nil || info.CurrentTrack.Name != "B" || !info.Editable() {
+		t.Errorf("info() = %+v, want B playing at position 1", info)
+	}
+}
+
+func TestNativeQueueClearUpcoming(t *testing.T) {
+	q := &nativeQueue{current: -1}
+	q.replace(track_entries([]Track{{Name: "A"}, {Name: "B"}, {Name: "C"}}))
+
+	q.start(1)
+	q.clearUpcoming()
+	if got, want := nativeQueueNames(q), []string{"A", "B"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after clearUpcoming = %v, want %v", got, want)
+	}
+
+	// Before playback starts everything goes
+	q.replace(track_entries([]Track{{Name: "A"}, {Name: "B"}}))
+	q.clearUpcoming()
+	if got := nativeQueueNames(q); len(got) != 0 {
+		t.Errorf("after clearUpcoming before playback = %v, want none", got)
+	}
+}
+
+func TestNativeQueueFinished(t *testing.T) {
+	q := &nativeQueue{current: -1}
+	q.replace(track_entries([]Track{{Name: "A"}, {Name: "B"}}))
+	stopped := PlaybackStatus{PlayerState: "stopped"}
+	playing := PlaybackStatus{PlayerState: "playing"}
+
+	if next := q.finished(stopped); next != -1 {
+		t.Errorf("finished() before playback = %d, want -1", next)
+	}
+	q.start(0)
+	if next := q.finished(playing); next != -1 {
+		t.Errorf("finished() while playing = %d, want -1", next)
+	}
+	if next := q.finished(stopped); next != 1 {
+		t.Errorf("finished() after first track = %d, want 1", next)
+	}
+	// Only advances once per finished track
+	if next := q.finished(stopped); next != -1 {
+		t.Errorf("finished() twice = %d, want -1", next)
+	}
+	q.start(1)
+	q.finished(playing)
+	if next := q.finished(stopped); next != -1 {
+		t.Errorf("finished() at end of queue = %d, want -1", next)
+	}
+}
+
+func TestNativeQueueFinishedStaleStatus(t *testing.T) {
+	q := &nativeQueue{current: -1}
+	q.replace(track_entries([]Track{{Id: "AAA", Name: "A"}, {Id: "BBB", Name: "B"}, {Id: "CCC", Name: "C"}}))
+
+	q.start(0)
+	// A poll made before the play script ran still reports the player stopped
+	if next := q.finished(PlaybackStatus{PlayerState: "stopped"}); next != -1 {
+		t.Errorf("finished() with a stale stopped status = %d, want -1", next)
+	}
+	// Still the track from before the queue started
+	if next := q.finished(PlaybackStatus{PlayerState: "playing", PersistentId: "ZZZ"}); next != -1 {
+		t.Errorf("finished() while another track plays = %d, want -1", next)
+	}
+	if next := q.finished(PlaybackStatus{PlayerState: "stopped"}); next != -1 {
+		t.Errorf("finished() before the started track played = %d, want -1", next)
+	}
+	q.finished(PlaybackStatus{PlayerState: "playing", PersistentId: "AAA"})
+	if next := q.finished(PlaybackStatus{PlayerState: "stopped"}); next != 1 {
+		t.Errorf("finished() after the started track played = %d, want 1", next)
+	}
+
+	q.start(1)
+	if next := q.finished(PlaybackStatus{PlayerState: "stopped"}); next != -1 {
+		t.Errorf("finished() with a stale stopped status after advancing = %d, want -1", next)
+	}
+}
+
+func TestSetQueueBackend(t *testing.T) {
+	defer SetQueueBackend(QueueBackendPlaylist)
+
+	if err := SetQueueBackend(" Native "); err != nil || !usingNativeQueue() {
+		t.Errorf("SetQueueBackend(native) = %v, native = %v", err, usingNativeQueue())
+	}
+	if err := SetQueueBackend("bogus"); err == nil {
+		t.Errorf("SetQueueBackend(bogus) expected error")
+	}
+	if err := SetQueueBackend(""); err != nil || usingNativeQueue() {
+		t.Errorf("SetQueueBackend(\"\") = %v, native = %v", err, usingNativeQueue())
+	}
+}
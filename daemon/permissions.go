@@ -0,0 +1,32 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrAutomationDenied is returned when macOS won't let amtui control Music (error -1743).
+// It's fixed by allowing the terminal to control Music in System Settings.
+var ErrAutomationDenied = errors.New("not allowed to control Music (Automation permission denied)")
+
+// automationSettingsURL opens System Settings › Privacy & Security › Automation
+const automationSettingsURL = "x-apple.systempreferences:com.apple.preference.security?Privacy_Automation"
+
+// automation_denied reports whether an osascript run was refused by macOS, either as a
+// failure of osascript itself or as an error message a script returned in-band
+func automation_denied(out []byte, failure error) bool {
+	denied := func(msg string) bool {
+		return strings.Contains(msg, "-1743") || strings.Contains(msg, "Not authorized to send Apple events")
+	}
+	return (failure != nil && denied(failure.Error())) || denied(string(out))
+}
+
+// OpenAutomationSettings opens the Automation pane of System Settings
+func OpenAutomationSettings() error {
+	if err := exec.Command("open", automationSettingsURL).Run(); err != nil {
+		return fmt.Errorf("failed to open System Settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package daemon
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAutomationDenied(t *testing.T) {
+	tests := []struct {
+		name    string
+		out     string
+		failure error
+		want    bool
+	}{
+		{"osascript failure", "", errors.New("exit status 1: execution error: Not authorized to send Apple events to Music. (-1743)"), true},
+		{"reported in-band", "ERROR: Not authorized to send Apple events to Music.", nil, true},
+		{"other failure", "", errors.New("exit status 1: Music got an error: Connection is invalid. (-609)"), false},
+		{"success", "SUCCESS", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := automation_denied([]byte(tt.out), tt.failure); got != tt.want {
+				t.Errorf("automation_denied() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
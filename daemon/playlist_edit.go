@@ -0,0 +1,103 @@
+package daemon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AddTracksToPlaylist duplicates tracks into the user playlist named playlist in a single
+// AppleScript call, returning how many were added
+func (d *Daemon) AddTracksToPlaylist(tracks []Track, playlist string) (int, error) {
+	return edit_tracks(tracks,
+		fmt.Sprintf("set targetPlaylist to user playlist %s", as_string(playlist)),
+		"library playlist 1",
+		"duplicate foundTrack to targetPlaylist")
+}
+
+// RemoveTracksFromPlaylist removes one entry of each of tracks from the user playlist
+// named playlist, leaving them in the library, and returns how many were removed
+func (d *Daemon) RemoveTracksFromPlaylist(tracks []Track, playlist string) (int, error) {
+	return edit_tracks(tracks,
+		fmt.Sprintf("set targetPlaylist to user playlist %s", as_string(playlist)),
+		"targetPlaylist",
+		"delete foundTrack")
+}
+
+// RateTracks sets the rating of tracks to rating, 0-100 with 20 per star (0 clears it),
+// and returns how many were rated
+func (d *Daemon) RateTracks(tracks []Track, rating int) (int, error) {
+	if rating < 0 || rating > 100 {
+		return 0, fmt.Errorf("rating %d is out of range 0-100", rating)
+	}
+	return edit_tracks(tracks, "", "library playlist 1",
+		fmt.Sprintf("set rating of foundTrack to %d", rating))
+}
+
+// edit_tracks runs action on each of tracks found among the tracks of container, an
+// AppleScript reference, after running setup once. Tracks are found by persistent ID, or
+// by name and artist when they have none, and action sees each as foundTrack. It returns
+// how many tracks were found and edited.
+func edit_tracks(tracks []Track, setup, container, action string) (int, error) {
+	if len(tracks) == 0 {
+		return 0, nil
+	}
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+
+	try
+		%s
+		set edited to 0
+		repeat with requested in %s
+			set trackID to item 1 of requested
+			try
+				if trackID is not "" then
+					set foundTracks to (tracks of %s whose persistent ID is trackID)
+				else
+					set foundTracks to (tracks of %s whose name is (item 2 of requested) and artist is (item 3 of requested))
+				end if
+				if (count of foundTracks) > 0 then
+					set foundTrack to item 1 of foundTracks
+					%s
+					set edited to edited + 1
+				end if
+			end try
+		end repeat
+		return "SUCCESS:" & edited
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, setup, track_refs(tracks), container, container, action)
+
+	out, err := get_script_output(script)
+	if err != nil {
+		return 0, fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+	return parse_edit_output(string(out))
+}
+
+// track_refs renders tracks as an AppleScript list of {persistent ID, name, artist}
+// triples
+func track_refs(tracks []Track) string {
+	items := make([]string, len(tracks))
+	for i, track := range tracks {
+		items[i] = as_list([]string{track.Id, track.Name, track.Artist})
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+// parse_edit_output reads the "SUCCESS:<count>" output of edit_tracks
+func parse_edit_output(output string) (int, error) {
+	output = strings.TrimSpace(output)
+	if strings.HasPrefix(output, "ERROR:") {
+		return 0, fmt.Errorf("AppleScript error: %s", strings.TrimSpace(output[6:]))
+	}
+	count, err := strconv.Atoi(strings.TrimPrefix(output, "SUCCESS:"))
+	if !strings.HasPrefix(output, "SUCCESS:") || err != nil {
+		return 0, fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	return count, nil
+}
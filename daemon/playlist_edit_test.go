@@ -0,0 +1,31 @@
+package daemon
+
+import "testing"
+
+func TestTrackRefs(t *testing.T) {
+	got := track_refs([]Track{{Id: "ABC", Name: `Say "Hi"`, Artist: "Queen"}, {Name: "Two"}})
+	want := `{{"ABC", "Say \"Hi\"", "Queen"}, {"", "Two", ""}}`
+	if got != want {
+		t.Errorf("track_refs() = %s, want %s", got, want)
+	}
+}
+
+func TestParseEditOutput(t *testing.T) {
+	if count, err := parse_edit_output("SUCCESS:3\n"); err != nil || count != 3 {
+		t.Errorf("parse_edit_output() = %d, %v, want 3", count, err)
+	}
+	for _, output := range []string{"ERROR: Can't get user playlist", "SUCCESS:", "garbage"} {
+		if _, err := parse_edit_output(output); err == nil {
+			t.Errorf("parse_edit_output(%q) succeeded, want an error", output)
+		}
+	}
+}
+
+func TestRateTracksRejectsOutOfRange(t *testing.T) {
+	d := &Daemon{}
+	for _, rating := range []int{-1, 101} {
+		if _, err := d.RateTracks([]Track{{Id: "ABC"}}, rating); err == nil {
+			t.Errorf("RateTracks(%d) succeeded, want an error", rating)
+		}
+	}
+}
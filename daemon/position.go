@@ -0,0 +1,87 @@
+package daemon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FullStatusInterval is how often NeedsFullStatus asks for a full refresh even when
+// nothing seems to have changed, to pick up volume, shuffle and repeat changes
+const FullStatusInterval = 5 * time.Second
+
+// PlayerPosition is the cheap subset of PlaybackStatus that changes every second
+type PlayerPosition struct {
+	PlayerState string  // "playing", "paused", "stopped"
+	Position    float64 // Seconds into the current track
+	TrackId     string  // Database ID of the current track, empty when stopped
+}
+
+// GetPosition returns the player state and position with a much smaller script than
+// GetPlaybackStatus, for per-second polling
+func (d *Daemon) GetPosition() (PlayerPosition, error) {
+	script := `
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+
+	try
+		set playerState to player state as string
+		if playerState is "stopped" then
+			return playerState & "|0|"
+		end if
+		return playerState & "|" & player position & "|" & (database ID of current track)
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`
+
+	out, err := query_script_output(script)
+	if err != nil {
+		return PlayerPosition{}, fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return PlayerPosition{}, fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	return parse_player_position(output)
+}
+
+// parse_player_position parses the "state|position|track id" output of GetPosition
+func parse_player_position(output string) (PlayerPosition, error) {
+	parts := strings.Split(output, "|")
+	if len(parts) != 3 {
+		return PlayerPosition{}, fmt.Errorf("invalid player position output: %s", output)
+	}
+	// AppleScript may format reals with a decimal comma depending on the locale
+	position, err := strconv.ParseFloat(strings.Replace(parts[1], ",", ".", 1), 64)
+	if err != nil {
+		return PlayerPosition{}, fmt.Errorf("invalid player position: %w", err)
+	}
+	return PlayerPosition{PlayerState: parts[0], Position: position, TrackId: parts[2]}, nil
+}
+
+// NeedsFullStatus reports whether a position poll shows enough of a change (another
+// track, play/pause) to fetch the full status, or the last full fetch is too old
+func NeedsFullStatus(last PlaybackStatus, pos PlayerPosition, sinceFull time.Duration) bool {
+	return pos.PlayerState != last.PlayerState ||
+		pos.TrackId != last.Track.Id ||
+		sinceFull >= FullStatusInterval
+}
+
+// WithPosition returns the status updated with a newer position poll
+func (s PlaybackStatus) WithPosition(pos PlayerPosition) PlaybackStatus {
+	s.PlayerState = pos.PlayerState
+	s.IsPlaying = pos.PlayerState == "playing"
+	s.Position = pos.Position
+	return s
+}
+
+// SetPosition seeks the current track to seconds from its start
+func (d *Daemon) SetPosition(seconds float64) error {
+	script := fmt.Sprintf(`tell application "Music" to set player position to %s`, strconv.FormatFloat(max(seconds, 0), 'f', 2, 64))
+	return run_script(script)
+}
@@ -0,0 +1,43 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePlayerPosition(t *testing.T) {
+	pos, err := parse_player_position("playing|83,5|4711")
+	if err != nil {
+		t.Fatalf("parse_player_position() error = %v", err)
+	}
+	want := PlayerPosition{PlayerState: "playing", Position: 83.5, TrackId: "4711"}
+	if pos != want {
+		t.Errorf("parse_player_position() = %+v, want %+v", pos, want)
+	}
+
+	if _, err := parse_player_position("playing|83"); err == nil {
+		t.Errorf("parse_player_position() expected error for malformed output")
+	}
+}
+
+func TestNeedsFullStatus(t *testing.T) {
+	last := PlaybackStatus{PlayerState: "playing", Track: Track{Id: "4711"}}
+	tests := []struct {
+		name      string
+		pos       PlayerPosition
+		sinceFull time.Duration
+		want      bool
+	}{
+		{"same track still playing", PlayerPosition{PlayerState: "playing", TrackId: "4711"}, time.Second, false},
+		{"track changed", PlayerPosition{PlayerState: "playing", TrackId: "4712"}, time.Second, true},
+		{"paused", PlayerPosition{PlayerState: "paused", TrackId: "4711"}, time.Second, true},
+		{"periodic refresh", PlayerPosition{PlayerState: "playing", TrackId: "4711"}, FullStatusInterval, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NeedsFullStatus(last, tt.pos, tt.sinceFull); got != tt.want {
+				t.Errorf("NeedsFullStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// musicAppPaths are where macOS keeps Music.app
+var musicAppPaths = []string{"/System/Applications/Music.app", "/Applications/Music.app"}
+
+// PreflightCheck is one thing amtui needs before it can show the library
+type PreflightCheck struct {
+	Name string
+	Hint string                 // How to fix it when the check fails
+	Run  func() (string, error) // Returns what was found, like Music's version
+}
+
+// PreflightChecks lists the checks to run at startup, in order: each one relies on the
+// ones before it. The launch check starts Music.app if needed and waits up to
+// launchTimeout for it.
+func (d *Daemon) PreflightChecks(launchTimeout time.Duration) []PreflightCheck {
+	return []PreflightCheck{
+		{
+			Name: "osascript available",
+			Hint: "amtui controls Music through osascript, which comes with macOS. Run amtui on a Mac.",
+			Run:  func() (string, error) { return exec.LookPath("osascript") },
+		},
+		{
+			Name: "Music.app installed",
+			Hint: "Reinstall Music.app, or update macOS to get it back.",
+			Run:  func() (string, error) { return music_installed(musicAppPaths, os.Stat) },
+		},
+		{
+			Name: "Music.app running",
+			Hint: "Open Music.app yourself and wait for the library to show, then press r.",
+			Run: func() (string, error) {
+				err := d.EnsureRunning(launchTimeout)
+				if errors.Is(err, ErrAutomationDenied) {
+					// Music is up, the next check explains the refusal
+					return "", nil
+				}
+				return "", err
+			},
+		},
+		{
+			Name: "Automation permission",
+			Hint: "Press o, find your terminal under Automation and turn on \"Music\", then press r.",
+			Run: func() (string, error) {
+				version, _, err := diagnostic_script(`tell application "Music" to get version`)
+				if err != nil {
+					return "", err
+				}
+				return "Music " + version, nil
+			},
+		},
+		{
+			Name: "Library reachable",
+			Hint: "Music answers but can't read its library. Check it opens in Music.app, then press r.",
+			Run: func() (string, error) {
+				counts, _, err := diagnostic_script(`tell application "Music" to return ((count of tracks of library playlist 1) as string) & "~" & ((count of playlists) as string)`)
+				if err != nil {
+					return "", err
+				}
+				tracks, playlists, err := parse_library_counts(counts)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d tracks, %d playlists", tracks, playlists), nil
+			},
+		},
+	}
+}
+
+// music_installed returns the first of paths holding Music.app
+func music_installed(paths []string, stat func(string) (os.FileInfo, error)) (string, error) {
+	for _, path := range paths {
+		if _, err := stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.New("Music.app not found")
+}
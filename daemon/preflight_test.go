@@ -0,0 +1,36 @@
+package daemon
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMusicInstalled(t *testing.T) {
+	stat := func(found string) func(string) (os.FileInfo, error) {
+		return func(path string) (os.FileInfo, error) {
+			if path == found {
+				return nil, nil
+			}
+			return nil, fs.ErrNotExist
+		}
+	}
+
+	path, err := music_installed(musicAppPaths, stat("/Applications/Music.app"))
+	if err != nil || path != "/Applications/Music.app" {
+		t.Errorf("music_installed() = %q, %v, want /Applications/Music.app", path, err)
+	}
+	if _, err := music_installed(musicAppPaths, stat("/elsewhere")); err == nil {
+		t.Error("music_installed() found Music.app where there is none")
+	}
+}
+
+func TestPreflightChecksHaveHints(t *testing.T) {
+	d := Daemon{}
+	for _, check := range d.PreflightChecks(time.Second) {
+		if check.Name == "" || check.Hint == "" || check.Run == nil {
+			t.Errorf("preflight check %+v is missing a name, hint or run", check)
+		}
+	}
+}
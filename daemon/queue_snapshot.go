@@ -0,0 +1,223 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueueSnapshot is the amtui Queue as saved to disk when amtui quits
+type QueueSnapshot struct {
+	Tracks         []Track   `json:"tracks"`          // Id holds the persistent ID used to rebuild the queue
+	Position       int       `json:"position"`        // 1-based position of the track that was playing
+	PlayerPosition float64   `json:"player_position"` // Seconds into that track
+	SavedAt        time.Time `json:"saved_at"`
+}
+
+// Current returns the track that was playing when the snapshot was taken
+func (s QueueSnapshot) Current() (Track, bool) {
+	if s.Position < 1 || s.Position > len(s.Tracks) {
+		return Track{}, false
+	}
+	return s.Tracks[s.Position-1], true
+}
+
+// SnapshotQueue captures the amtui Queue and playback position. ok is false when
+// Music isn't playing from the amtui Queue, so there is nothing worth resuming.
+func (d *Daemon) SnapshotQueue() (snapshot QueueSnapshot, ok bool, err error) {
+	script := `
+tell application "Music"
+	if it is not running then
+		return "INFO: Music app is not running"
+	end if
+
+	try
+		if name of current playlist is not "amtui Queue" then
+			return "INFO: Not playing from amtui Queue"
+		end if
+
+		set queuePlaylist to user playlist "amtui Queue"
+		set currentTrack to current track
+		set currentPosition to 0
+		set trackLines to {}
+		repeat with i from 1 to count of tracks of queuePlaylist
+			set queueTrack to track i of queuePlaylist
+			if queueTrack is currentTrack then set currentPosition to i
+			set end of trackLines to (persistent ID of queueTrack) & "~" & (name of queueTrack) & "~" & (artist of queueTrack) & "~" & (album of queueTrack) & "~" & (duration of queueTrack as string)
+		end repeat
+
+		set AppleScript's text item delimiters to "||"
+		set output to trackLines as string
+		set AppleScript's text item delimiters to ""
+		return "SUCCESS:" & currentPosition & "|" & (player position as string) & "|" & output
+
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`
+
+	out, err := query_script_output(script)
+	if err != nil {
+		return QueueSnapshot{}, false, fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+
+	output := strings.TrimSpace(string(out))
+	switch {
+	case strings.HasPrefix(output, "INFO:"):
+		return QueueSnapshot{}, false, nil
+	case strings.HasPrefix(output, "ERROR:"):
+		return QueueSnapshot{}, false, fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	case !strings.HasPrefix(output, "SUCCESS:"):
+		return QueueSnapshot{}, false, fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+
+	snapshot, err = parse_queue_snapshot(output[8:])
+	if err != nil {
+		return QueueSnapshot{}, false, err
+	}
+	snapshot.SavedAt = time.Now()
+	return snapshot, len(snapshot.Tracks) > 0, nil
+}
+
+// parse_queue_snapshot parses "position|player position|id~name~artist~album~duration||..."
+func parse_queue_snapshot(output string) (QueueSnapshot, error) {
+	parts := strings.SplitN(output, "|", 3)
+	if len(parts) != 3 {
+		return QueueSnapshot{}, fmt.Errorf("invalid queue snapshot output: %s", output)
+	}
+
+	position, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return QueueSnapshot{}, fmt.Errorf("invalid queue position: %w", err)
+	}
+	// AppleScript may format reals with a decimal comma depending on the locale
+	playerPosition, err := strconv.ParseFloat(strings.Replace(parts[1], ",", ".", 1), 64)
+	if err != nil {
+		return QueueSnapshot{}, fmt.Errorf("invalid player position: %w", err)
+	}
+
+	snapshot := QueueSnapshot{Position: position, PlayerPosition: playerPosition}
+	if parts[2] == "" {
+		return snapshot, nil
+	}
+	for _, trackStr := range strings.Split(parts[2], "||") {
+		trackParts := strings.Split(trackStr, "~")
+		if len(trackParts) != 5 {
+			continue
+		}
+		snapshot.Tracks = append(snapshot.Tracks, Track{
+			Id:       trackParts[0],
+			Name:     trackParts[1],
+			Artist:   trackParts[2],
+			Album:    trackParts[3],
+			Duration: trackParts[4],
+		})
+	}
+	return snapshot, nil
+}
+
+// RestoreQueue rebuilds the amtui Queue from a snapshot and resumes playback where it left off.
+// Tracks that are no longer in the library are skipped.
+func (d *Daemon) RestoreQueue(snapshot QueueSnapshot) error {
+	if len(snapshot.Tracks) == 0 {
+		return errors.New("queue snapshot is empty")
+	}
+
+	ids := make([]string, len(snapshot.Tracks))
+	for i, track := range snapshot.Tracks {
+		ids[i] = track.Id
+	}
+	position := max(snapshot.Position, 1)
+
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+
+	try
+		-- Check if amtui Queue exists, create if it doesn't
+		try
+			set queuePlaylist to user playlist "amtui Queue"
+			delete tracks of queuePlaylist
+		on error
+			set queuePlaylist to (make new user playlist with properties {name:"amtui Queue"})
+		end try
+
+		-- Rebuild the queue, remembering where the playing track ended up
+		set resumeIndex to 0
+		set trackIndex to 0
+		repeat with trackID in %s
+			set trackIndex to trackIndex + 1
+			try
+				duplicate (first track of library playlist 1 whose persistent ID is (contents of trackID)) to queuePlaylist
+				if trackIndex ≤ %d then set resumeIndex to count of tracks of queuePlaylist
+			end try
+		end repeat
+
+		if (count of tracks of queuePlaylist) = 0 then
+			return "ERROR: None of the queued tracks are in your library anymore"
+		end if
+		if resumeIndex < 1 then set resumeIndex to 1
+
+		set shuffle enabled to false
+		play track resumeIndex of queuePlaylist
+		if resumeIndex is %d then set player position to %s
+
+		return "SUCCESS: Restored " & (count of tracks of queuePlaylist) & " tracks"
+
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell
+	`, as_list(ids), position, position, strconv.FormatFloat(snapshot.PlayerPosition, 'f', 1, 64))
+
+	out, err := get_script_output(script)
+	if err != nil {
+		return fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	if !strings.HasPrefix(output, "SUCCESS:") {
+		return fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	return nil
+}
+
+// SaveQueueSnapshot writes a snapshot to path, creating the parent directory if needed
+func SaveQueueSnapshot(path string, snapshot QueueSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode queue snapshot: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write queue snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadQueueSnapshot reads a snapshot saved by SaveQueueSnapshot. ok is false when there is none.
+func LoadQueueSnapshot(path string) (snapshot QueueSnapshot, ok bool, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return QueueSnapshot{}, false, nil
+	}
+	if err != nil {
+		return QueueSnapshot{}, false, fmt.Errorf("failed to read queue snapshot: %w", err)
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return QueueSnapshot{}, false, fmt.Errorf("failed to parse queue snapshot: %w", err)
+	}
+	return snapshot, len(snapshot.Tracks) > 0, nil
+}
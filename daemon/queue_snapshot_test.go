@@ -0,0 +1,58 @@
+package daemon
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseQueueSnapshot(t *testing.T) {
+	snapshot, err := parse_queue_snapshot("2|83,5|AB12~After Dark~Mr.Kitty~Time~259.1||CD34~Sunset~The Midnight~Days of Thunder~301")
+	if err != nil {
+		t.Fatalf("parse_queue_snapshot() error = %v", err)
+	}
+	want := QueueSnapshot{
+		Position:       2,
+		PlayerPosition: 83.5,
+		Tracks: []Track{
+			{Id: "AB12", Name: "After Dark", Artist: "Mr.Kitty", Album: "Time", Duration: "259.1"},
+			{Id: "CD34", Name: "Sunset", Artist: "The Midnight", Album: "Days of Thunder", Duration: "301"},
+		},
+	}
+	if !reflect.DeepEqual(snapshot, want) {
+		t.Errorf("parse_queue_snapshot() = %+v, want %+v", snapshot, want)
+	}
+	if current, ok := snapshot.Current(); !ok || current.Name != "Sunset" {
+		t.Errorf("Current() = %+v, %v, want Sunset", current, ok)
+	}
+
+	if _, err := parse_queue_snapshot("garbage"); err == nil {
+		t.Errorf("parse_queue_snapshot() expected error for malformed output")
+	}
+}
+
+func TestQueueSnapshotRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "queue.json")
+
+	if _, ok, err := LoadQueueSnapshot(path); ok || err != nil {
+		t.Fatalf("LoadQueueSnapshot() on missing file = %v, %v, want no snapshot", ok, err)
+	}
+
+	saved := QueueSnapshot{
+		Tracks:         []Track{{Id: "AB12", Name: "After Dark"}},
+		Position:       1,
+		PlayerPosition: 12.5,
+		SavedAt:        time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+	if err := SaveQueueSnapshot(path, saved); err != nil {
+		t.Fatalf("SaveQueueSnapshot() error = %v", err)
+	}
+	loaded, ok, err := LoadQueueSnapshot(path)
+	if err != nil || !ok {
+		t.Fatalf("LoadQueueSnapshot() = %v, %v", ok, err)
+	}
+	if !reflect.DeepEqual(loaded, saved) {
+		t.Errorf("LoadQueueSnapshot() = %+v, want %+v", loaded, saved)
+	}
+}
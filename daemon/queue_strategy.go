@@ -0,0 +1,241 @@
+package daemon
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Names of the built-in queue strategies, as used in the config file
+const (
+	QueueStrategyAuto                 = "auto" // selected-first-shuffle when shuffle is on, in-order otherwise
+	QueueStrategySelectedFirstShuffle = "selected-first-shuffle"
+	QueueStrategyInOrder              = "in-order"
+	QueueStrategyShuffle              = "shuffle"
+	QueueStrategyAlbumShuffle         = "album-shuffle"
+)
+
+// QueueStrategy decides which tracks of a source playlist end up in the amtui Queue and in what order
+type QueueStrategy interface {
+	Name() string
+	// Order returns 1-based positions into tracks, in the order they should be queued.
+	// selectedPosition is the 1-based position of the track the user picked.
+	Order(tracks []Track, selectedPosition int, rng *rand.Rand) []int
+}
+
+// SelectedFirstShuffleStrategy plays the selected track first, followed by every other track shuffled
+type SelectedFirstShuffleStrategy struct{}
+
+func (SelectedFirstShuffleStrategy) Name() string { return QueueStrategySelectedFirstShuffle }
+
+func (SelectedFirstShuffleStrategy) Order(tracks []Track, selectedPosition int, rng *rand.Rand) []int {
+	remaining := make([]int, 0, len(tracks))
+	for i := 1; i <= len(tracks); i++ {
+		if i != selectedPosition {
+			remaining = append(remaining, i)
+		}
+	}
+	rng.Shuffle(len(remaining), func(i, j int) { remaining[i], remaining[j] = remaining[j], remaining[i] })
+	return append([]int{selectedPosition}, remaining...)
+}
+
+// InOrderStrategy plays from the selected track to the end of the playlist in order
+type InOrderStrategy struct{}
+
+func (InOrderStrategy) Name() string { return QueueStrategyInOrder }
+
+func (InOrderStrategy) Order(tracks []Track, selectedPosition int, rng *rand.Rand) []int {
+	order := make([]int, 0, len(tracks)-selectedPosition+1)
+	for i := selectedPosition; i <= len(tracks); i++ {
+		order = append(order, i)
+	}
+	return order
+}
+
+// ShuffleStrategy shuffles the whole playlist, ignoring which track was selected
+type ShuffleStrategy struct{}
+
+func (ShuffleStrategy) Name() string { return QueueStrategyShuffle }
+
+func (ShuffleStrategy) Order(tracks []Track, selectedPosition int, rng *rand.Rand) []int {
+	order := make([]int, len(tracks))
+	for i := range order {
+		order[i] = i + 1
+	}
+	rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	return order
+}
+
+// AlbumShuffleStrategy keeps albums together: the selected track's album plays first
+// (starting at the selected track), then the remaining albums in random order, each in playlist order
+type AlbumShuffleStrategy struct{}
+
+func (AlbumShuffleStrategy) Name() string { return QueueStrategyAlbumShuffle }
+
+func (AlbumShuffleStrategy) Order(tracks []Track, selectedPosition int, rng *rand.Rand) []int {
+	// Group positions by album, remembering the order albums first appear in
+	var albums []string
+	positions := make(map[string][]int)
+	for i, track := range tracks {
+		if _, seen := positions[track.Album]; !seen {
+			albums = append(albums, track.Album)
+		}
+		positions[track.Album] = append(positions[track.Album], i+1)
+	}
+
+	selectedAlbum := tracks[selectedPosition-1].Album
+	order := []int{selectedPosition}
+	for _, pos := range positions[selectedAlbum] {
+		if pos != selectedPosition {
+			order = append(order, pos)
+		}
+	}
+
+	others := make([]string, 0, len(albums))
+	for _, album := range albums {
+		if album != selectedAlbum {
+			others = append(others, album)
+		}
+	}
+	rng.Shuffle(len(others), func(i, j int) { others[i], others[j] = others[j], others[i] })
+	for _, album := range others {
+		order = append(order, positions[album]...)
+	}
+	return order
+}
+
+// queueStrategies lists the built-in strategies in the order they are cycled through in the TUI
+var queueStrategies = []QueueStrategy{
+	SelectedFirstShuffleStrategy{},
+	InOrderStrategy{},
+	ShuffleStrategy{},
+	AlbumShuffleStrategy{},
+}
+
+// QueueStrategyNames returns the names accepted by LookupQueueStrategy, starting with "auto"
+func QueueStrategyNames() []string {
+	names := []string{QueueStrategyAuto}
+	for _, strategy := range queueStrategies {
+		names = append(names, strategy.Name())
+	}
+	return names
+}
+
+// LookupQueueStrategy resolves a strategy by name. "auto" (or an empty name) resolves to nil,
+// which makes queue construction follow Music's shuffle setting.
+func LookupQueueStrategy(name string) (QueueStrategy, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || name == QueueStrategyAuto {
+		return nil, nil
+	}
+	for _, strategy := range queueStrategies {
+		if strategy.Name() == name {
+			return strategy, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown queue strategy %q (valid: %s)", name, strings.Join(QueueStrategyNames(), ", "))
+}
+
+// CreateQueueWithStrategy rebuilds the amtui Queue from sourcePlaylist using strategy.
+// A nil strategy picks selected-first-shuffle or in-order based on the current shuffle state.
+func (d *Daemon) CreateQueueWithStrategy(sourcePlaylist string, selectedPosition int, strategy QueueStrategy) error {
+	playlist, err := d.GetPlaylist(sourcePlaylist)
+	if err != nil {
+		return fmt.Errorf("failed to get playlist: %w", err)
+	}
+	return d.buildQueue(sourcePlaylist, playlist.Tracks, selectedPosition, strategy)
+}
+
+// queueOrder resolves a nil strategy from the shuffle state and returns the 1-based positions
+// of tracks to queue, leaving out unavailable tracks when configured to
+func (d *Daemon) queueOrder(sourcePlaylist string, tracks []Track, selectedPosition int, strategy QueueStrategy) ([]int, QueueStrategy, error) {
+	if selectedPosition < 1 || selectedPosition > len(tracks) {
+		return nil, nil, fmt.Errorf("invalid position %d for playlist with %d tracks", selectedPosition, len(tracks))
+	}
+
+	if strategy == nil {
+		currentShuffle, err := d.GetShuffle()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get shuffle state: %w", err)
+		}
+		strategy = InOrderStrategy{}
+		if currentShuffle {
+			strategy = SelectedFirstShuffleStrategy{}
+		}
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	order := strategy.Order(tracks, selectedPosition, rng)
+	if skippingUnavailable() {
+		order = playable_positions(order, tracks)
+		if len(order) == 0 {
+			return nil, nil, fmt.Errorf("no playable tracks in %s", sourcePlaylist)
+		}
+	}
+	return order, strategy, nil
+}
+
+// buildQueue orders tracks with strategy and duplicates them into the amtui Queue in a single script
+func (d *Daemon) buildQueue(sourcePlaylist string, tracks []Track, selectedPosition int, strategy QueueStrategy) error {
+	order, strategy, err := d.queueOrder(sourcePlaylist, tracks, selectedPosition, strategy)
+	if err != nil {
+		return err
+	}
+	positions := make([]string, len(order))
+	for i, pos := range order {
+		positions[i] = strconv.Itoa(pos)
+	}
+
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		error "Music app is not running"
+	end if
+
+	try
+		set sourcePlaylist to playlist %s
+
+		-- Check if amtui Queue exists, create if it doesn't
+		try
+			set queuePlaylist to user playlist "amtui Queue"
+			-- Clear existing tracks from queue
+			delete tracks of queuePlaylist
+		on error
+			-- Create the playlist if it doesn't exist
+			set queuePlaylist to (make new user playlist with properties {name:"amtui Queue"})
+		end try
+
+		-- Positions were ordered by the %s strategy in Go
+		repeat with trackIndex in {%s}
+			duplicate (track trackIndex of sourcePlaylist) to queuePlaylist
+		end repeat
+
+		-- Disable shuffle for queue playback (queue is pre-ordered)
+		set shuffle enabled to false
+
+		return "SUCCESS: Created amtui Queue with " & (count of tracks of queuePlaylist) & " tracks"
+
+	on error errMsg
+		return "Failed to create queue: " & errMsg
+	end try
+end tell
+	`, as_string(sourcePlaylist), strategy.Name(), strings.Join(positions, ", "))
+
+	out, err := get_script_output(script)
+	if err != nil {
+		return fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "Failed to create queue:") {
+		return fmt.Errorf("Queue creation failed: %s", output[24:]) // Remove "Failed to create queue: " prefix
+	}
+
+	if !strings.HasPrefix(output, "SUCCESS:") {
+		return fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+
+	return nil
+}
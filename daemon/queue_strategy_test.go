@@ -0,0 +1,126 @@
+package daemon
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func strategyTestTracks() []Track {
+	return []Track{
+		{Name: "A1", Album: "A"},
+		{Name: "B1", Album: "B"},
+		{Name: "A2", Album: "A"},
+		{Name: "C1", Album: "C"},
+		{Name: "B2", Album: "B"},
+		{Name: "A3", Album: "A"},
+	}
+}
+
+func TestQueueStrategyOrder(t *testing.T) {
+	tracks := strategyTestTracks()
+
+	tests := []struct {
+		name      string
+		strategy  QueueStrategy
+		selected  int
+		wantFirst int
+		wantLen   int
+	}{
+		{name: "selected first shuffle", strategy: SelectedFirstShuffleStrategy{}, selected: 3, wantFirst: 3, wantLen: 6},
+		{name: "in order from selection", strategy: InOrderStrategy{}, selected: 4, wantFirst: 4, wantLen: 3},
+		{name: "album shuffle", strategy: AlbumShuffleStrategy{}, selected: 3, wantFirst: 3, wantLen: 6},
+		{name: "pure shuffle", strategy: ShuffleStrategy{}, selected: 2, wantFirst: -1, wantLen: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := tt.strategy.Order(tracks, tt.selected, rand.New(rand.NewSource(1)))
+			if len(order) != tt.wantLen {
+				t.Fatalf("Order() returned %d positions, want %d: %v", len(order), tt.wantLen, order)
+			}
+			if tt.wantFirst != -1 && order[0] != tt.wantFirst {
+				t.Errorf("Order()[0] = %d, want %d", order[0], tt.wantFirst)
+			}
+			// Every position must be valid and appear at most once
+			seen := make(map[int]bool)
+			for _, pos := range order {
+				if pos < 1 || pos > len(tracks) || seen[pos] {
+					t.Fatalf("Order() returned invalid or duplicate position %d: %v", pos, order)
+				}
+				seen[pos] = true
+			}
+		})
+	}
+}
+
+func TestInOrderStrategy(t *testing.T) {
+	got := InOrderStrategy{}.Order(strategyTestTracks(), 4, rand.New(rand.NewSource(1)))
+	if want := []int{4, 5, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Order() = %v, want %v", got, want)
+	}
+}
+
+func TestAlbumShuffleStrategyKeepsAlbumsTogether(t *testing.T) {
+	tracks := strategyTestTracks()
+	order := AlbumShuffleStrategy{}.Order(tracks, 3, rand.New(rand.NewSource(7)))
+
+	// Selected album plays first, starting at the selected track
+	if want := []int{3, 1, 6}; !reflect.DeepEqual(order[:3], want) {
+		t.Errorf("selected album order = %v, want %v", order[:3], want)
+	}
+
+	// Each album forms one contiguous run
+	runs := make(map[string]int)
+	for i, pos := range order {
+		if i == 0 || tracks[order[i-1]-1].Album != tracks[pos-1].Album {
+			runs[tracks[pos-1].Album]++
+		}
+	}
+	for album, count := range runs {
+		if count != 1 {
+			t.Errorf("album %s split into %d runs: %v", album, count, order)
+		}
+	}
+
+	sorted := append([]int(nil), order...)
+	sort.Ints(sorted)
+	if want := []int{1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(sorted, want) {
+		t.Errorf("Order() lost tracks: %v", order)
+	}
+}
+
+func TestLookupQueueStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantName string // empty means nil (auto)
+		wantErr  bool
+	}{
+		{name: "empty is auto", input: "", wantName: ""},
+		{name: "auto", input: "auto", wantName: ""},
+		{name: "album shuffle", input: "album-shuffle", wantName: QueueStrategyAlbumShuffle},
+		{name: "case insensitive", input: " In-Order ", wantName: QueueStrategyInOrder},
+		{name: "unknown", input: "random", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LookupQueueStrategy(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LookupQueueStrategy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			gotName := ""
+			if got != nil {
+				gotName = got.Name()
+			}
+			if gotName != tt.wantName {
+				t.Errorf("LookupQueueStrategy() = %q, want %q", gotName, tt.wantName)
+			}
+		})
+	}
+}
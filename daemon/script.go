@@ -0,0 +1,31 @@
+package daemon
+
+import (
+	"strings"
+)
+
+// scriptEscaper escapes text for use inside an AppleScript string literal. Backslashes
+// and quotes would otherwise end the literal early and let a playlist or track name
+// inject AppleScript; line breaks and tabs are escaped so the literal stays on one line.
+var scriptEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+)
+
+// as_string renders s as an AppleScript string literal, quotes included. Every value
+// interpolated into a script must go through it (or be a number).
+func as_string(s string) string {
+	return `"` + scriptEscaper.Replace(s) + `"`
+}
+
+// as_list renders values as an AppleScript list of string literals, e.g. {"a", "b"}
+func as_list(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = as_string(value)
+	}
+	return "{" + strings.Join(quoted, ", ") + "}"
+}
@@ -0,0 +1,96 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAsString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "Road Trip", `"Road Trip"`},
+		{"empty", "", `""`},
+		{"quotes", `The "Best" Of`, `"The \"Best\" Of"`},
+		{"backslash", `AC\DC`, `"AC\\DC"`},
+		{"trailing backslash", `Mix\`, `"Mix\\"`},
+		{"escaped quote", `\"`, `"\\\""`},
+		{"newline", "Line one\nLine two", `"Line one\nLine two"`},
+		{"carriage return and tab", "a\r\tb", `"a\r\tb"`},
+		{"unicode", "Sigur Rós — Hoppípolla", `"Sigur Rós — Hoppípolla"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := as_string(tt.value); got != tt.want {
+				t.Errorf("as_string(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// unquote reads back an AppleScript string literal the way the AppleScript compiler
+// would, failing if the literal ends before the last character
+func unquote(t *testing.T, literal string) string {
+	t.Helper()
+	if len(literal) < 2 || literal[0] != '"' {
+		t.Fatalf("%s is not a string literal", literal)
+	}
+	var b strings.Builder
+	for i := 1; i < len(literal); i++ {
+		switch c := literal[i]; c {
+		case '"':
+			if i != len(literal)-1 {
+				t.Fatalf("literal %s ends early at byte %d", literal, i)
+			}
+			return b.String()
+		case '\n', '\r':
+			t.Fatalf("literal %s spans more than one line", literal)
+		case '\\':
+			i++
+			switch literal[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(literal[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	t.Fatalf("literal %s is not terminated", literal)
+	return ""
+}
+
+func TestAsStringHostileNames(t *testing.T) {
+	hostile := []string{
+		`" & (do shell script "rm -rf ~") & "`,
+		`\" & (do shell script "say hi") & \"`,
+		"\" \nend tell\ndo shell script \"touch /tmp/pwned\"\n--",
+		`\`,
+		`\\"`,
+		`"""`,
+		"tab\tand\\back\\slash\"quote",
+	}
+	for _, name := range hostile {
+		if got := unquote(t, as_string(name)); got != name {
+			t.Errorf("as_string(%q) reads back as %q", name, got)
+		}
+	}
+}
+
+func TestAsList(t *testing.T) {
+	got := as_list([]string{"ABC123", `My "Song"`, ""})
+	want := `{"ABC123", "My \"Song\"", ""}`
+	if got != want {
+		t.Errorf("as_list() = %s, want %s", got, want)
+	}
+	if got := as_list(nil); got != "{}" {
+		t.Errorf("as_list(nil) = %s, want {}", got)
+	}
+}
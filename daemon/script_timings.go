@@ -0,0 +1,79 @@
+package daemon
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxScriptTimings is how many of the latest script runs are kept for RecentScripts
+const maxScriptTimings = 50
+
+// ScriptTiming is how long one osascript run took, for the TUI's debug overlay
+type ScriptTiming struct {
+	Label  string        // The Daemon method the script ran for, or its first line
+	Wait   time.Duration // Time spent queued behind other scripts
+	Run    time.Duration // Time osascript took
+	Failed bool
+	At     time.Time // When the run finished
+}
+
+// scriptTimings keeps the latest runs of a scriptWorker, oldest first
+type scriptTimings struct {
+	mu      sync.Mutex
+	timings []ScriptTiming
+}
+
+func (t *scriptTimings) record(timing ScriptTiming) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timings = append(t.timings, timing)
+	if len(t.timings) > maxScriptTimings {
+		t.timings = t.timings[len(t.timings)-maxScriptTimings:]
+	}
+}
+
+// recent returns a copy of the kept runs, newest first
+func (t *scriptTimings) recent() []ScriptTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	recent := make([]ScriptTiming, len(t.timings))
+	for i, timing := range t.timings {
+		recent[len(t.timings)-1-i] = timing
+	}
+	return recent
+}
+
+// RecentScripts returns how long the latest osascript runs took, newest first
+func RecentScripts() []ScriptTiming {
+	return scripts.timings.recent()
+}
+
+// scriptLabel names a script for its timing: the Daemon method that is running it,
+// or else its first line that isn't just `tell application "Music"`
+func scriptLabel(script string) string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if _, method, ok := strings.Cut(frame.Function, ".(*Daemon)."); ok {
+			return method
+		}
+		if !more {
+			break
+		}
+	}
+	return scriptFirstLine(script)
+}
+
+// scriptFirstLine is the first line of script that says what it does
+func scriptFirstLine(script string) string {
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && line != `tell application "Music"` {
+			return line
+		}
+	}
+	return ""
+}
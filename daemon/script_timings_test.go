@@ -0,0 +1,59 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestScriptWorkerRecordsTimings(t *testing.T) {
+	w := newScriptWorker(func(script string) ([]byte, error) {
+		if script == "fail" {
+			return nil, errors.New("boom")
+		}
+		return nil, nil
+	})
+	w.do(`tell application "Music" to play`, false)
+	w.do("fail", false)
+
+	recent := w.timings.recent()
+	if len(recent) != 2 {
+		t.Fatalf("recorded %d timings, want 2", len(recent))
+	}
+	if recent[0].Label != "fail" || !recent[0].Failed {
+		t.Errorf("newest timing = %+v, want the failed run", recent[0])
+	}
+	if recent[1].Label != `tell application "Music" to play` || recent[1].Failed {
+		t.Errorf("oldest timing = %+v, want the play run", recent[1])
+	}
+}
+
+func TestScriptTimingsKeepsLatest(t *testing.T) {
+	var timings scriptTimings
+	for i := range maxScriptTimings + 5 {
+		timings.record(ScriptTiming{Label: fmt.Sprint(i)})
+	}
+	recent := timings.recent()
+	if len(recent) != maxScriptTimings {
+		t.Fatalf("kept %d timings, want %d", len(recent), maxScriptTimings)
+	}
+	if recent[0].Label != fmt.Sprint(maxScriptTimings+4) || recent[len(recent)-1].Label != "5" {
+		t.Errorf("kept %s..%s, want %d..5", recent[0].Label, recent[len(recent)-1].Label, maxScriptTimings+4)
+	}
+}
+
+func TestScriptFirstLine(t *testing.T) {
+	tests := []struct {
+		script string
+		want   string
+	}{
+		{`tell application "Music" to pause`, `tell application "Music" to pause`},
+		{"\ntell application \"Music\"\n\tget player state\nend tell", "get player state"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := scriptFirstLine(tt.script); got != tt.want {
+			t.Errorf("scriptFirstLine(%q) = %q, want %q", tt.script, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package daemon
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const (
+	// maxSearchResults is how many songs, albums, artists or playlists a search returns
+	maxSearchResults = 50
+	// searchIndexTTL is how long the library read for searching is reused. Changes made
+	// through the daemon drop it right away (see invalidate), so this only catches those
+	// made in Music itself.
+	searchIndexTTL = 30 * time.Minute
+	// wholeLibrary is a page size that covers any library; AppleScript integers top out
+	// just above it
+	wholeLibrary = 1 << 28
+)
+
+// searchIndex caches the library's tracks, and its albums and artists, for searches
+type searchIndex struct {
+	mu       sync.Mutex
+	loadedAt time.Time
+	tracks   []Track
+	albums   []TrackGroup
+	artists  []TrackGroup
+	now      func() time.Time
+	load     func() ([]Track, error)
+	uncached bool // Read the library for every search, as in safe mode
+}
+
+var library_search = &searchIndex{
+	now: time.Now,
+	load: func() ([]Track, error) {
+		d := Daemon{}
+		tracks, _, err := d.GetLibraryPage(0, wholeLibrary)
+		return tracks, err
+	},
+}
+
+// library loads the library unless it was loaded within searchIndexTTL
+func (s *searchIndex) library() error {
+	if !s.uncached && s.tracks != nil && s.now().Sub(s.loadedAt) < searchIndexTTL {
+		return nil
+	}
+	tracks, err := s.load()
+	if err != nil {
+		return err
+	}
+	s.tracks = tracks
+	s.albums = GroupByAlbum(tracks)
+	s.artists = GroupByArtist(tracks)
+	s.loadedAt = s.now()
+	return nil
+}
+
+// SetSearchCaching sets whether searches reuse the library read for searchIndexTTL (on
+// by default). Off, every search reads the library again.
+func SetSearchCaching(cache bool) {
+	library_search.mu.Lock()
+	defer library_search.mu.Unlock()
+	library_search.uncached = !cache
+}
+
+// invalidate drops the library read, after tracks were edited or deleted, so the next
+// search reads it again
+func (s *searchIndex) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tracks, s.albums, s.artists = nil, nil, nil
+}
+
+// SearchResults are what a search found in the library, by kind, best matches first
+type SearchResults struct {
+	Songs     []Track
+	Albums    []TrackGroup
+	Artists   []TrackGroup
+	Playlists []string
+}
+
+// SearchLibrary fuzzy searches songs, albums, artists and the given playlist names (see
+// FuzzyScore), the caller having the playlists at hand already. A search canceled
+// through ctx, e.g. because the query changed while typing, stops between its steps and
+// returns ctx's error.
+func (d *Daemon) SearchLibrary(ctx context.Context, query string, playlists []string) (SearchResults, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return SearchResults{}, nil
+	}
+
+	library_search.mu.Lock()
+	defer library_search.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return SearchResults{}, err
+	}
+	if err := library_search.library(); err != nil {
+		return SearchResults{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return SearchResults{}, err
+	}
+	return SearchResults{
+		Songs: rank_tracks(query, library_search.tracks, maxSearchResults),
+		// Albums match on their artist too, so "queen opera" finds A Night at the Opera
+		Albums: rank_names(query, library_search.albums, maxSearchResults, func(album TrackGroup) string {
+			return album.Name + " " + album.Tracks[0].Artist
+		}),
+		Artists:   rank_names(query, library_search.artists, maxSearchResults, func(artist TrackGroup) string { return artist.Name }),
+		Playlists: rank_names(query, playlists, maxSearchResults, func(name string) string { return name }),
+	}, nil
+}
+
+// SearchTracks fuzzy searches the Music library by name, artist and album, best matches
+// first (see FuzzyScore), so "bhmy rps" finds "Bohemian Rhapsody".
+// Note: This searches your personal music library. To search the full Apple Music catalog,
+// you would need to add songs to your library first using the Music app.
+func (d *Daemon) SearchTracks(query string) ([]Track, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []Track{}, nil
+	}
+	library_search.mu.Lock()
+	defer library_search.mu.Unlock()
+	if err := library_search.library(); err != nil {
+		return nil, err
+	}
+	return rank_tracks(query, library_search.tracks, maxSearchResults), nil
+}
+
+// rank_tracks returns up to limit of the tracks matching query, best first, keeping
+// library order between equal matches
+func rank_tracks(query string, tracks []Track, limit int) []Track {
+	return rank_names(query, tracks, limit, TrackSearchText)
+}
+
+// rank_names returns up to limit of items whose text matches query, best first, keeping
+// the order of items between equal matches
+func rank_names[T any](query string, items []T, limit int, text func(T) string) []T {
+	type match struct {
+		item  T
+		score int
+	}
+	matches := make([]match, 0)
+	for _, item := range items {
+		if score, ok := FuzzyScore(query, text(item)); ok {
+			matches = append(matches, match{item: item, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		return matches[a].score > matches[b].score
+	})
+
+	results := make([]T, 0, min(limit, len(matches)))
+	for _, m := range matches[:min(limit, len(matches))] {
+		results = append(results, m.item)
+	}
+	return results
+}
+
+// TrackSearchText is what searches and filters match a track against
+func TrackSearchText(track Track) string {
+	return track.Name + " " + track.Artist + " " + track.Album
+}
+
+// FuzzyScore reports whether every space-separated term of query matches text, ignoring
+// case, and how well. A term matches when its characters appear in text in order, scored
+// the way fzf does: consecutive characters and characters at word starts count for more,
+// gaps inside a match for less.
+func FuzzyScore(query, text string) (int, bool) {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return 0, true
+	}
+	t := []rune(strings.ToLower(text))
+
+	score := 0
+	for _, term := range terms {
+		termScore, ok := fuzzy_term_score([]rune(term), t)
+		if !ok {
+			return 0, false
+		}
+		score += termScore
+	}
+	// Prefer shorter texts when matches are otherwise equal
+	return score*100 - len(t), true
+}
+
+// fuzzy_term_score scores the shortest run of text that ends where term's leftmost
+// match ends and holds all of term in order
+func fuzzy_term_score(term, text []rune) (int, bool) {
+	// Forward to where the leftmost match ends...
+	qi, end := 0, -1
+	for ti := 0; ti < len(text); ti++ {
+		if text[ti] == term[qi] {
+			qi++
+			if qi == len(term) {
+				end = ti
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, false
+	}
+	// ...then back to the latest start, so "ab" in "a-xab" scores the tight "ab"
+	qi, start := len(term)-1, end
+	for ti := end; ti >= 0; ti-- {
+		if text[ti] == term[qi] {
+			qi--
+			if qi < 0 {
+				start = ti
+				break
+			}
+		}
+	}
+
+	score, previous := 0, -1
+	qi = 0
+	for ti := start; ti <= end; ti++ {
+		if text[ti] != term[qi] {
+			score-- // Gap
+			continue
+		}
+		score += 16
+		if ti == previous+1 && previous >= 0 {
+			score += 8 // Consecutive
+		}
+		if ti == 0 || !unicode.IsLetter(text[ti-1]) && !unicode.IsDigit(text[ti-1]) {
+			score += 10 // Word start
+		}
+		previous = ti
+		qi++
+		if qi == len(term) {
+			break
+		}
+	}
+	return score, true
+}
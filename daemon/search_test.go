@@ -0,0 +1,94 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query, text string
+		match       bool
+	}{
+		{"bhmy rps", "Bohemian Rhapsody Queen A Night at the Opera", true},
+		{"queen bohemian", "Bohemian Rhapsody Queen A Night at the Opera", true},
+		{"BOHEMIAN", "Bohemian Rhapsody", true},
+		{"", "anything", true},
+		{"rhapsody bohemian x", "Bohemian Rhapsody", false},
+		{"ydosp", "Rhapsody", false},
+	}
+	for _, tt := range tests {
+		if _, ok := FuzzyScore(tt.query, tt.text); ok != tt.match {
+			t.Errorf("FuzzyScore(%q, %q) matched = %v, want %v", tt.query, tt.text, ok, tt.match)
+		}
+	}
+
+	// Consecutive characters and word starts beat scattered ones
+	tight, _ := FuzzyScore("sun", "Sunset")
+	loose, _ := FuzzyScore("sun", "Sugar Town")
+	if tight <= loose {
+		t.Errorf("FuzzyScore() = %d for a prefix, %d for a scattered match", tight, loose)
+	}
+}
+
+func TestRankTracks(t *testing.T) {
+	tracks := []Track{
+		{Name: "Somebody to Love", Artist: "Queen"},
+		{Name: "Bohemian Rhapsody", Artist: "Queen"},
+		{Name: "Rhapsody in Blue", Artist: "Gershwin"},
+		{Name: "Bohemian Like You", Artist: "The Dandy Warhols"},
+	}
+	got := rank_tracks("bhmy rps", tracks, 10)
+	if len(got) != 1 || got[0].Name != "Bohemian Rhapsody" {
+		t.Errorf("rank_tracks() = %v, want just Bohemian Rhapsody", got)
+	}
+
+	got = rank_tracks("queen", tracks, 1)
+	if len(got) != 1 || got[0].Name != "Somebody to Love" {
+		t.Errorf("rank_tracks() = %v, want the shorter of two equal matches and the limit kept", got)
+	}
+}
+
+func TestSearchIndexCaches(t *testing.T) {
+	now := time.Unix(0, 0)
+	loads := 0
+	index := &searchIndex{
+		now:  func() time.Time { return now },
+		load: func() ([]Track, error) { loads++; return []Track{{Name: "One"}}, nil },
+	}
+
+	index.library()
+	index.library()
+	if loads != 1 {
+		t.Errorf("library() loaded %d times within the TTL, want 1", loads)
+	}
+	if len(index.albums) != 1 || index.albums[0].Name != "Unknown Album" {
+		t.Errorf("library() albums = %v, want the track grouped", index.albums)
+	}
+	now = now.Add(searchIndexTTL)
+	index.library()
+	if loads != 2 {
+		t.Errorf("library() loaded %d times after the TTL, want 2", loads)
+	}
+	index.invalidate()
+	index.library()
+	if loads != 3 {
+		t.Errorf("library() loaded %d times after invalidate(), want 3", loads)
+	}
+	index.uncached = true
+	index.library()
+	if loads != 4 {
+		t.Errorf("library() loaded %d times uncached, want 4", loads)
+	}
+}
+
+func TestSearchLibraryCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d := &Daemon{}
+	if _, err := d.SearchLibrary(ctx, "queen", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("SearchLibrary() error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,83 @@
+package daemon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ShuffleMode is what Music shuffles when shuffle is enabled
+type ShuffleMode string
+
+const (
+	ShuffleSongs     ShuffleMode = "songs"
+	ShuffleAlbums    ShuffleMode = "albums"
+	ShuffleGroupings ShuffleMode = "groupings"
+)
+
+// Next returns the mode after m in the songs → albums → groupings cycle
+func (m ShuffleMode) Next() ShuffleMode {
+	switch m {
+	case ShuffleSongs:
+		return ShuffleAlbums
+	case ShuffleAlbums:
+		return ShuffleGroupings
+	default:
+		return ShuffleSongs
+	}
+}
+
+// Label is the mode as shown in the playback bar
+func (m ShuffleMode) Label() string {
+	switch m {
+	case ShuffleAlbums:
+		return "Albums"
+	case ShuffleGroupings:
+		return "Groupings"
+	default:
+		return "Songs"
+	}
+}
+
+// parse_shuffle_mode reads the shuffle mode property, defaulting to songs for unexpected values
+func parse_shuffle_mode(value string) ShuffleMode {
+	switch mode := ShuffleMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case ShuffleAlbums, ShuffleGroupings:
+		return mode
+	default:
+		return ShuffleSongs
+	}
+}
+
+// GetShuffleMode returns whether Music shuffles songs, albums or groupings
+func (d *Daemon) GetShuffleMode() (ShuffleMode, error) {
+	script := `tell application "Music" to get shuffle mode as string`
+	out, err := query_script_output(script)
+	if err != nil {
+		return "", err
+	}
+	return parse_shuffle_mode(string(out)), nil
+}
+
+// SetShuffleMode sets what Music shuffles, without turning shuffle on or off
+func (d *Daemon) SetShuffleMode(mode ShuffleMode) error {
+	switch mode {
+	case ShuffleSongs, ShuffleAlbums, ShuffleGroupings:
+	default:
+		return fmt.Errorf("unknown shuffle mode %q", mode)
+	}
+	script := fmt.Sprintf(`tell application "Music" to set shuffle mode to %s`, mode)
+	return run_script(script)
+}
+
+// CycleShuffleMode switches to the next shuffle mode and returns it
+func (d *Daemon) CycleShuffleMode() (ShuffleMode, error) {
+	current, err := d.GetShuffleMode()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current shuffle mode: %w", err)
+	}
+	next := current.Next()
+	if err := d.SetShuffleMode(next); err != nil {
+		return "", err
+	}
+	return next, nil
+}
@@ -0,0 +1,33 @@
+package daemon
+
+import "testing"
+
+func TestShuffleModeNext(t *testing.T) {
+	mode := ShuffleSongs
+	want := []ShuffleMode{ShuffleAlbums, ShuffleGroupings, ShuffleSongs}
+	for _, next := range want {
+		mode = mode.Next()
+		if mode != next {
+			t.Fatalf("Next() = %s, want %s", mode, next)
+		}
+	}
+	if got := ShuffleMode("").Next(); got != ShuffleSongs {
+		t.Errorf("Next() of unknown mode = %s, want songs", got)
+	}
+}
+
+func TestParseShuffleMode(t *testing.T) {
+	tests := map[string]ShuffleMode{
+		"songs\n":    ShuffleSongs,
+		"albums":     ShuffleAlbums,
+		" groupings": ShuffleGroupings,
+		"Albums":     ShuffleAlbums,
+		"":           ShuffleSongs,
+		"missing":    ShuffleSongs,
+	}
+	for value, want := range tests {
+		if got := parse_shuffle_mode(value); got != want {
+			t.Errorf("parse_shuffle_mode(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package daemon
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// stream_url checks that rawURL is something Music can open: an internet radio stream
+// (http/https) or an Apple Music link (music.apple.com, itms, music)
+func stream_url(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid stream URL %q: %w", rawURL, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "itms", "itmss", "music":
+	default:
+		return "", fmt.Errorf("unsupported stream URL %q (expected http, https or music)", rawURL)
+	}
+	if u.Host == "" && u.Opaque == "" {
+		return "", fmt.Errorf("invalid stream URL %q: missing host", rawURL)
+	}
+	return u.String(), nil
+}
+
+// PlayURL plays an internet radio stream or an Apple Music station/link in Music
+func (d *Daemon) PlayURL(rawURL string) error {
+	streamURL, err := stream_url(rawURL)
+	if err != nil {
+		return err
+	}
+
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+
+	try
+		open location %s
+		play
+		return "SUCCESS"
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, as_string(streamURL))
+
+	out, err := get_script_output(script)
+	if err != nil {
+		return fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	if !strings.HasPrefix(output, "SUCCESS") {
+		return fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	return nil
+}
+
+// StartStationFromCurrentTrack starts an Apple Music station based on the playing track.
+// Music has no AppleScript command for this, so it clicks Song › Create Station through
+// System Events, which needs Accessibility permission and an English menu bar.
+func (d *Daemon) StartStationFromCurrentTrack() error {
+	script := `
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+	if player state is stopped then
+		return "ERROR: Nothing is playing"
+	end if
+end tell
+
+try
+	tell application "System Events" to tell process "Music"
+		click menu item "Create Station" of menu "Song" of menu bar 1
+	end tell
+	return "SUCCESS"
+on error errMsg
+	return "ERROR: " & errMsg
+end try`
+
+	out, err := get_script_output(script)
+	if err != nil {
+		return fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	if !strings.HasPrefix(output, "SUCCESS") {
+		return fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package daemon
+
+import "testing"
+
+func TestStreamURL(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "https://ice1.somafm.com/groovesalad-128-mp3", want: "https://ice1.somafm.com/groovesalad-128-mp3"},
+		{in: "  https://music.apple.com/us/station/ra.978194965  ", want: "https://music.apple.com/us/station/ra.978194965"},
+		{in: "itms://music.apple.com/station/ra.1", want: "itms://music.apple.com/station/ra.1"},
+		{in: "file:///etc/passwd", wantErr: true},
+		{in: "somafm.com/groovesalad", wantErr: true},
+		{in: "https://", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := stream_url(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("stream_url(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("stream_url(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
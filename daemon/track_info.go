@@ -0,0 +1,144 @@
+package daemon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TrackInfo is the complete metadata of a single library track
+type TrackInfo struct {
+	Track
+	AlbumArtist string
+	Genre       string
+	Year        int // 0 if unknown
+	TrackNumber int
+	TrackCount  int
+	DiscNumber  int
+	DiscCount   int
+	BitRate     int    // kbps
+	Kind        string // e.g. "Apple Music AAC audio file", as localized by Music
+	CloudStatus string // e.g. "matched", "subscription", "uploaded", empty for local-only tracks
+	Location    string // POSIX path of the file, empty for tracks that only stream
+}
+
+// trackInfoFields is the number of "~" separated fields GetTrackInfo emits
+const trackInfoFields = 19
+
+// GetTrackInfo fetches the full metadata of the library track with the given persistent ID
+func (d *Daemon) GetTrackInfo(persistentID string) (TrackInfo, error) {
+	if persistentID == "" {
+		return TrackInfo{}, fmt.Errorf("track has no persistent ID")
+	}
+
+	script := fmt.Sprintf(`
+tell application "Music"
+	if it is not running then
+		return "ERROR: Music app is not running"
+	end if
+
+	try
+		set matchingTracks to (tracks of library playlist 1 whose persistent ID is %s)
+		if (count of matchingTracks) = 0 then
+			return "ERROR: Track not found in library"
+		end if
+		set t to item 1 of matchingTracks
+
+		set trackStatus to "ok"
+		set trackCloudStatus to ""
+		try
+			set trackCloudStatus to cloud status of t as string
+			if trackCloudStatus is in {"removed", "error", "no longer available", "prerelease"} then
+				set trackStatus to "unavailable"
+			end if
+		end try
+		set trackLocation to ""
+		try
+			if class of t is file track then
+				if location of t is missing value then
+					set trackStatus to "unavailable"
+				else
+					set trackLocation to POSIX path of (location of t)
+				end if
+			else if trackStatus is "ok" then
+				set trackStatus to "cloud"
+			end if
+		end try
+		set trackAge to ""
+		try
+			set trackAge to ((current date) - (date added of t)) as string
+		end try
+
+		return "SUCCESS:" & (persistent ID of t) & "~" & (name of t) & "~" & (artist of t) & "~" & (album of t) & "~" & (album artist of t) & "~" & (duration of t as string) & "~" & trackStatus & "~" & trackAge & "~" & (genre of t) & "~" & (year of t) & "~" & (track number of t) & "~" & (track count of t) & "~" & (disc number of t) & "~" & (disc count of t) & "~" & (bit rate of t) & "~" & (kind of t) & "~" & trackCloudStatus & "~" & (played count of t) & "~" & (rating of t) & "~" & trackLocation
+
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, as_string(persistentID))
+
+	out, err := query_script_output(script)
+	if err != nil {
+		return TrackInfo{}, fmt.Errorf("AppleScript execution failed: %w", err)
+	}
+
+	output := strings.TrimSpace(string(out))
+	if strings.HasPrefix(output, "ERROR:") {
+		return TrackInfo{}, fmt.Errorf("AppleScript error: %s", output[7:]) // Remove "ERROR: " prefix
+	}
+	if !strings.HasPrefix(output, "SUCCESS:") {
+		return TrackInfo{}, fmt.Errorf("unexpected AppleScript output: %s", output)
+	}
+	return parse_track_info(output[8:])
+}
+
+// parse_track_info parses the
+// "id~name~artist~album~album artist~duration~status~age~genre~year~track number~track count~disc number~disc count~bit rate~kind~cloud status~plays~rating~location"
+// output of GetTrackInfo
+func parse_track_info(output string) (TrackInfo, error) {
+	parts := strings.Split(output, "~")
+	if len(parts) < trackInfoFields+1 {
+		return TrackInfo{}, fmt.Errorf("invalid track info output: %s", output)
+	}
+	// Only the file path is free-form enough to contain the separator, and it comes last
+	if len(parts) > trackInfoFields+1 {
+		parts[trackInfoFields] = strings.Join(parts[trackInfoFields:], "~")
+	}
+
+	info := TrackInfo{
+		Track: Track{
+			Id:        parts[0],
+			Name:      parts[1],
+			Artist:    parts[2],
+			Album:     parts[3],
+			Duration:  parts[5],
+			Status:    parse_track_status(parts[6]),
+			PlayCount: parse_track_number(parts[17]),
+			Rating:    parse_track_number(parts[18]),
+		},
+		AlbumArtist: parts[4],
+		Genre:       parts[8],
+		Year:        parse_track_number(parts[9]),
+		TrackNumber: parse_track_number(parts[10]),
+		TrackCount:  parse_track_number(parts[11]),
+		DiscNumber:  parse_track_number(parts[12]),
+		DiscCount:   parse_track_number(parts[13]),
+		BitRate:     parse_track_number(parts[14]),
+		Kind:        parts[15],
+		CloudStatus: parts[16],
+		Location:    parts[trackInfoFields],
+	}
+	if age, err := strconv.ParseFloat(strings.Replace(parts[7], ",", ".", 1), 64); err == nil {
+		info.Added = clock().Add(-time.Duration(age) * time.Second)
+	}
+	return info, nil
+}
+
+// parse_track_number reads an integer property, treating missing or malformed values as 0
+func parse_track_number(value string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0
+	}
+	return n
+}
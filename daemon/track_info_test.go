@@ -0,0 +1,58 @@
+package daemon
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTrackInfo(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock = func() time.Time { return now }
+	defer func() { clock = time.Now }()
+
+	info, err := parse_track_info("AB12~After Dark~Mr.Kitty~Time~Mr.Kitty~259,1~ok~86400~Darkwave~2014~7~12~1~1~256~Apple Music AAC audio file~subscription~42~80~/Users/me/Music/~odd~/After Dark.m4a")
+	if err != nil {
+		t.Fatalf("parse_track_info() error = %v", err)
+	}
+	want := TrackInfo{
+		Track: Track{
+			Id:        "AB12",
+			Name:      "After Dark",
+			Artist:    "Mr.Kitty",
+			Album:     "Time",
+			Duration:  "259,1",
+			Status:    TrackAvailable,
+			Added:     now.Add(-24 * time.Hour),
+			PlayCount: 42,
+			Rating:    80,
+		},
+		AlbumArtist: "Mr.Kitty",
+		Genre:       "Darkwave",
+		Year:        2014,
+		TrackNumber: 7,
+		TrackCount:  12,
+		DiscNumber:  1,
+		DiscCount:   1,
+		BitRate:     256,
+		Kind:        "Apple Music AAC audio file",
+		CloudStatus: "subscription",
+		Location:    "/Users/me/Music/~odd~/After Dark.m4a",
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("parse_track_info() = %+v, want %+v", info, want)
+	}
+
+	// Streamed tracks have no file and may lack numbering
+	info, err = parse_track_info("CD34~Sunset~The Midnight~Days of Thunder~~301~cloud~~Synthwave~~~~~~~Apple Music AAC audio file~subscription~0~0~")
+	if err != nil {
+		t.Fatalf("parse_track_info() error = %v", err)
+	}
+	if info.Status != TrackCloudOnly || info.Location != "" || info.Year != 0 || !info.Added.IsZero() {
+		t.Errorf("parse_track_info() = %+v, want cloud-only track without location, year or date added", info)
+	}
+
+	if _, err := parse_track_info("garbage"); err == nil {
+		t.Errorf("parse_track_info() expected error for malformed output")
+	}
+}
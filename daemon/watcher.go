@@ -0,0 +1,125 @@
+package daemon
+
+import (
+	"sync"
+	"time"
+)
+
+// TrackChange is published by a TrackWatcher when Music moves to another track
+type TrackChange struct {
+	Previous Track // Zero when nothing was playing before
+	Current  Track // Zero when playback stopped
+	At       time.Time
+}
+
+// TrackWatcher polls Music in the background and publishes track changes on a channel
+type TrackWatcher struct {
+	events    chan TrackChange
+	stop      chan struct{}
+	stopOnce  sync.Once
+	intervals chan time.Duration // Interval changes for the polling goroutine
+
+	mu       sync.Mutex
+	interval time.Duration // Last interval set, see SetInterval
+}
+
+// trackChangeDetector remembers the last seen track to spot changes between polls
+type trackChangeDetector struct {
+	lastId  string
+	last    Track
+	started bool
+}
+
+// observe reports whether id differs from the track seen at the previous poll
+func (t *trackChangeDetector) observe(id string) bool {
+	if !t.started {
+		t.started = true
+		t.lastId = id
+		return id != ""
+	}
+	if id == t.lastId {
+		return false
+	}
+	t.lastId = id
+	return true
+}
+
+// WatchTrackChanges starts a TrackWatcher that checks for track changes every interval.
+// The first event reports the track playing when the watcher started, if any.
+func WatchTrackChanges(interval time.Duration) *TrackWatcher {
+	d := &Daemon{}
+	w := &TrackWatcher{
+		events:    make(chan TrackChange, 8),
+		stop:      make(chan struct{}),
+		intervals: make(chan time.Duration, 1),
+		interval:  interval,
+	}
+	go w.run(interval, d.GetPosition, d.GetCurrentTrack)
+	return w
+}
+
+// Events delivers track changes; it is closed once the watcher stops
+func (w *TrackWatcher) Events() <-chan TrackChange {
+	return w.events
+}
+
+// SetInterval changes how often the watcher polls, so it can slow down while nobody is
+// looking. A shorter interval than before polls right away. It never blocks.
+func (w *TrackWatcher) SetInterval(interval time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if interval <= 0 || interval == w.interval {
+		return
+	}
+	w.interval = interval
+	// Replace a change the goroutine hasn't picked up yet
+	select {
+	case <-w.intervals:
+	default:
+	}
+	w.intervals <- interval
+}
+
+// Stop ends the watcher goroutine
+func (w *TrackWatcher) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+}
+
+func (w *TrackWatcher) run(interval time.Duration, position func() (PlayerPosition, error), current func() (Track, error)) {
+	defer close(w.events)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var detector trackChangeDetector
+	for {
+		// Errors (Music closed, breaker open) are left for the regular status polling to report
+		if pos, err := position(); err == nil && detector.observe(pos.TrackId) {
+			change := TrackChange{Previous: detector.last, At: clock()}
+			if pos.TrackId != "" {
+				if track, err := current(); err == nil {
+					change.Current = track
+				}
+			}
+			detector.last = change.Current
+
+			// Drop the event rather than stall polling if nobody is listening
+			select {
+			case w.events <- change:
+			default:
+			}
+		}
+
+		for waiting := true; waiting; {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				waiting = false
+			case next := <-w.intervals:
+				ticker.Reset(next)
+				waiting = next >= interval
+				interval = next
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrackChangeDetector(t *testing.T) {
+	var detector trackChangeDetector
+	steps := []struct {
+		id   string
+		want bool
+	}{
+		{"4711", true}, // Already playing when the watcher starts
+		{"4711", false},
+		{"4712", true},
+		{"", true}, // Stopped
+		{"", false},
+		{"4711", true},
+	}
+	for i, step := range steps {
+		if got := detector.observe(step.id); got != step.want {
+			t.Errorf("step %d: observe(%q) = %v, want %v", i, step.id, got, step.want)
+		}
+	}
+}
+
+func TestTrackWatcherPublishesChanges(t *testing.T) {
+	ids := []string{"1", "1", "2"}
+	poll := 0
+	position := func() (PlayerPosition, error) {
+		id := ids[min(poll, len(ids)-1)]
+		poll++
+		return PlayerPosition{PlayerState: "playing", TrackId: id}, nil
+	}
+	current := func() (Track, error) {
+		return Track{Id: ids[min(poll-1, len(ids)-1)], Name: "Song " + ids[min(poll-1, len(ids)-1)]}, nil
+	}
+
+	w := &TrackWatcher{events: make(chan TrackChange, 8), stop: make(chan struct{})}
+	go w.run(time.Millisecond, position, current)
+	defer w.Stop()
+
+	first := <-w.Events()
+	if first.Current.Name != "Song 1" || first.Previous.Name != "" {
+		t.Errorf("first change = %+v, want nothing -> Song 1", first)
+	}
+	second := <-w.Events()
+	if second.Current.Name != "Song 2" || second.Previous.Name != "Song 1" {
+		t.Errorf("second change = %+v, want Song 1 -> Song 2", second)
+	}
+}
+
+func TestTrackWatcherSetInterval(t *testing.T) {
+	ids := []string{"1", "2"}
+	polls := make(chan int, 8)
+	poll := 0
+	position := func() (PlayerPosition, error) {
+		id := ids[min(poll, len(ids)-1)]
+		poll++
+		polls <- poll
+		return PlayerPosition{PlayerState: "playing", TrackId: id}, nil
+	}
+	current := func() (Track, error) {
+		return Track{Name: "Song " + ids[min(poll-1, len(ids)-1)]}, nil
+	}
+
+	w := &TrackWatcher{events: make(chan TrackChange, 8), stop: make(chan struct{}), intervals: make(chan time.Duration, 1), interval: time.Hour}
+	go w.run(time.Hour, position, current)
+	defer w.Stop()
+
+	<-polls
+	if first := <-w.Events(); first.Current.Name != "Song 1" {
+		t.Fatalf("first change = %+v, want Song 1", first)
+	}
+	// Slowing down again or setting the same interval doesn't poll
+	w.SetInterval(2 * time.Hour)
+	w.SetInterval(2 * time.Hour)
+	select {
+	case n := <-polls:
+		t.Fatalf("poll %d after slowing down, want none", n)
+	case <-time.After(20 * time.Millisecond):
+	}
+	// Speeding up polls right away
+	w.SetInterval(time.Millisecond)
+	select {
+	case second := <-w.Events():
+		if second.Current.Name != "Song 2" {
+			t.Errorf("second change = %+v, want Song 2", second)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no poll after the interval was shortened")
+	}
+}
@@ -0,0 +1,92 @@
+package daemon
+
+import (
+	"sync"
+	"time"
+)
+
+// scriptCall is one osascript run waiting for, or getting, its result
+type scriptCall struct {
+	script   string
+	label    string
+	coalesce bool
+	queued   time.Time
+	done     chan struct{}
+	out      []byte
+	err      error
+}
+
+// scriptWorker runs scripts one at a time, so calls fired from several goroutines
+// (volume, shuffle, polling...) can't interleave and stall Music.app. Identical
+// read-only queries that are already queued or running share a single run.
+type scriptWorker struct {
+	mu       sync.Mutex
+	inflight map[string]*scriptCall // Coalescable calls not finished yet, by script
+	queue    chan *scriptCall
+	run      func(script string) ([]byte, error)
+	start    sync.Once
+	timings  scriptTimings
+}
+
+func newScriptWorker(run func(script string) ([]byte, error)) *scriptWorker {
+	return &scriptWorker{
+		inflight: make(map[string]*scriptCall),
+		queue:    make(chan *scriptCall, 64),
+		run:      run,
+	}
+}
+
+// scripts executes every osascript invocation made by the daemon
+var scripts = newScriptWorker(exec_script)
+
+// do queues script and waits for its output. With coalesce set, it joins an identical
+// call that is still pending instead of running the script again; only use it for
+// scripts without side effects.
+func (w *scriptWorker) do(script string, coalesce bool) ([]byte, error) {
+	w.start.Do(func() { go w.loop() })
+
+	w.mu.Lock()
+	if coalesce {
+		if call, ok := w.inflight[script]; ok {
+			w.mu.Unlock()
+			<-call.done
+			return call.out, call.err
+		}
+	}
+	call := &scriptCall{
+		script:   script,
+		label:    scriptLabel(script),
+		coalesce: coalesce,
+		queued:   time.Now(),
+		done:     make(chan struct{}),
+	}
+	if coalesce {
+		w.inflight[script] = call
+	}
+	w.mu.Unlock()
+
+	w.queue <- call
+	<-call.done
+	return call.out, call.err
+}
+
+func (w *scriptWorker) loop() {
+	for call := range w.queue {
+		started := time.Now()
+		call.out, call.err = w.run(call.script)
+		w.timings.record(ScriptTiming{
+			Label:  call.label,
+			Wait:   started.Sub(call.queued),
+			Run:    time.Since(started),
+			Failed: call.err != nil,
+			At:     time.Now(),
+		})
+
+		w.mu.Lock()
+		if call.coalesce {
+			delete(w.inflight, call.script)
+		}
+		w.mu.Unlock()
+		close(call.done)
+	}
+}
@@ -0,0 +1,75 @@
+package daemon
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScriptWorkerSerializes(t *testing.T) {
+	var running, maxRunning int32
+	w := newScriptWorker(func(script string) ([]byte, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return []byte(script), nil
+	})
+
+	var wg sync.WaitGroup
+	for _, script := range []string{"a", "b", "c", "d"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out, err := w.do(script, false)
+			if err != nil || string(out) != script {
+				t.Errorf("do(%q) = %q, %v", script, out, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if maxRunning != 1 {
+		t.Errorf("%d scripts ran at once, want 1", maxRunning)
+	}
+}
+
+func TestScriptWorkerCoalescesQueries(t *testing.T) {
+	release := make(chan struct{})
+	var runs int32
+	w := newScriptWorker(func(script string) ([]byte, error) {
+		atomic.AddInt32(&runs, 1)
+		<-release
+		return []byte("playing"), nil
+	})
+
+	var wg sync.WaitGroup
+	query := func() {
+		defer wg.Done()
+		if out, _ := w.do("player state", true); string(out) != "playing" {
+			t.Errorf("do() = %q, want playing", out)
+		}
+	}
+
+	// Start one query and hold it in the worker, then let two more join it
+	wg.Add(1)
+	go query()
+	for atomic.LoadInt32(&runs) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	wg.Add(2)
+	go query()
+	go query()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if runs != 1 {
+		t.Errorf("query ran %d times, want 1", runs)
+	}
+}
@@ -0,0 +1,80 @@
+// Package layout measures and arranges text by the cells it takes up in the terminal,
+// so styled strings, emoji and CJK are truncated, padded and centered the same way in
+// every view. ANSI escape sequences take up no room.
+package layout
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Ellipsis is what Truncate callers usually mark cut text with
+const Ellipsis = "…"
+
+// Width is how many cells s takes up: wide characters count twice, escape sequences
+// not at all
+func Width(s string) int {
+	return ansi.StringWidth(s)
+}
+
+// Strip removes escape sequences from s, leaving the text
+func Strip(s string) string {
+	return ansi.Strip(s)
+}
+
+// Truncate cuts s down to width cells, ending in tail when anything was cut. Wide
+// characters are never split, and styles in s are kept.
+func Truncate(s string, width int, tail string) string {
+	if width <= 0 {
+		return ""
+	}
+	if Width(s) <= width {
+		return s
+	}
+	if Width(tail) > width {
+		tail = ""
+	}
+	return ansi.Truncate(s, width, tail)
+}
+
+// Pad fills s with spaces up to width cells, leaving it alone when it's already as wide
+func Pad(s string, width int) string {
+	if gap := width - Width(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
+
+// PadLeft fills s with spaces in front up to width cells, right-aligning it
+func PadLeft(s string, width int) string {
+	if gap := width - Width(s); gap > 0 {
+		return strings.Repeat(" ", gap) + s
+	}
+	return s
+}
+
+// Fit makes s exactly width cells: truncated with tail when it's too wide, padded when
+// it's too narrow
+func Fit(s string, width int, tail string) string {
+	return Pad(Truncate(s, width, tail), width)
+}
+
+// Center puts s in the middle of width cells by indenting it, truncating it first when
+// it doesn't fit. Nothing is added after s.
+func Center(s string, width int) string {
+	s = Truncate(s, width, Ellipsis)
+	if indent := (width - Width(s)) / 2; indent > 0 {
+		return strings.Repeat(" ", indent) + s
+	}
+	return s
+}
+
+// Wrap breaks s into lines of at most width cells, between words where it can and
+// inside words that are longer than a line
+func Wrap(s string, width int) []string {
+	if width <= 0 {
+		return nil
+	}
+	return strings.Split(ansi.Wrap(s, width, ""), "\n")
+}
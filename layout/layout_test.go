@@ -0,0 +1,128 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+// red wraps s in the escape sequences lipgloss would style it with
+func red(s string) string {
+	return "\x1b[31m" + s + "\x1b[0m"
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"abc", 3},
+		{"", 0},
+		{"東京", 4},
+		{"🎵 Song", 7},
+		{"Café", 4},
+		{red("abc"), 3},
+		{red("東京") + "x", 5},
+	}
+	for _, tt := range tests {
+		if got := Width(tt.s); got != tt.want {
+			t.Errorf("Width(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		tail  string
+		want  string
+	}{
+		{"abcdef", 10, Ellipsis, "abcdef"},
+		{"abcdef", 4, Ellipsis, "abc…"},
+		{"abcdef", 4, "", "abcd"},
+		// A wide character that doesn't fit whole is left out
+		{"東京都", 5, "", "東京"},
+		{"東京都", 4, Ellipsis, "東…"},
+		{"abcdef", 0, Ellipsis, ""},
+		// The tail is dropped when it's wider than the room
+		{"abcdef", 2, "...", "ab"},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.s, tt.width, tt.tail); got != tt.want {
+			t.Errorf("Truncate(%q, %d, %q) = %q, want %q", tt.s, tt.width, tt.tail, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateKeepsStyles(t *testing.T) {
+	got := Truncate(red("abcdef"), 3, "")
+	if Width(got) != 3 || Strip(got) != "abc" {
+		t.Errorf("Truncate(red) = %q, want 3 cells of abc", got)
+	}
+	if got[:5] != "\x1b[31m" {
+		t.Errorf("Truncate(red) = %q, lost the style", got)
+	}
+}
+
+func TestPadAndFit(t *testing.T) {
+	if got := Pad("東", 4); got != "東  " {
+		t.Errorf("Pad(東, 4) = %q", got)
+	}
+	if got := Pad("abcdef", 3); got != "abcdef" {
+		t.Errorf("Pad(abcdef, 3) = %q, want it unchanged", got)
+	}
+	if got := PadLeft("3:05", 6); got != "  3:05" {
+		t.Errorf("PadLeft(3:05, 6) = %q", got)
+	}
+	if got := PadLeft(red("東"), 3); got != " "+red("東") {
+		t.Errorf("PadLeft(red(東), 3) = %q", got)
+	}
+	if got := Fit(red("ab"), 4, Ellipsis); Width(got) != 4 || Strip(got) != "ab  " {
+		t.Errorf("Fit(red(ab), 4) = %q", got)
+	}
+	if got := Fit("東京都", 5, ""); got != "東京 " {
+		t.Errorf("Fit(東京都, 5) = %q, want 東京 and a space", got)
+	}
+}
+
+func TestCenter(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"ab", 6, "  ab"},
+		{"東京", 8, "  東京"},
+		{red("ab"), 6, "  " + red("ab")},
+		{"abcdef", 4, "abc…"},
+	}
+	for _, tt := range tests {
+		if got := Center(tt.s, tt.width); got != tt.want {
+			t.Errorf("Center(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  []string
+	}{
+		{"one two three", 7, []string{"one two", "three"}},
+		{"東京 大阪 京都", 5, []string{"東京", "大阪", "京都"}},
+		{"abcdefgh", 3, []string{"abc", "def", "gh"}},
+		{"short", 10, []string{"short"}},
+	}
+	for _, tt := range tests {
+		got := Wrap(tt.s, tt.width)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Wrap(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+		for _, line := range got {
+			if Width(line) > tt.width {
+				t.Errorf("Wrap(%q, %d) has a line %d cells wide", tt.s, tt.width, Width(line))
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package locale
+
+// english is the text every other catalog translates
+var english = Catalog{
+	// Loading and empty states
+	"loading":         "Loading...",
+	"loading.tab":     "Loading %s...",
+	"loading.library": "Loading library...",
+	"loading.songs":   "Loading songs...",
+	"loading.queue":   "Loading queue information...",
+	"loading.lyrics":  "Loading lyrics...",
+	"library.empty":   "Nothing in your library.",
+	"select_playlist": "Select a playlist (Tab to switch panes)",
+	"error":           "Error: %v",
+
+	// Search box
+	"search.placeholder": "[Search box]",
+	"search.prompt":      "Search...",
+	"search.help":        "Help: / search • Esc cancel",
+	"queue.filter":       "Filter: ",
+
+	// Instructions bar, after "Focus: <pane> | ". Each label follows the keys bound to it.
+	"focus":                    "Focus: %s | ",
+	"focus.search":             "Search",
+	"focus.playlists":          "Playlists",
+	"focus.main":               "Main",
+	"instructions.quit":        "quit",
+	"instructions.help":        "keys",
+	"instructions.cycle":       "cycle",
+	"instructions.panes":       "vim nav",
+	"instructions.navigate":    "navigate",
+	"instructions.select":      "select",
+	"instructions.play_song":   "play song",
+	"instructions.search":      "search",
+	"instructions.play_pause":  "play/pause",
+	"instructions.shuffle":     "shuffle",
+	"instructions.repeat":      "repeat",
+	"instructions.volume":      "volume",
+	"instructions.volume_fine": "volume 1%",
+
+	// Song list, the title's labels after " · " and the column headers
+	"songs.fetch_error":   "Error fetching playlist: %v",
+	"songs.no_cache":      "Playlist cache not available.",
+	"songs.empty":         "No tracks found in this playlist.",
+	"songs.no_match":      "No songs match %q.",
+	"songs.smart":         "smart playlist · read-only",
+	"songs.sorted_by":     "sorted by %s",
+	"songs.filter":        "filter: %s",
+	"songs.find":          "find: %s",
+	"songs.no_find_match": "no matches",
+	"songs.visual":        "VISUAL %d marked",
+	"songs.results_for":   "Search Results for: \"%s\"",
+	"songs.no_results":    "No results found.",
+	"sort.playlist_order": "playlist order",
+	"sort.date_added":     "date added",
+	"column.name":         "Name",
+	"column.artist":       "Artist",
+	"column.album":        "Album",
+	"column.genre":        "Genre",
+	"column.duration":     "Duration",
+	"column.year":         "Year",
+	"column.plays":        "Plays",
+	"column.rating":       "Rating",
+	"column.added":        "Added",
+
+	// Key hints at the bottom of overlays
+	"hint.close":            "Esc close",
+	"hint.scroll":           "↑↓ scroll • Esc close",
+	"hint.confirm":          "y confirm • n/Esc cancel",
+	"hint.doctor":           "r run again • Esc close",
+	"hint.key_conflicts":    "Press Enter or Esc to continue • run 'amtui keys verify' to re-check",
+	"hint.metadata":         "Tab next field • Enter save • Esc cancel",
+	"hint.palette":          "↑↓ choose • Enter run • Esc close",
+	"hint.permission":       "o open System Settings • r retry • Esc dismiss • q quit",
+	"hint.playlist_picker":  "↑↓ choose • Enter add • Esc cancel",
+	"hint.queue.navigation": "Navigation: ",
+	"hint.queue.select":     "select",
+	"hint.queue.play":       "skip to track",
+	"hint.queue.move":       "move",
+	"hint.queue.add":        "add",
+	"hint.queue.remove":     "remove",
+	"hint.queue.clear":      "clear",
+	"hint.queue.current":    "current",
+	"hint.queue.filter":     "filter",
+	"hint.queue.close":      "close",
+	"hint.queue.refresh":    "refresh",
+	"hint.queue_restore":    "y/Enter restore • n/Esc discard",
+	"hint.queue_search":     "Enter search/add • ↑↓ select • Esc back to queue",
+	"hint.theme_picker":     "↑↓ preview • Enter keep • Esc cancel",
+	"hint.track_picker":     "↑↓ select • Enter add to queue • Esc cancel",
+	"hint.now_playing":      "v visualizer: %s • Esc close",
+	"hint.preflight":        "r check again • o open System Settings • c continue anyway • q quit",
+	"hint.preflight_wait":   "q quit",
+	"hint.music_down":       "Enter launch Music • q quit",
+	"hint.history":          "↑↓ choose • Enter play • Esc close",
+
+	// Song context menu
+	"menu.play":                  "Play",
+	"menu.play_next":             "Play Next",
+	"menu.add_to_queue":          "Add To Queue",
+	"menu.add_to_playlist":       "Add To Playlist…",
+	"menu.add_album_to_queue":    "Add Album To Queue",
+	"menu.add_playlist_to_queue": "Add Playlist To Queue",
+	"menu.play_as":               "Play As: ◂ %s ▸",
+	"menu.reveal_in_finder":      "Reveal in Finder",
+	"menu.copy_file_path":        "Copy File Path",
+	"menu.edit_metadata":         "Edit Metadata…",
+	"menu.remove_from_playlist":  "Remove From Playlist…",
+	"menu.delete_from_library":   "Delete From Library…",
+
+	// Placeholder shown while Music.app is closed
+	"music_down.title":    "Music isn't running",
+	"music_down.launch":   "Press Enter to launch Music",
+	"music_down.waiting":  "amtui picks up again as soon as Music is open.",
+	"music_down.starting": "Starting Music.app…",
+
+	// Tab bar
+	"view.playlists": "Playlists",
+	"view.search":    "Search",
+	"view.queue":     "Queue",
+	"view.lyrics":    "Lyrics",
+	"view.history":   "History",
+	"view.stats":     "Stats",
+
+	// Panel titles
+	"theme_picker.title": "Theme",
+	"now_playing.title":  "Now Playing",
+	"preflight.title":    "Getting ready",
+	"history.title":      "History",
+	"history.empty":      "Nothing played yet",
+	"nothing_playing":    "Nothing playing",
+}
@@ -0,0 +1,124 @@
+package locale
+
+// french is the French translation. Key names stay as they are, since they're what
+// has to be pressed.
+var french = Catalog{
+	"loading":         "Chargement...",
+	"loading.tab":     "Chargement (%s)...",
+	"loading.library": "Chargement de la bibliothèque...",
+	"loading.songs":   "Chargement des morceaux...",
+	"loading.queue":   "Chargement de la file d'attente...",
+	"loading.lyrics":  "Chargement des paroles...",
+	"library.empty":   "Votre bibliothèque est vide.",
+	"select_playlist": "Choisissez une playlist (Tab pour changer de panneau)",
+	"error":           "Erreur : %v",
+
+	"search.placeholder": "[Recherche]",
+	"search.prompt":      "Rechercher...",
+	"search.help":        "Aide : / rechercher • Esc annuler",
+	"queue.filter":       "Filtrer : ",
+
+	"focus":                    "Panneau : %s | ",
+	"focus.search":             "Recherche",
+	"focus.playlists":          "Playlists",
+	"focus.main":               "Morceaux",
+	"instructions.quit":        "quitter",
+	"instructions.help":        "touches",
+	"instructions.cycle":       "panneau suivant",
+	"instructions.panes":       "nav vim",
+	"instructions.navigate":    "naviguer",
+	"instructions.select":      "choisir",
+	"instructions.play_song":   "lire",
+	"instructions.search":      "rechercher",
+	"instructions.play_pause":  "lecture/pause",
+	"instructions.shuffle":     "aléatoire",
+	"instructions.repeat":      "répéter",
+	"instructions.volume":      "volume",
+	"instructions.volume_fine": "volume 1 %",
+
+	"songs.fetch_error":   "Erreur au chargement de la playlist : %v",
+	"songs.no_cache":      "Cache des playlists indisponible.",
+	"songs.empty":         "Aucun morceau dans cette playlist.",
+	"songs.no_match":      "Aucun morceau ne correspond à %q.",
+	"songs.smart":         "playlist intelligente · lecture seule",
+	"songs.sorted_by":     "triée par %s",
+	"songs.filter":        "filtre : %s",
+	"songs.find":          "recherche : %s",
+	"songs.no_find_match": "aucun résultat",
+	"songs.visual":        "VISUEL %d marqués",
+	"songs.results_for":   "Résultats pour : « %s »",
+	"songs.no_results":    "Aucun résultat.",
+	"sort.playlist_order": "ordre de la playlist",
+	"sort.date_added":     "date d'ajout",
+	"column.name":         "Titre",
+	"column.artist":       "Artiste",
+	"column.album":        "Album",
+	"column.genre":        "Genre",
+	"column.duration":     "Durée",
+	"column.year":         "Année",
+	"column.plays":        "Écoutes",
+	"column.rating":       "Note",
+	"column.added":        "Ajout",
+
+	"hint.close":            "Esc fermer",
+	"hint.scroll":           "↑↓ défiler • Esc fermer",
+	"hint.confirm":          "y confirmer • n/Esc annuler",
+	"hint.doctor":           "r relancer • Esc fermer",
+	"hint.key_conflicts":    "Enter ou Esc pour continuer • 'amtui keys verify' pour revérifier",
+	"hint.metadata":         "Tab champ suivant • Enter enregistrer • Esc annuler",
+	"hint.palette":          "↑↓ choisir • Enter exécuter • Esc fermer",
+	"hint.permission":       "o ouvrir les Réglages Système • r réessayer • Esc ignorer • q quitter",
+	"hint.playlist_picker":  "↑↓ choisir • Enter ajouter • Esc annuler",
+	"hint.queue.navigation": "Navigation : ",
+	"hint.queue.select":     "choisir",
+	"hint.queue.play":       "passer au morceau",
+	"hint.queue.move":       "déplacer",
+	"hint.queue.add":        "ajouter",
+	"hint.queue.remove":     "retirer",
+	"hint.queue.clear":      "vider",
+	"hint.queue.current":    "en cours",
+	"hint.queue.filter":     "filtrer",
+	"hint.queue.close":      "fermer",
+	"hint.queue.refresh":    "actualiser",
+	"hint.queue_restore":    "y/Enter restaurer • n/Esc abandonner",
+	"hint.queue_search":     "Enter rechercher/ajouter • ↑↓ choisir • Esc retour à la file",
+	"hint.theme_picker":     "↑↓ aperçu • Enter garder • Esc annuler",
+	"hint.track_picker":     "↑↓ choisir • Enter ajouter à la file • Esc annuler",
+	"hint.now_playing":      "v visualiseur : %s • Esc fermer",
+	"hint.preflight":        "r revérifier • o ouvrir les Réglages Système • c continuer quand même • q quitter",
+	"hint.preflight_wait":   "q quitter",
+	"hint.music_down":       "Enter ouvrir Music • q quitter",
+	"hint.history":          "↑↓ choisir • Enter lire • Esc fermer",
+
+	"menu.play":                  "Lire",
+	"menu.play_next":             "Lire ensuite",
+	"menu.add_to_queue":          "Ajouter à la file",
+	"menu.add_to_playlist":       "Ajouter à une playlist…",
+	"menu.add_album_to_queue":    "Ajouter l'album à la file",
+	"menu.add_playlist_to_queue": "Ajouter la playlist à la file",
+	"menu.play_as":               "Lire en : ◂ %s ▸",
+	"menu.reveal_in_finder":      "Afficher dans le Finder",
+	"menu.copy_file_path":        "Copier le chemin du fichier",
+	"menu.edit_metadata":         "Modifier les informations…",
+	"menu.remove_from_playlist":  "Retirer de la playlist…",
+	"menu.delete_from_library":   "Supprimer de la bibliothèque…",
+
+	"music_down.title":    "Music n'est pas ouvert",
+	"music_down.launch":   "Appuyez sur Enter pour ouvrir Music",
+	"music_down.waiting":  "amtui reprend dès que Music est ouvert.",
+	"music_down.starting": "Ouverture de Music.app…",
+
+	"view.playlists": "Playlists",
+	"view.search":    "Recherche",
+	"view.queue":     "File",
+	"view.lyrics":    "Paroles",
+	"view.history":   "Historique",
+	"view.stats":     "Stats",
+
+	"theme_picker.title": "Thème",
+	"now_playing.title":  "En cours de lecture",
+	"preflight.title":    "Préparation",
+	"history.title":      "Historique",
+	"history.empty":      "Rien écouté pour l'instant",
+	"nothing_playing":    "Aucune lecture en cours",
+}
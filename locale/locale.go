@@ -0,0 +1,100 @@
+// Package locale holds the text amtui shows, in every language it's translated to. The
+// language is picked once at startup with Set; T then looks messages up in it, falling
+// back to English for anything not translated yet.
+//
+// Catalogs cover the interface itself: labels, hints, menus and instructions. Toasts
+// and status messages, which mostly pass on errors from Music, stay in English.
+package locale
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Catalog maps message IDs to text, with fmt verbs where T fills in arguments
+type Catalog map[string]string
+
+// catalogs are the translations by language code. English is the reference: every
+// message has an English text, and the others may leave some out.
+var catalogs = map[string]Catalog{
+	"en": english,
+	"fr": french,
+}
+
+// current is the catalog T reads, set by Set
+var (
+	current     = english
+	currentLang = "en"
+)
+
+// Languages lists the language codes there are catalogs for
+func Languages() []string {
+	return slices.Sorted(maps.Keys(catalogs))
+}
+
+// Detect picks the language for the [ui] language setting: a language code ("fr",
+// "fr_CA.UTF-8") or "auto" to follow LC_ALL, LC_MESSAGES and LANG, in that order, as
+// getenv reports them. Languages without a catalog get English, reported as an error
+// when the setting named them.
+func Detect(setting string, getenv func(string) string) (string, error) {
+	if setting != "" && !strings.EqualFold(setting, "auto") {
+		if lang := language(setting); catalogs[lang] != nil {
+			return lang, nil
+		}
+		return "en", fmt.Errorf("no translation for language %q (use auto or one of %s)",
+			setting, strings.Join(Languages(), ", "))
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := getenv(name)
+		if value == "" {
+			continue
+		}
+		// The first variable set decides, even when there's no catalog for it
+		if lang := language(value); catalogs[lang] != nil {
+			return lang, nil
+		}
+		break
+	}
+	return "en", nil
+}
+
+// language reduces a locale name like "pt_BR.UTF-8@euro" to its language, "pt"
+func language(locale string) string {
+	lang, _, _ := strings.Cut(locale, ".")
+	lang, _, _ = strings.Cut(lang, "@")
+	lang, _, _ = strings.Cut(lang, "_")
+	lang, _, _ = strings.Cut(lang, "-")
+	return strings.ToLower(lang)
+}
+
+// Set makes T use the catalog for lang, English when there's none
+func Set(lang string) {
+	if catalog, ok := catalogs[lang]; ok {
+		current, currentLang = catalog, lang
+		return
+	}
+	current, currentLang = english, "en"
+}
+
+// Current is the language T is using
+func Current() string {
+	return currentLang
+}
+
+// T returns the text of message id in the current language, formatted with args when
+// there are any. Messages missing from the catalog are taken from English, and IDs
+// missing from English are returned as they are so they stand out.
+func T(id string, args ...any) string {
+	text, ok := current[id]
+	if !ok {
+		if text, ok = english[id]; !ok {
+			text = id
+		}
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
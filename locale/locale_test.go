@@ -0,0 +1,76 @@
+package locale
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// verbs matches the fmt verbs in a message
+var verbs = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestCatalogsMatchEnglish(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for id, text := range catalog {
+			source, ok := english[id]
+			if !ok {
+				t.Errorf("%s: %q isn't an English message", lang, id)
+				continue
+			}
+			if got, want := verbs.FindAllString(text, -1), verbs.FindAllString(source, -1); !slices.Equal(got, want) {
+				t.Errorf("%s: %q has verbs %q, English has %q", lang, id, got, want)
+			}
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		setting string
+		env     map[string]string
+		want    string
+		wantErr bool
+	}{
+		{"auto", nil, "en", false},
+		{"", map[string]string{"LANG": "fr_FR.UTF-8"}, "fr", false},
+		{"auto", map[string]string{"LANG": "en_US.UTF-8", "LC_MESSAGES": "fr_CA"}, "fr", false},
+		{"auto", map[string]string{"LC_ALL": "C", "LANG": "fr_FR.UTF-8"}, "en", false},
+		{"auto", map[string]string{"LANG": "ja_JP.UTF-8"}, "en", false},
+		{"fr", map[string]string{"LANG": "en_US.UTF-8"}, "fr", false},
+		{"FR-be", nil, "fr", false},
+		{"klingon", map[string]string{"LANG": "fr_FR.UTF-8"}, "en", true},
+	}
+	for _, tt := range tests {
+		got, err := Detect(tt.setting, func(name string) string { return tt.env[name] })
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("Detect(%q, %v) = %q, %v; want %q, error %v", tt.setting, tt.env, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	defer Set("en")
+
+	Set("fr")
+	if got, want := T("menu.play"), "Lire"; got != want {
+		t.Errorf("T(menu.play) = %q, want %q", got, want)
+	}
+	if got, want := T("menu.play_as", "album-shuffle"), "Lire en : ◂ album-shuffle ▸"; got != want {
+		t.Errorf("T(menu.play_as) = %q, want %q", got, want)
+	}
+
+	// Untranslated messages fall back to English, unknown ones to their ID
+	delete(french, "loading")
+	defer func() { french["loading"] = "Chargement..." }()
+	if got, want := T("loading"), "Loading..."; got != want {
+		t.Errorf("T(loading) = %q, want %q", got, want)
+	}
+	if got, want := T("no.such.message"), "no.such.message"; got != want {
+		t.Errorf("T(no.such.message) = %q, want %q", got, want)
+	}
+
+	Set("xx")
+	if got := Current(); got != "en" {
+		t.Errorf("Set(xx) left the language at %q, want en", got)
+	}
+}
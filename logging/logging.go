@@ -0,0 +1,77 @@
+// Package logging sends amtui's log to a file, as anything printed while the TUI runs
+// would end up over the interface.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"main/config"
+)
+
+// maxLogSize is how big the log grows before it's moved aside to amtui.log.1 on startup
+const maxLogSize = 5 << 20
+
+// Levels lists the names --log-level takes, quietest last
+var Levels = []string{"debug", "info", "warn", "error", "off"}
+
+// ParseLevel reads a --log-level name. off is reported as ok with discard set.
+func ParseLevel(name string) (level slog.Level, discard bool, err error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, false, nil
+	case "info", "":
+		return slog.LevelInfo, false, nil
+	case "warn", "warning":
+		return slog.LevelWarn, false, nil
+	case "error":
+		return slog.LevelError, false, nil
+	case "off":
+		return 0, true, nil
+	}
+	return 0, false, fmt.Errorf("unknown log level %q (use %s)", name, strings.Join(Levels, ", "))
+}
+
+// Path returns where the log is written
+func Path() (string, error) {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "amtui.log"), nil
+}
+
+// Setup makes the default slog logger write records of at least level name to the log
+// file. The returned function closes the file once the program is done.
+func Setup(name string) (func() error, error) {
+	level, discard, err := ParseLevel(name)
+	if err != nil {
+		return nil, err
+	}
+	if discard {
+		slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		return func() error { return nil }, nil
+	}
+
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	// Keep one old log around rather than letting it grow forever
+	if info, err := os.Stat(path); err == nil && info.Size() > maxLogSize {
+		_ = os.Rename(path, path+".1")
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log: %w", err)
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(file, &slog.HandlerOptions{Level: level})))
+	return file.Close, nil
+}
@@ -1,14 +1,54 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
+	"main/logging"
 	"main/tui"
 )
 
 func main() {
-	if err := tui.Run(); err != nil {
+	// amtui keys verify [config.toml]: check keybindings without starting the UI
+	if len(os.Args) >= 3 && os.Args[1] == "keys" && os.Args[2] == "verify" {
+		path := ""
+		if len(os.Args) >= 4 {
+			path = os.Args[3]
+		}
+		ok, err := tui.VerifyKeys(os.Stdout, path)
+		if err != nil {
+			fmt.Printf("Error verifying keys: %v\n", err)
+			os.Exit(2)
+		}
+		if !ok {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// amtui doctor: check the connection to Music without starting the UI
+	if len(os.Args) >= 2 && os.Args[1] == "doctor" {
+		if !tui.Doctor(os.Stdout) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	safeMode := flag.Bool("safe-mode", false, "start with the default config, no hooks and no saved state")
+	logLevel := flag.String("log-level", "info", "what to write to ~/.cache/amtui/amtui.log: debug, info, warn, error or off")
+	flag.Parse()
+
+	// Nothing may be printed while the TUI runs, so everything worth knowing goes to the log
+	closeLog, err := logging.Setup(*logLevel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error setting up the log: %v\n", err)
+		os.Exit(2)
+	}
+
+	err = tui.Run(tui.Options{SafeMode: *safeMode})
+	closeLog()
+	if err != nil {
 		fmt.Printf("Error running program: %v", err)
 		os.Exit(1)
 	}
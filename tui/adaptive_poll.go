@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// idlePollInterval is how often the status is polled while nothing plays or the
+	// terminal is in the background. The progress bar keeps moving on its own.
+	idlePollInterval = 5 * time.Second
+	// awayPollInterval is how often it's polled while both are the case
+	awayPollInterval = 15 * time.Second
+	// interactionGrace is how long polling stays at full rate after a key or a click,
+	// so pressing play in a paused player shows up right away
+	interactionGrace = 30 * time.Second
+)
+
+// Message sent when the next playback status poll is due
+type pollTickMsg struct {
+	seq int
+}
+
+// nextPollInterval is how long to wait before the next poll: the configured interval
+// while the user is around and music plays, longer otherwise
+func (m playbackModel) nextPollInterval() time.Duration {
+	interval := m.pollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	if m.steady || time.Since(m.lastInteraction) < interactionGrace {
+		return interval
+	}
+	idle := m.status.PlayerState != "playing"
+	switch {
+	case idle && m.blurred:
+		return max(interval, awayPollInterval)
+	case idle || m.blurred:
+		return max(interval, idlePollInterval)
+	}
+	return interval
+}
+
+// schedulePoll waits interval for the next poll of the current polling chain
+func (m *playbackModel) schedulePoll(interval time.Duration) tea.Cmd {
+	m.pollDelay = interval
+	seq := m.pollSeq
+	return tea.Tick(interval, func(time.Time) tea.Msg { return pollTickMsg{seq: seq} })
+}
+
+// wake notes an interaction. When the next poll is further off than the configured
+// interval, it's brought forward; the poll waited for is dropped.
+func (m *playbackModel) wake() tea.Cmd {
+	m.lastInteraction = time.Now()
+	if m.polling || m.pollDelay <= m.nextPollInterval() {
+		return nil // A poll is on its way, or due soon anyway
+	}
+	m.pollSeq++
+	return m.schedulePoll(0)
+}
+
+// wakePolling brings playback polling back to full rate after a key or a click
+func (m *Model) wakePolling() tea.Cmd {
+	var cmd tea.Cmd
+	m.boxer.EditLeaf("playback", func(model tea.Model) (tea.Model, error) {
+		pb := model.(playbackModel)
+		cmd = pb.wake()
+		return pb, nil
+	})
+	return cmd
+}
+
+// setTerminalFocused slows polling while the terminal is in the background, and brings
+// it back to full rate when it's in front again
+func (m *Model) setTerminalFocused(focused bool) tea.Cmd {
+	var cmd tea.Cmd
+	m.boxer.EditLeaf("playback", func(model tea.Model) (tea.Model, error) {
+		pb := model.(playbackModel)
+		pb.blurred = !focused
+		if focused {
+			cmd = pb.wake()
+		}
+		return pb, nil
+	})
+	return cmd
+}
+
+// syncTrackWatcher has the track watcher follow status polling: every second while
+// polling runs at full rate, as slow as polling while nobody is looking
+func (m *Model) syncTrackWatcher() {
+	if m.trackWatcher == nil {
+		return
+	}
+	interval := time.Second
+	m.boxer.EditLeaf("playback", func(model tea.Model) (tea.Model, error) {
+		pb := model.(playbackModel)
+		if next := pb.nextPollInterval(); next > pb.pollInterval {
+			interval = max(interval, next)
+		}
+		return pb, nil
+	})
+	m.trackWatcher.SetInterval(interval)
+}
@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/config"
+	"main/daemon"
+)
+
+const (
+	// alarmCheckInterval is the longest the clock goes unchecked while an alarm is set.
+	// Timers stop while the Mac sleeps, so a single one set for the alarm time could go
+	// off late.
+	alarmCheckInterval = time.Minute
+	// alarmGrace is how late an alarm still starts playing, as when amtui was started or
+	// the Mac woke up just after it was due. Later than that it's reported as missed.
+	alarmGrace = 15 * time.Minute
+)
+
+// alarm is a playlist set with :alarm to start playing at a given time, kept between runs
+type alarm struct {
+	At       time.Time `json:"at"`
+	Playlist string    `json:"playlist"`
+}
+
+// label describes the alarm as "07:30 Morning Mix"
+func (a alarm) label() string {
+	return a.At.Format("15:04") + " " + a.Playlist
+}
+
+// Message sent to check whether the alarm set for at is due
+type alarmMsg struct {
+	at time.Time
+}
+
+// alarmPath is where the pending alarm is kept between runs
+func alarmPath() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "alarm.json"), nil
+}
+
+// loadAlarm reads the pending alarm. A missing or unreadable file yields nil.
+func loadAlarm() *alarm {
+	path, err := alarmPath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var a alarm
+	if err := json.Unmarshal(data, &a); err != nil || a.Playlist == "" {
+		return nil
+	}
+	return &a
+}
+
+// saveAlarm writes a to disk, or removes the saved alarm when a is nil
+func saveAlarm(a *alarm) error {
+	path, err := alarmPath()
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove alarm: %w", err)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode alarm: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write alarm: %w", err)
+	}
+	return nil
+}
+
+// nextAlarmTime parses "7:30", "07:30" or "7:30pm" as the next time the clock shows it
+// after now: today if it's still to come, tomorrow otherwise
+func nextAlarmTime(clock string, now time.Time) (time.Time, error) {
+	var parsed time.Time
+	var err error
+	for _, format := range []string{"15:04", "3:04pm", "3pm"} {
+		if parsed, err = time.Parse(format, strings.ToLower(clock)); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, use 07:30 or 7:30am", clock)
+	}
+	at := time.Date(now.Year(), now.Month(), now.Day(), parsed.Hour(), parsed.Minute(), 0, 0, now.Location())
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at, nil
+}
+
+// runAlarmCommand handles :alarm <time> <playlist>, :alarm off and a bare :alarm, which
+// tells when the alarm is set for
+func runAlarmCommand(m *Model, arg string) (tea.Cmd, error) {
+	switch arg {
+	case "":
+		if m.alarm == nil {
+			m.commandLine.message = "no alarm set"
+		} else {
+			m.commandLine.message = fmt.Sprintf("alarm: %s at %s", m.alarm.Playlist,
+				m.alarm.At.Format("Mon 15:04"))
+		}
+		return nil, nil
+	case "off":
+		if m.alarm == nil {
+			return nil, errors.New("no alarm set")
+		}
+		m.commandLine.message = "alarm cancelled"
+		return nil, m.setAlarm(nil)
+	}
+
+	clock, name, _ := strings.Cut(arg, " ")
+	name = strings.Trim(strings.TrimSpace(name), `"'`)
+	if name == "" {
+		return nil, errUsage
+	}
+	at, err := nextAlarmTime(clock, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	names := playlistNames(m)
+	i, err := matchName(names, name)
+	if err != nil {
+		return nil, err
+	}
+	a := &alarm{At: at, Playlist: names[i]}
+	m.commandLine.message = fmt.Sprintf("alarm set: %s in %s", a.label(),
+		time.Until(at).Round(time.Minute))
+	err = m.setAlarm(a)
+	return m.scheduleAlarm(), err
+}
+
+// setAlarm replaces the pending alarm, nil to clear it, and saves it for later runs.
+// The alarm is kept for this run even when saving fails.
+func (m *Model) setAlarm(a *alarm) error {
+	m.alarm = a
+	m.status.alarm = a
+	m.setStatus()
+	if m.safeMode {
+		return nil
+	}
+	return saveAlarm(a)
+}
+
+// scheduleAlarm checks back when the alarm is due, or in alarmCheckInterval if that's
+// sooner
+func (m Model) scheduleAlarm() tea.Cmd {
+	if m.alarm == nil {
+		return nil
+	}
+	at := m.alarm.At
+	wait := min(time.Until(at), alarmCheckInterval)
+	if wait <= 0 {
+		return func() tea.Msg { return alarmMsg{at: at} }
+	}
+	return tea.Tick(wait, func(time.Time) tea.Msg { return alarmMsg{at: at} })
+}
+
+// checkAlarm starts the alarm's playlist once it's due. Checks left over from an alarm
+// that was since changed or cancelled are dropped.
+func (m *Model) checkAlarm(msg alarmMsg) tea.Cmd {
+	if m.alarm == nil || !m.alarm.At.Equal(msg.at) {
+		return nil
+	}
+	late := time.Since(m.alarm.At)
+	if late < 0 {
+		return m.scheduleAlarm()
+	}
+	a := *m.alarm
+	var cmd tea.Cmd
+	if err := m.setAlarm(nil); err != nil {
+		cmd = m.toast(notifyError("%v", err))
+	}
+	if late > alarmGrace {
+		return tea.Batch(cmd, m.toast(notifyError("Missed the %s alarm", a.label())))
+	}
+
+	d := daemon.Daemon{}
+	strategy := m.queueStrategy
+	return tea.Batch(cmd, m.toast(notify("⏰ Playing %s", a.Playlist)),
+		attempt("Error playing "+a.Playlist, func() error {
+			return d.PlaySongAtPositionWithStrategy(a.Playlist, 1, strategy)
+		}))
+}
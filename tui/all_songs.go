@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+)
+
+const (
+	// songPageSize is how many library tracks the Songs tab fetches at a time
+	songPageSize = 500
+	// songPrefetch is how close to the edge of the loaded rows the next page is fetched
+	songPrefetch = 100
+	// allSongsTitle heads the Songs tab's song list
+	allSongsTitle = "All songs"
+)
+
+// Message carrying a page of the library for the Songs tab
+type songPageMsg struct {
+	offset int
+	tracks []daemon.Track
+	total  int
+	err    error
+}
+
+func fetchSongPage(offset int) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		tracks, total, err := d.GetLibraryPage(offset, songPageSize)
+		return songPageMsg{offset: offset, tracks: tracks, total: total, err: err}
+	}
+}
+
+// songPager holds the Songs tab's view of the library. tracks is as long as the library,
+// with zero Tracks standing in for rows whose page hasn't arrived, so the song list can
+// scroll and render the visible window without everything being loaded.
+type songPager struct {
+	tracks    []daemon.Track
+	known     bool         // The library size is known
+	requested map[int]bool // Page offsets loaded or being loaded
+	err       error
+}
+
+// loaded reports whether the track at row has arrived
+func loaded(track daemon.Track) bool {
+	return track.Id != "" || track.Name != ""
+}
+
+// label is the Songs tab's one sidebar entry
+func (p songPager) label() string {
+	if !p.known {
+		return allSongsTitle
+	}
+	return fmt.Sprintf("%s (%d)", allSongsTitle, len(p.tracks))
+}
+
+// request marks the page holding row as requested, returning the command to fetch it
+// unless it already was
+func (p *songPager) request(row int) tea.Cmd {
+	if p.requested == nil {
+		p.requested = make(map[int]bool)
+	}
+	offset := row / songPageSize * songPageSize
+	if p.requested[offset] || (p.known && offset >= len(p.tracks)) {
+		return nil
+	}
+	p.requested[offset] = true
+	return fetchSongPage(offset)
+}
+
+// add stores a page, resizing the list if the library changed size since the last one
+func (p *songPager) add(msg songPageMsg) {
+	if msg.err != nil {
+		p.err = msg.err
+		return
+	}
+	p.err = nil
+	if !p.known || msg.total != len(p.tracks) {
+		tracks := make([]daemon.Track, msg.total)
+		copy(tracks, p.tracks)
+		p.tracks = tracks
+		p.known = true
+	}
+	copy(p.tracks[min(msg.offset, len(p.tracks)):], msg.tracks)
+}
+
+// openAllSongs shows the whole library in the main pane, fetching pages as they scroll
+// into view
+func (m *Model) openAllSongs() tea.Cmd {
+	if m.songs.err != nil {
+		m.songs = songPager{} // Start over after a failed load
+	}
+	m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+		pl := model.(playlistsModel)
+		pl.activeItem = 0
+		return pl, nil
+	})
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		m.views.remember(main)
+		main.view = viewPlaylists
+		main.collection = allSongsTitle
+		main.searchQuery = ""
+		main.searchResults = m.songs.tracks
+		main.selectedSong = 0
+		main.scrollOffset = 0
+		return main, nil
+	})
+	m.currentFocus = focusMain
+	m.updateFocus()
+	return m.songs.request(0)
+}
+
+// setSongPage stores a page of the library and shows it if the song list is open
+func (m *Model) setSongPage(msg songPageMsg) {
+	m.songs.add(msg)
+	m.syncTabItems()
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		if main.collection == allSongsTitle {
+			main.searchResults = m.songs.tracks
+			if m.songs.err != nil {
+				main.searchQuery = "Error: " + m.songs.err.Error()
+			}
+		}
+		return main, nil
+	})
+}
+
+// requestSongPages fetches the pages of the song list that are on screen or about to be
+func (m *Model) requestSongPages() tea.Cmd {
+	first, last, open := 0, 0, false
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		if main.collection == allSongsTitle {
+			open = true
+			first = min(main.scrollOffset, main.selectedSong)
+			last = max(main.scrollOffset+main.height, main.selectedSong)
+		}
+		return main, nil
+	})
+	if !open || m.songs.err != nil {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for _, row := range []int{max(0, first-songPrefetch), first, last, last + songPrefetch} {
+		cmds = append(cmds, m.songs.request(row))
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadedRun narrows tracks to the run of loaded rows around index, so playing from the
+// Songs tab queues what's been fetched rather than placeholders. It returns the run and
+// index's position in it.
+func loadedRun(tracks []daemon.Track, index int) ([]daemon.Track, int) {
+	if index < 0 || index >= len(tracks) || !loaded(tracks[index]) {
+		return nil, 0
+	}
+	start, end := index, index+1
+	for start > 0 && loaded(tracks[start-1]) {
+		start--
+	}
+	for end < len(tracks) && loaded(tracks[end]) {
+		end++
+	}
+	return tracks[start:end], index - start
+}
@@ -0,0 +1,387 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+)
+
+// graphicsProtocol is how cover art is drawn in the terminal
+type graphicsProtocol int
+
+const (
+	graphicsOff   graphicsProtocol = iota // No artwork at all
+	graphicsText                          // Colored half-block characters, for terminals without an image protocol
+	graphicsKitty                         // kitty graphics protocol (kitty, Ghostty, WezTerm)
+	graphicsITerm                         // iTerm2 inline images (iTerm2, WezTerm, VS Code)
+	graphicsSixel                         // DEC sixel (foot, mlterm, xterm -ti vt340...)
+)
+
+// artworkProtocol is chosen once at startup from the [ui] artwork setting, see Run
+var artworkProtocol = graphicsText
+
+// Terminal cells are roughly twice as tall as they are wide; these are the pixel sizes
+// images are scaled to for protocols that need exact dimensions (sixel)
+const (
+	cellPixelWidth  = 10
+	cellPixelHeight = 20
+)
+
+// detectGraphicsProtocol picks the protocol for the [ui] artwork setting ("auto", "kitty",
+// "iterm", "sixel", "text" or "off"), looking at the environment for "auto"
+func detectGraphicsProtocol(setting string, getenv func(string) string) (graphicsProtocol, error) {
+	switch strings.ToLower(setting) {
+	case "off", "none":
+		return graphicsOff, nil
+	case "text":
+		return graphicsText, nil
+	case "kitty":
+		return graphicsKitty, nil
+	case "iterm", "iterm2":
+		return graphicsITerm, nil
+	case "sixel":
+		return graphicsSixel, nil
+	case "", "auto":
+	default:
+		return graphicsText, fmt.Errorf("unknown artwork setting %q (use auto, kitty, iterm, sixel, text or off)", setting)
+	}
+
+	term, program := getenv("TERM"), getenv("TERM_PROGRAM")
+	switch {
+	// Multiplexers swallow image escapes unless configured for passthrough
+	case getenv("TMUX") != "" || strings.HasPrefix(term, "screen"):
+		return graphicsText, nil
+	case getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty" || program == "ghostty" || term == "xterm-ghostty":
+		return graphicsKitty, nil
+	case program == "iTerm.app" || program == "WezTerm" || program == "vscode" || getenv("LC_TERMINAL") == "iTerm2":
+		return graphicsITerm, nil
+	case strings.Contains(term, "sixel") || strings.HasPrefix(term, "foot") || strings.HasPrefix(term, "mlterm"):
+		return graphicsSixel, nil
+	}
+	return graphicsText, nil
+}
+
+// Message carrying the cover art of the playing track
+type artworkMsg struct {
+	trackID string // Database ID of the track the art was fetched for
+	image   image.Image
+	err     error
+}
+
+// fetchArtwork loads the cover art of the current track
+func fetchArtwork(trackID string) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		img, err := d.GetArtwork("")
+		return artworkMsg{trackID: trackID, image: img, err: err}
+	}
+}
+
+// updateArtwork fetches the cover art when the playing track changes
+func (m *Model) updateArtwork(status daemon.PlaybackStatus) tea.Cmd {
+	if artworkProtocol == graphicsOff || status.Track.Id == m.artworkTrack {
+		return nil
+	}
+	m.artworkTrack = status.Track.Id
+	if status.Track.Id == "" {
+		return m.setArtwork(nil)
+	}
+	return fetchArtwork(status.Track.Id)
+}
+
+// setArtwork shows art in the playback bar and the main pane. With kitty, the image is
+// sent to the terminal once here, replacing the previous track's, and the panes only
+// place it.
+func (m *Model) setArtwork(art *coverArt) tea.Cmd {
+	var sequence string
+	if artworkProtocol == graphicsKitty {
+		if m.artworkImage != 0 {
+			sequence = kittyDelete(m.artworkImage)
+		}
+		m.artworkImage = 0
+		if art != nil {
+			// A new ID each time, so the placements in the view change with the image
+			m.artworkImages++
+			if transmit := kittyTransmit(art.image, m.artworkImages); transmit != "" {
+				art.imageID, m.artworkImage = m.artworkImages, m.artworkImages
+				sequence += transmit
+			}
+		}
+	}
+	m.boxer.EditLeaf("playback", func(model tea.Model) (tea.Model, error) {
+		pb := model.(playbackModel)
+		pb.artwork = art
+		return pb, nil
+	})
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		main.artwork = art
+		return main, nil
+	})
+	if sequence == "" {
+		return nil
+	}
+	return m.sendEscape(sequence)
+}
+
+// coverArt is a track's artwork with its renderings cached, since encoding an image
+// for the terminal is too slow to redo on every frame
+type coverArt struct {
+	image    image.Image
+	imageID  int // kitty image ID once sent to the terminal, see setArtwork
+	rendered map[artworkSlot][]string
+}
+
+// artworkSlot identifies one place artwork is drawn at one size
+type artworkSlot struct {
+	id         int // kitty placement ID, so redrawing a slot moves its previous placement
+	cols, rows int
+}
+
+// Kitty placement IDs of the places artwork is shown
+const (
+	artworkSlotPlayback = iota + 1
+	artworkSlotMain
+	artworkSlotNowPlaying
+)
+
+func newCoverArt(img image.Image) *coverArt {
+	return &coverArt{image: img, rendered: make(map[artworkSlot][]string)}
+}
+
+// lines renders the art in a cols x rows cell area, one string per row
+func (a *coverArt) lines(id, cols, rows int) []string {
+	if a == nil || cols < 1 || rows < 1 || artworkProtocol == graphicsOff {
+		return nil
+	}
+	slot := artworkSlot{id: id, cols: cols, rows: rows}
+	if lines, ok := a.rendered[slot]; ok {
+		return lines
+	}
+
+	// kitty keeps images apart from the text, so a placement is enough to show the image
+	// sent once by setArtwork. iTerm2 and sixel images are drawn into the cells, and go
+	// out again whenever the renderer redraws the line they start on.
+	var lines []string
+	switch artworkProtocol {
+	case graphicsKitty:
+		if a.imageID != 0 {
+			lines = imageLines(kittyPlacement(a.imageID, id, cols, rows), cols, rows)
+		}
+	case graphicsITerm:
+		lines = imageLines(keepCursor(itermImage(a.image, cols, rows)), cols, rows)
+	case graphicsSixel:
+		lines = imageLines(keepCursor(sixelImage(scaleImage(a.image, cols*cellPixelWidth, rows*cellPixelHeight))), cols, rows)
+	}
+	if lines == nil {
+		lines = halfBlockArt(a.image, cols, rows, colorProfile)
+	}
+	a.rendered[slot] = lines
+	return lines
+}
+
+// imageLines places an image escape sequence at the start of a cols x rows block of
+// blank cells, which the terminal draws the image over. It returns nil if encoding failed.
+func imageLines(sequence string, cols, rows int) []string {
+	if sequence == "" {
+		return nil
+	}
+	blank := strings.Repeat(" ", cols)
+	lines := make([]string, rows)
+	for i := range lines {
+		lines[i] = blank
+	}
+	lines[0] = sequence + blank
+	return lines
+}
+
+// keepCursor saves and restores the cursor around an image, for terminals that move it past
+// the image, which would shift everything drawn after it
+func keepCursor(sequence string) string {
+	if sequence == "" {
+		return ""
+	}
+	return "\x1b7" + sequence + "\x1b8"
+}
+
+// scaleImage resizes img to width x height, averaging the source pixels behind each target pixel
+func scaleImage(img image.Image, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if srcW == 0 || srcH == 0 {
+		return dst
+	}
+	for y := 0; y < height; y++ {
+		y0, y1 := bounds.Min.Y+y*srcH/height, bounds.Min.Y+max((y+1)*srcH/height, y*srcH/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := bounds.Min.X+x*srcW/width, bounds.Min.X+max((x+1)*srcW/width, x*srcW/width+1)
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, _ := img.At(sx, sy).RGBA()
+					r, g, b, n = r+uint64(cr), g+uint64(cg), b+uint64(cb), n+1
+				}
+			}
+			dst.Set(x, y, color.RGBA{R: uint8(r / n >> 8), G: uint8(g / n >> 8), B: uint8(b / n >> 8), A: 0xff})
+		}
+	}
+	return dst
+}
+
+// encodePNG encodes img for the protocols that take a file
+func encodePNG(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// kittyImageSize is the width and height in pixels images are sent to kitty at, which
+// scales them to the cells of each placement
+const kittyImageSize = 512
+
+// kittyTransmit sends img to the terminal with the kitty graphics protocol, as image id,
+// without showing it
+func kittyTransmit(img image.Image, id int) string {
+	// Scale down first, covers can be 3000px wide
+	data, err := encodePNG(scaleImage(img, kittyImageSize, kittyImageSize))
+	if err != nil {
+		return ""
+	}
+	// Payloads are sent in chunks of at most 4096 bytes; m=1 marks that more follow
+	const chunkSize = 4096
+	var b strings.Builder
+	for start := 0; start < len(data); start += chunkSize {
+		end := min(start+chunkSize, len(data))
+		more := 0
+		if end < len(data) {
+			more = 1
+		}
+		if start == 0 {
+			fmt.Fprintf(&b, "\x1b_Ga=t,f=100,i=%d,q=2,m=%d;%s\x1b\\", id, more, data[start:end])
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, data[start:end])
+		}
+	}
+	return b.String()
+}
+
+// kittyPlacement shows the image sent as id over cols x rows cells at the cursor, as
+// placement, replacing where that placement was shown before
+func kittyPlacement(id, placement, cols, rows int) string {
+	return fmt.Sprintf("\x1b_Ga=p,i=%d,p=%d,c=%d,r=%d,C=1,q=2\x1b\\", id, placement, cols, rows)
+}
+
+// kittyDelete frees the image sent as id, taking down its placements
+func kittyDelete(id int) string {
+	return fmt.Sprintf("\x1b_Ga=d,d=I,i=%d,q=2\x1b\\", id)
+}
+
+// itermImage draws img over cols x rows cells as an iTerm2 inline image
+func itermImage(img image.Image, cols, rows int) string {
+	data, err := encodePNG(scaleImage(img, cols*cellPixelWidth, rows*cellPixelHeight))
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("\x1b]1337;File=inline=1;width=%d;height=%d;preserveAspectRatio=0;doNotMoveCursor=1:%s\a", cols, rows, data)
+}
+
+// sixelLevels is the number of levels per channel of the 6x6x6 color cube sixel images use
+const sixelLevels = 6
+
+// sixelColor maps a pixel to its palette index in the color cube
+func sixelColor(c color.RGBA) int {
+	level := func(v uint8) int { return (int(v)*(sixelLevels-1) + 127) / 255 }
+	return level(c.R)*sixelLevels*sixelLevels + level(c.G)*sixelLevels + level(c.B)
+}
+
+// sixelImage encodes img as DEC sixel graphics
+func sixelImage(img *image.RGBA) string {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\x1bP0;1;0q\"1;1;%d;%d", width, height)
+	for i := 0; i < sixelLevels*sixelLevels*sixelLevels; i++ {
+		r, g, bl := i/(sixelLevels*sixelLevels), i/sixelLevels%sixelLevels, i%sixelLevels
+		percent := func(level int) int { return level * 100 / (sixelLevels - 1) }
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", i, percent(r), percent(g), percent(bl))
+	}
+
+	indices := make([]int, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			indices[y*width+x] = sixelColor(img.RGBAAt(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	// Each band is 6 pixel rows; every color in the band is drawn as its own pass over it
+	row := make([]byte, width)
+	for top := 0; top < height; top += 6 {
+		used := make(map[int]bool)
+		for y := top; y < min(top+6, height); y++ {
+			for x := 0; x < width; x++ {
+				used[indices[y*width+x]] = true
+			}
+		}
+		first := true
+		for c := 0; c < sixelLevels*sixelLevels*sixelLevels; c++ {
+			if !used[c] {
+				continue
+			}
+			if !first {
+				b.WriteByte('$') // Back to the start of the band for the next color
+			}
+			first = false
+			for x := 0; x < width; x++ {
+				bits := 0
+				for k := 0; k < 6 && top+k < height; k++ {
+					if indices[(top+k)*width+x] == c {
+						bits |= 1 << k
+					}
+				}
+				row[x] = byte(63 + bits)
+			}
+			fmt.Fprintf(&b, "#%d", c)
+			writeSixelRun(&b, row)
+		}
+		b.WriteByte('-')
+	}
+	b.WriteString("\x1b\\")
+	return b.String()
+}
+
+// writeSixelRun writes a row of sixel characters, run-length encoding repeats
+func writeSixelRun(b *strings.Builder, row []byte) {
+	for i := 0; i < len(row); {
+		j := i
+		for j < len(row) && row[j] == row[i] {
+			j++
+		}
+		if n := j - i; n > 3 {
+			fmt.Fprintf(b, "!%d%c", n, row[i])
+		} else {
+			b.Write(row[i:j])
+		}
+		i = j
+	}
+}
+
+// artworkMissing reports whether err just means the track has no cover art
+func artworkMissing(err error) bool {
+	return errors.Is(err, daemon.ErrNoArtwork)
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+)
+
+// osc52 is the escape sequence asking the terminal to put text on the clipboard. The
+// terminal does the copying, so it works over SSH too.
+func osc52(text string) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	if os.Getenv("TMUX") != "" {
+		// tmux passes sequences on to the terminal when wrapped, with their escapes doubled
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return seq
+}
+
+// Message asking to copy text to the clipboard
+type clipboardMsg struct {
+	text string
+}
+
+// copyToClipboard copies text through the terminal, with the next frame. Terminals that
+// ignore OSC 52, like Terminal.app, are covered by also copying with pbcopy when
+// running locally.
+func (m *Model) copyToClipboard(msg clipboardMsg) tea.Cmd {
+	sent := m.sendEscape(osc52(msg.text))
+	if os.Getenv("SSH_CONNECTION") != "" {
+		return tea.Batch(sent, m.toast(notify("Copied %s", msg.text)))
+	}
+	return tea.Batch(sent, func() tea.Msg {
+		if err := daemon.CopyToClipboard(msg.text); err != nil {
+			return notifyError("Error copying to the clipboard: %v", err)
+		}
+		return notify("Copied %s", msg.text)
+	})
+}
+
+// yankTrack copies "Title – Artist" of track
+func yankTrack(track daemon.Track) tea.Cmd {
+	return func() tea.Msg {
+		text := track.Name
+		if track.Artist != "" {
+			text += " – " + track.Artist
+		}
+		return clipboardMsg{text: text}
+	}
+}
+
+// yankTrackLink looks track up in the Apple Music catalog and copies its share link
+func yankTrackLink(track daemon.Track) tea.Cmd {
+	return inFlight("Looking up link", func() tea.Msg {
+		d := daemon.Daemon{}
+		link, err := d.CatalogURL(track)
+		switch {
+		case errors.Is(err, daemon.ErrNotInCatalog):
+			return notifyError("'%s' isn't in the Apple Music catalog, there is no link to copy", track.Name)
+		case err != nil:
+			return notifyError("Error copying link: %v", err)
+		}
+		return clipboardMsg{text: link}
+	})
+}
+
+// highlightedTrack returns the song selected in the main pane
+func (m *Model) highlightedTrack() (daemon.Track, bool) {
+	var track daemon.Track
+	var ok bool
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		track, ok = main.trackAtRow(main.selectedSong)
+		return main, nil
+	})
+	return track, ok
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// colorProfile is how many colors the interface is drawn with, chosen once at startup
+// from the [ui] colors setting, see Run. Styles are degraded to it by lipgloss; cover art
+// drawn with half blocks reads it directly.
+var colorProfile = termenv.TrueColor
+
+// detectColorProfile picks the color profile for the [ui] colors setting ("auto",
+// "truecolor", "256", "16" or "none"). "auto" asks detect, which honors NO_COLOR and
+// looks at TERM and COLORTERM.
+func detectColorProfile(setting string, detect func() termenv.Profile) (termenv.Profile, error) {
+	switch strings.ToLower(setting) {
+	case "truecolor", "24bit":
+		return termenv.TrueColor, nil
+	case "256":
+		return termenv.ANSI256, nil
+	case "16":
+		return termenv.ANSI, nil
+	case "none", "off":
+		return termenv.Ascii, nil
+	case "", "auto":
+		return detect(), nil
+	}
+	return detect(), fmt.Errorf("unknown colors setting %q (use auto, truecolor, 256, 16 or none)", setting)
+}
+
+// setColorProfile draws everything with profile from now on. Themes applied afterwards
+// make up for the colors it lacks.
+func setColorProfile(profile termenv.Profile) {
+	colorProfile = profile
+	lipgloss.SetColorProfile(profile)
+}
+
+// colorProfileName is the [ui] colors value for profile
+func colorProfileName(profile termenv.Profile) string {
+	switch profile {
+	case termenv.TrueColor:
+		return "truecolor"
+	case termenv.ANSI256:
+		return "256"
+	case termenv.ANSI:
+		return "16"
+	}
+	return "none"
+}
@@ -0,0 +1,225 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/config"
+	"main/daemon"
+)
+
+// columnSpec describes a song list column that can be chosen with [[ui.columns]]. Its
+// header is the "column.<name>" message of the locale catalogs.
+type columnSpec struct {
+	// fixed is the width of columns whose values are short, 0 for columns that share the
+	// remaining room by weight
+	fixed    int
+	weight   int // Default share of the room for flexible columns
+	minWidth int
+	right    bool // Right-aligned
+	value    func(track daemon.Track, now time.Time) string
+}
+
+var columnSpecs = map[string]columnSpec{
+	"name": {weight: 40, minWidth: 8, value: func(track daemon.Track, now time.Time) string {
+		if track.Unavailable() {
+			return unavailableTrackMarker + track.Name
+		}
+		return track.Name
+	}},
+	"artist": {weight: 30, minWidth: 6, value: func(track daemon.Track, now time.Time) string { return track.Artist }},
+	"album":  {weight: 30, minWidth: 6, value: func(track daemon.Track, now time.Time) string { return track.Album }},
+	"genre":  {weight: 15, minWidth: 6, value: func(track daemon.Track, now time.Time) string { return track.Genre }},
+	// 5 chars fit "3:45" and "59:59"; the longer header is cut to fit
+	"duration": {fixed: 5, right: true, value: func(track daemon.Track, now time.Time) string {
+		return formatTrackDuration(track.Duration)
+	}},
+	"year": {fixed: 4, right: true, value: func(track daemon.Track, now time.Time) string {
+		if track.Year == 0 {
+			return "-"
+		}
+		return strconv.Itoa(track.Year)
+	}},
+	"plays": {fixed: 5, right: true, value: func(track daemon.Track, now time.Time) string {
+		return strconv.Itoa(track.PlayCount)
+	}},
+	"rating": {fixed: 6, value: func(track daemon.Track, now time.Time) string {
+		return formatRating(track.Rating)
+	}},
+	// "11mo ago" is the longest age, "today" the shortest
+	"added": {fixed: 7, right: true, value: func(track daemon.Track, now time.Time) string {
+		return formatAge(track.Added, now)
+	}},
+}
+
+// columnNames lists the columns in the order they're documented
+var columnNames = []string{"name", "artist", "album", "genre", "duration", "year", "plays", "rating", "added"}
+
+// tableColumn is a column of the song list with the share of the room it was given
+type tableColumn struct {
+	name   string
+	weight int
+}
+
+// tableColumns checks the configured columns, filling in default widths
+func tableColumns(configured []config.ColumnConfig) ([]tableColumn, error) {
+	if len(configured) == 0 {
+		configured = config.Default().UI.Columns
+	}
+	columns := make([]tableColumn, 0, len(configured))
+	seen := make(map[string]bool)
+	for _, c := range configured {
+		name := strings.ToLower(strings.TrimSpace(c.Name))
+		spec, ok := columnSpecs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q, expected one of %s", c.Name, strings.Join(columnNames, ", "))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("column %q is listed twice", name)
+		}
+		if c.Width < 0 {
+			return nil, fmt.Errorf("column %q has a negative width: %d", name, c.Width)
+		}
+		seen[name] = true
+		weight := c.Width
+		if weight == 0 {
+			weight = spec.weight
+		}
+		columns = append(columns, tableColumn{name: name, weight: weight})
+	}
+	return columns, nil
+}
+
+// pickColumns checks the columns called names, keeping the width configured for the
+// ones in configured
+func pickColumns(names []string, configured []tableColumn) ([]tableColumn, error) {
+	picked := make([]config.ColumnConfig, len(names))
+	for i, name := range names {
+		picked[i].Name = name
+		for _, c := range configured {
+			if c.name == strings.ToLower(strings.TrimSpace(name)) {
+				picked[i].Width = c.weight
+			}
+		}
+	}
+	return tableColumns(picked)
+}
+
+// runColumnsCommand shows the columns listed, separated by spaces or commas, in the song
+// list. The open playlist keeps them (see viewSettings); no names go back to [[ui.columns]].
+func runColumnsCommand(m *Model, arg string) (tea.Cmd, error) {
+	names := strings.FieldsFunc(arg, func(r rune) bool { return r == ' ' || r == ',' })
+	configured, err := tableColumns(m.config.UI.Columns)
+	if err != nil {
+		configured, _ = tableColumns(nil)
+	}
+	columns := configured
+	if len(names) > 0 {
+		if columns, err = pickColumns(names, configured); err != nil {
+			return nil, err
+		}
+	}
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		main.columns, main.customColumns = columns, len(names) > 0
+		// Listing the "added" column turns it on, like [[ui.columns]]
+		if hasColumn(columns, "added") {
+			main.showAdded = true
+		}
+		return main, nil
+	})
+	return nil, nil
+}
+
+// shownColumns returns the columns to draw: the "added" column appears only while it's toggled
+// on, at the end unless the config placed it
+func shownColumns(columns []tableColumn, showAdded bool) []tableColumn {
+	shown := make([]tableColumn, 0, len(columns)+1)
+	hasAdded := false
+	for _, c := range columns {
+		if c.name == "added" {
+			hasAdded = true
+			if !showAdded {
+				continue
+			}
+		}
+		shown = append(shown, c)
+	}
+	if showAdded && !hasAdded {
+		shown = append(shown, tableColumn{name: "added"})
+	}
+	return shown
+}
+
+// hasColumn reports whether name is one of columns
+func hasColumn(columns []tableColumn, name string) bool {
+	for _, c := range columns {
+		if c.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// columnWidths splits width between columns: fixed-width columns get their width and the
+// rest share what's left by weight, shrinking towards a minimum of 4 when it's tight
+func columnWidths(columns []tableColumn, width int) []int {
+	widths := make([]int, len(columns))
+	// Account for: left padding + a space between columns + the fixed-width columns.
+	// Subtract 8 characters for safety margin to prevent bubbleboxer errors.
+	availableWidth := width - 1 - (len(columns) - 1) - 8
+	totalWeight := 0
+	for _, c := range columns {
+		spec := columnSpecs[c.name]
+		availableWidth -= spec.fixed
+		if spec.fixed == 0 {
+			totalWeight += c.weight
+		}
+	}
+	if availableWidth < 10 {
+		availableWidth = 10 // Very conservative minimum
+	}
+
+	totalNeeded := 1 + len(columns) - 1
+	flexibleTotal := 0
+	for i, c := range columns {
+		spec := columnSpecs[c.name]
+		if spec.fixed > 0 {
+			widths[i] = spec.fixed
+		} else if totalWeight > 0 {
+			widths[i] = max(availableWidth*c.weight/totalWeight, spec.minWidth)
+			flexibleTotal += widths[i]
+		}
+		totalNeeded += widths[i]
+	}
+
+	// Reduce the flexible columns proportionally but protect the fixed ones
+	if excess := totalNeeded - width; excess > 0 && flexibleTotal > excess {
+		reduction := float64(excess) / float64(flexibleTotal)
+		for i, c := range columns {
+			if columnSpecs[c.name].fixed == 0 {
+				widths[i] = max(widths[i]-int(float64(widths[i])*reduction), 4)
+			}
+		}
+	}
+	return widths
+}
+
+// formatTrackDuration converts a duration in seconds, as Music reports it, to m:ss
+func formatTrackDuration(duration string) string {
+	var seconds float64
+	if n, err := fmt.Sscanf(duration, "%f", &seconds); err != nil || n == 0 {
+		return "0:00"
+	}
+	return fmt.Sprintf("%d:%02d", int(seconds)/60, int(seconds)%60)
+}
+
+// formatRating shows a 0-100 rating as five stars
+func formatRating(rating int) string {
+	stars := min(max(rating, 0), 100) / 20
+	return strings.Repeat("★", stars) + strings.Repeat("☆", 5-stars)
+}
@@ -0,0 +1,410 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+)
+
+// commandHistorySize is how many commands up/down can recall
+const commandHistorySize = 50
+
+// errUsage is returned by commands given arguments they don't understand; the command
+// line shows the command's usage instead
+var errUsage = errors.New("usage")
+
+// commandResultMsg reports the outcome of a command that ran in the background
+type commandResultMsg struct {
+	err error
+}
+
+// command is a ":" command. run returns the command to execute, or an error shown in the
+// command line; complete lists candidates for the argument.
+type command struct {
+	name     string
+	aliases  []string
+	usage    string
+	complete func(m *Model) []string
+	run      func(m *Model, arg string) (tea.Cmd, error)
+}
+
+// commands lists everything the command line can run
+var commands = []command{
+	{name: "quit", aliases: []string{"q"}, usage: ":q", run: func(m *Model, arg string) (tea.Cmd, error) {
+		return m.dispatch(actionQuit), nil
+	}},
+	{name: "playlist", aliases: []string{"pl"}, usage: ":playlist <name>", complete: playlistNames, run: runPlaylistCommand},
+	{name: "station", usage: ":station <name>", complete: stationNames, run: runStationCommand},
+	{name: "volume", aliases: []string{"vol"}, usage: ":volume <0-100|+n|-n>", run: runVolumeCommand},
+	{name: "volstep", usage: ":volstep <1-100>", run: runVolumeStepCommand},
+	{name: "seek", usage: ":seek <1:23|+10|-10>", run: runSeekCommand},
+	{name: "next", aliases: []string{"n"}, usage: ":next", run: func(m *Model, arg string) (tea.Cmd, error) {
+		return skipTrack(true), nil
+	}},
+	{name: "prev", aliases: []string{"previous"}, usage: ":prev", run: func(m *Model, arg string) (tea.Cmd, error) {
+		return skipTrack(false), nil
+	}},
+	{name: "play", usage: ":play", run: func(m *Model, arg string) (tea.Cmd, error) {
+		return playerCommand(func(d *daemon.Daemon) error { return d.Play() }), nil
+	}},
+	{name: "pause", usage: ":pause", run: func(m *Model, arg string) (tea.Cmd, error) {
+		return playerCommand(func(d *daemon.Daemon) error { return d.Pause() }), nil
+	}},
+	{name: "shuffle", usage: ":shuffle [on|off]", complete: func(*Model) []string { return []string{"on", "off"} }, run: runShuffleCommand},
+	{name: "repeat", usage: ":repeat [off|one|all]", complete: func(*Model) []string { return []string{"off", "one", "all"} }, run: runRepeatCommand},
+	{name: "search", usage: ":search <query>", run: func(m *Model, arg string) (tea.Cmd, error) {
+		if arg == "" {
+			return nil, errUsage
+		}
+		return m.search(arg), nil
+	}},
+	{name: "columns", aliases: []string{"cols"}, usage: ":columns [<name> ...]", complete: func(*Model) []string { return columnNames }, run: runColumnsCommand},
+	{name: "alarm", usage: ":alarm [<07:30> <playlist>|off]", run: runAlarmCommand},
+	{name: "theme", usage: ":theme <name>", complete: func(m *Model) []string { return m.themes.names }, run: func(m *Model, arg string) (tea.Cmd, error) {
+		return nil, m.themes.selectTheme(arg)
+	}},
+}
+
+// lookupCommand finds a command by name or alias; a unique prefix of a name works too
+func lookupCommand(name string) (command, bool) {
+	var prefixed []command
+	for _, c := range commands {
+		if c.name == name || slices.Contains(c.aliases, name) {
+			return c, true
+		}
+		if strings.HasPrefix(c.name, name) {
+			prefixed = append(prefixed, c)
+		}
+	}
+	if len(prefixed) == 1 {
+		return prefixed[0], true
+	}
+	return command{}, false
+}
+
+// dispatch runs a global action as if its key had been pressed
+func (m *Model) dispatch(action keyAction) tea.Cmd {
+	model, cmd := m.runAction(action, nil)
+	*m = model.(Model)
+	return cmd
+}
+
+// playerCommand runs a daemon call in the background and reports the result
+func playerCommand(call func(d *daemon.Daemon) error) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		return commandResultMsg{err: call(&d)}
+	}
+}
+
+func (m *Model) sidebar() playlistsModel {
+	var pl playlistsModel
+	m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+		pl = model.(playlistsModel)
+		return pl, nil
+	})
+	return pl
+}
+
+func playlistNames(m *Model) []string {
+	return m.sidebar().playlistItems
+}
+
+func stationNames(m *Model) []string {
+	var names []string
+	for _, station := range m.sidebar().stations {
+		names = append(names, station.Name)
+	}
+	return names
+}
+
+// matchName finds name in names, ignoring case; a unique prefix is enough
+func matchName(names []string, name string) (int, error) {
+	lower := strings.ToLower(name)
+	match := -1
+	for i, n := range names {
+		if strings.EqualFold(n, name) {
+			return i, nil
+		}
+		if strings.HasPrefix(strings.ToLower(n), lower) {
+			if match >= 0 {
+				return -1, fmt.Errorf("%q matches more than one name, press Tab to pick", name)
+			}
+			match = i
+		}
+	}
+	if match < 0 {
+		return -1, fmt.Errorf("nothing called %q", name)
+	}
+	return match, nil
+}
+
+func runPlaylistCommand(m *Model, arg string) (tea.Cmd, error) {
+	if arg == "" {
+		return nil, errUsage
+	}
+	i, err := matchName(playlistNames(m), arg)
+	if err != nil {
+		return nil, err
+	}
+	return m.openPlaylistItem(i), nil
+}
+
+func runStationCommand(m *Model, arg string) (tea.Cmd, error) {
+	if arg == "" {
+		return nil, errUsage
+	}
+	i, err := matchName(stationNames(m), arg)
+	if err != nil {
+		return nil, err
+	}
+	return m.openPlaylistItem(len(playlistNames(m)) + i), nil
+}
+
+func runVolumeCommand(m *Model, arg string) (tea.Cmd, error) {
+	value, err := strconv.Atoi(arg)
+	if err != nil {
+		return nil, errUsage
+	}
+	if strings.HasPrefix(arg, "+") || strings.HasPrefix(arg, "-") {
+		m.changeControls(func(s *daemon.PlaybackStatus) { s.Volume = clampVolume(s.Volume + value) })
+		return playerCommand(func(d *daemon.Daemon) error {
+			_, err := d.AdjustVolume(value)
+			return err
+		}), nil
+	}
+	if value < 0 || value > 100 {
+		return nil, fmt.Errorf("volume %d is outside 0-100", value)
+	}
+	m.changeControls(func(s *daemon.PlaybackStatus) { s.Volume = value })
+	return playerCommand(func(d *daemon.Daemon) error { return d.SetVolume(value) }), nil
+}
+
+// runVolumeStepCommand sets how far the volume keys move the volume, kept for later runs
+func runVolumeStepCommand(m *Model, arg string) (tea.Cmd, error) {
+	step, err := strconv.Atoi(arg)
+	if err != nil {
+		return nil, errUsage
+	}
+	if step < 1 || step > 100 {
+		return nil, fmt.Errorf("volume step %d is outside 1-100", step)
+	}
+	m.volumeStep = step
+	if step == m.config.Playback.VolumeStep {
+		m.volumeStep = 0 // Back to following the config
+	}
+	return nil, nil
+}
+
+// parseSeekTarget parses "1:23", "1:02:03" or "83" as a position in seconds, and "+10"
+// or "-10" as an offset from the current position
+func parseSeekTarget(arg string) (seconds float64, relative bool, err error) {
+	if strings.HasPrefix(arg, "+") || strings.HasPrefix(arg, "-") {
+		offset, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid offset %q", arg)
+		}
+		return offset, true, nil
+	}
+	for _, part := range strings.Split(arg, ":") {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil || value < 0 {
+			return 0, false, fmt.Errorf("invalid position %q", arg)
+		}
+		seconds = seconds*60 + value
+	}
+	return seconds, false, nil
+}
+
+func runSeekCommand(m *Model, arg string) (tea.Cmd, error) {
+	target, relative, err := parseSeekTarget(arg)
+	if err != nil {
+		return nil, err
+	}
+	if cmd := m.seek(target, !relative); cmd != nil {
+		return cmd, nil
+	}
+	return nil, errors.New("nothing is playing")
+}
+
+func runShuffleCommand(m *Model, arg string) (tea.Cmd, error) {
+	var on bool
+	switch arg {
+	case "":
+		return m.dispatch(actionShuffle), nil
+	case "on":
+		on = true
+	case "off":
+	default:
+		return nil, errUsage
+	}
+	m.changeControls(func(s *daemon.PlaybackStatus) { s.Shuffle = on })
+	return playerCommand(func(d *daemon.Daemon) error { return d.SetShuffle(on) }), nil
+}
+
+func runRepeatCommand(m *Model, arg string) (tea.Cmd, error) {
+	switch arg {
+	case "":
+		return m.dispatch(actionRepeat), nil
+	case "off", "one", "all":
+	default:
+		return nil, errUsage
+	}
+	m.changeControls(func(s *daemon.PlaybackStatus) { s.RepeatMode = arg })
+	return playerCommand(func(d *daemon.Daemon) error { return d.SetRepeat(arg) }), nil
+}
+
+// commandLineModel is the ":" prompt shown in place of the instructions
+type commandLineModel struct {
+	input   lineInput
+	message string // Outcome of the last command, shown until the next key
+	// Tab cycles through completions of the word being typed
+	completions     []string
+	completionIndex int
+	completionBase  string // Input before the word being completed
+	// Earlier commands, most recent last, recalled with up/down
+	history      []string
+	historyIndex int
+}
+
+// runCommandLine runs the command typed at the prompt
+func (m *Model) runCommandLine(input string) tea.Cmd {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil
+	}
+	history := append(m.commandLine.history, input)
+	if len(history) > commandHistorySize {
+		history = history[len(history)-commandHistorySize:]
+	}
+	m.commandLine.history = history
+
+	name, arg, _ := strings.Cut(input, " ")
+	c, ok := lookupCommand(name)
+	if !ok {
+		m.commandLine.message = fmt.Sprintf("E: not a command: %s", name)
+		return nil
+	}
+	cmd, err := c.run(m, strings.TrimSpace(arg))
+	if errors.Is(err, errUsage) {
+		m.commandLine.message = "usage: " + c.usage
+	} else if err != nil {
+		m.commandLine.message = "E: " + err.Error()
+	}
+	return cmd
+}
+
+// completeCommandLine fills in the word being typed, cycling through the candidates on each Tab
+func (m *Model) completeCommandLine(step int) {
+	cl := &m.commandLine
+	if len(cl.completions) == 0 {
+		name, arg, hasArg := strings.Cut(cl.input.text, " ")
+		var candidates []string
+		var word string
+		if !hasArg {
+			for _, c := range commands {
+				candidates = append(candidates, c.name)
+			}
+			word = name
+		} else if c, ok := lookupCommand(name); ok && c.complete != nil {
+			candidates = c.complete(m)
+			word = strings.TrimLeft(arg, " ")
+			cl.completionBase = c.name + " "
+		}
+
+		lower := strings.ToLower(word)
+		for _, candidate := range candidates {
+			if strings.HasPrefix(strings.ToLower(candidate), lower) {
+				cl.completions = append(cl.completions, candidate)
+			}
+		}
+		if !hasArg {
+			sort.Strings(cl.completions)
+			cl.completionBase = ""
+		}
+		if len(cl.completions) == 0 {
+			return
+		}
+		cl.completionIndex = -1
+		if step < 0 {
+			cl.completionIndex = 0
+		}
+	}
+	n := len(cl.completions)
+	cl.completionIndex = ((cl.completionIndex+step)%n + n) % n
+	cl.input = newLineInput(cl.completionBase + cl.completions[cl.completionIndex])
+}
+
+// updateCommandLine handles keys while the prompt is open
+func (m *Model) updateCommandLine(msg tea.KeyMsg) tea.Cmd {
+	cl := &m.commandLine
+	key := msg.String()
+	if key != "tab" && key != "shift+tab" {
+		cl.completions = nil
+	}
+
+	switch key {
+	case "esc":
+		m.commandLineVisible = false
+	case "enter":
+		m.commandLineVisible = false
+		return m.runCommandLine(cl.input.text)
+	case "tab":
+		m.completeCommandLine(1)
+	case "shift+tab":
+		m.completeCommandLine(-1)
+	case "up":
+		if cl.historyIndex > 0 {
+			cl.historyIndex--
+			cl.input = newLineInput(cl.history[cl.historyIndex])
+		}
+	case "down":
+		if cl.historyIndex < len(cl.history)-1 {
+			cl.historyIndex++
+			cl.input = newLineInput(cl.history[cl.historyIndex])
+		} else {
+			cl.historyIndex = len(cl.history)
+			cl.input = lineInput{}
+		}
+	case "backspace":
+		// Like vim, backspace on an empty prompt closes it
+		if cl.input.text == "" {
+			m.commandLineVisible = false
+			break
+		}
+		cl.input.update(msg)
+	default:
+		cl.input.update(msg)
+	}
+	return nil
+}
+
+// openCommandLine shows an empty ":" prompt
+func (m *Model) openCommandLine() {
+	m.commandLineVisible = true
+	m.commandLine.input = lineInput{}
+	m.commandLine.message = ""
+	m.commandLine.completions = nil
+	m.commandLine.historyIndex = len(m.commandLine.history)
+	m.syncCommandLine()
+}
+
+// syncCommandLine mirrors the prompt, or the last command's message, into the instructions area
+func (m *Model) syncCommandLine() {
+	line := m.commandLine.message
+	if m.commandLineVisible {
+		line = ":" + m.commandLine.input.View()
+	}
+	m.boxer.EditLeaf("instructions", func(model tea.Model) (tea.Model, error) {
+		instr := model.(instructionsModel)
+		instr.commandLine = line
+		return instr, nil
+	})
+}
@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/layout"
+	"main/locale"
+)
+
+// Below either size the regular layout can't fit its borders and panes, so the compact
+// layout takes over: one line of playback and a bare list of the focused pane
+const (
+	compactWidth  = 60
+	compactHeight = 18
+)
+
+// compact reports whether the terminal is too small for the regular layout
+func (m Model) compact() bool {
+	return m.lastWidth > 0 && m.lastHeight > 0 && (m.lastWidth < compactWidth || m.lastHeight < compactHeight)
+}
+
+// compactView renders the compact layout
+func (m Model) compactView() string {
+	width, height := m.lastWidth, m.lastHeight
+	lines := []string{m.compactPlayback(width)}
+
+	listHeight := height - 1
+	if m.currentFocus == focusSearch && listHeight > 0 {
+		lines = append(lines, m.compactSearch(width))
+		listHeight--
+	}
+	if m.filtering && listHeight > 0 {
+		var filter lineInput
+		m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+			filter = model.(mainContentModel).prompt
+			return model, nil
+		})
+		lines = append(lines, layout.Truncate("filter: "+filter.View(), width, "…"))
+		listHeight--
+	}
+	if m.finding && listHeight > 0 {
+		var find lineInput
+		m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+			find = model.(mainContentModel).prompt
+			return model, nil
+		})
+		lines = append(lines, layout.Truncate("find: "+find.View(), width, "…"))
+		listHeight--
+	}
+	if listHeight > 0 {
+		lines = append(lines, m.compactList(width, listHeight)...)
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines[:height], "\n")
+}
+
+// compactPlayback is the track, artist and position squeezed into one line, with a
+// progress bar in whatever room is left
+func (m Model) compactPlayback(width int) string {
+	var pb playbackModel
+	m.boxer.EditLeaf("playback", func(model tea.Model) (tea.Model, error) {
+		pb = model.(playbackModel)
+		return pb, nil
+	})
+	status := pb.status
+	if status.Track.Name == "" {
+		return layout.Truncate("■ Nothing playing", width, "…")
+	}
+
+	icon := "⏸"
+	if status.IsPlaying {
+		icon = "▶"
+	}
+	timeInfo := pb.timeInfo()
+	room := width - layout.Width(timeInfo) - 3
+	if room < 1 {
+		return layout.Truncate(icon+" "+status.Track.Name, width, "…")
+	}
+
+	track := layout.Truncate(fmt.Sprintf("%s %s — %s", icon, status.Track.Name, status.Track.Artist), room, "…")
+	// Leftover space goes to the bar, as long as it's worth drawing
+	barWidth := room - layout.Width(track) - 1
+	if barWidth < 5 || status.Duration <= 0 {
+		return layout.Pad(track, room) + "   " + timeInfo
+	}
+	return track + " " + progressBar(pb.position()/status.Duration, barWidth) + "   " + timeInfo
+}
+
+// compactSearch is the search box as a single prompt line
+func (m Model) compactSearch(width int) string {
+	var text string
+	m.boxer.EditLeaf("searchHelp", func(model tea.Model) (tea.Model, error) {
+		sh := model.(searchHelpModel)
+		text = sh.input.View()
+		return sh, nil
+	})
+	return layout.Truncate("/"+text, width, "…")
+}
+
+// compactList shows the focused pane's items: the sidebar while it has focus, the song
+// list otherwise, scrolled to keep the selection in view. Only the rows on screen are
+// formatted, as the song list can hold the whole library.
+func (m Model) compactList(width, height int) []string {
+	count, selected := 0, 0
+	var name func(i int) string
+	if m.currentFocus == focusPlaylists {
+		m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+			pl := model.(playlistsModel)
+			count = pl.itemCount()
+			name = func(i int) string {
+				if _, isStation := pl.station(i); isStation {
+					return "📻 " + pl.itemName(i)
+				}
+				return pl.itemName(i)
+			}
+			return pl, nil
+		})
+		selected = m.selectedPlaylistItem
+	} else {
+		m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+			main := model.(mainContentModel)
+			selected = main.selectedSong
+			switch {
+			case main.showsSearchHits():
+				count = main.searchRowCount()
+				name = main.searchHitName
+			case main.showsResults():
+				results := main.searchResults
+				count = len(results)
+				name = func(i int) string {
+					if !loaded(results[i]) {
+						return locale.T("loading")
+					}
+					return results[i].Name + " — " + results[i].Artist
+				}
+			case main.currentPlaylist != "" && main.playlistCache != nil:
+				tracks := (*main.playlistCache)[main.currentPlaylist].Tracks
+				order := main.rowOrder(tracks)
+				count = main.rowCount(tracks)
+				name = func(row int) string {
+					i := row
+					if order != nil {
+						i = order[row]
+					}
+					return tracks[i].Name + " — " + tracks[i].Artist
+				}
+			}
+			return main, nil
+		})
+	}
+
+	if count == 0 {
+		if m.currentFocus == focusPlaylists {
+			return []string{locale.T("loading.tab", strings.ToLower(m.tab.String()))}
+		}
+		return []string{locale.T("select_playlist")}
+	}
+
+	// Keep the selection in the middle of the window where possible
+	offset := max(0, min(selected-height/2, count-height))
+	var lines []string
+	for i := offset; i < count && len(lines) < height; i++ {
+		item := layout.Truncate(name(i), width-2, "…")
+		if i == selected {
+			lines = append(lines, "> "+selectedItemStyle.Render(item))
+		} else {
+			lines = append(lines, "  "+item)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+	"main/locale"
+)
+
+// confirmModel asks before running an action that can't be undone
+type confirmModel struct {
+	width, height int
+	title         string
+	lines         []string // Explanation shown under the title
+	onConfirm     tea.Cmd
+}
+
+func (m confirmModel) View() string {
+	overlayWidth := int(float64(m.width) * 0.5)
+	if overlayWidth < 50 {
+		overlayWidth = 50
+	}
+	// Title + separator + lines + spacer + footer + spacer, plus borders
+	overlayHeight := len(m.lines) + 5 + 2
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, m.getContentLine)
+}
+
+func (m confirmModel) getContentLine(lineIndex int, maxWidth int) string {
+	switch {
+	case lineIndex == 0:
+		return " " + warningStyle.Render(m.title)
+	case lineIndex == 1:
+		return " " + strings.Repeat("─", maxWidth-2)
+	case lineIndex-2 < len(m.lines):
+		return layout.Truncate("  "+m.lines[lineIndex-2], maxWidth, "...")
+	case lineIndex == len(m.lines)+3:
+		return " " + locale.T("hint.confirm")
+	}
+	return ""
+}
+
+// Message sent after a track was deleted from the library
+type trackDeletedMsg struct {
+	track daemon.Track
+	err   error
+}
+
+// deleteTrack removes track from the library
+func deleteTrack(track daemon.Track) tea.Cmd {
+	return inFlight("Deleting from library", func() tea.Msg {
+		d := daemon.Daemon{}
+		return trackDeletedMsg{track: track, err: d.DeleteTrackFromLibrary(track.Id)}
+	})
+}
+
+// confirmDeleteTrack asks before deleting track from the library
+func confirmDeleteTrack(track daemon.Track) confirmModel {
+	return confirmModel{
+		title: "Delete from library?",
+		lines: []string{
+			track.Name + " — " + track.Artist,
+			"It will be removed from every playlist.",
+		},
+		onConfirm: deleteTrack(track),
+	}
+}
+
+// confirmClearQueue asks before deleting the count upcoming tracks from the queue.
+// selected is the queue index to select afterwards.
+func confirmClearQueue(count, selected int) confirmModel {
+	return confirmModel{
+		title: "Clear the queue?",
+		lines: []string{
+			fmt.Sprintf("%d upcoming tracks will be removed.", count),
+			"The playing track keeps playing.",
+		},
+		onConfirm: clearQueue(selected),
+	}
+}
+
+// forgetTrack drops a deleted track from every cached playlist
+func forgetTrack(cache map[string]daemon.Playlist, id string) {
+	for name, playlist := range cache {
+		kept := playlist.Tracks[:0:0]
+		for _, track := range playlist.Tracks {
+			if track.Id != id {
+				kept = append(kept, track)
+			}
+		}
+		if len(kept) != len(playlist.Tracks) {
+			playlist.Tracks = kept
+			cache[name] = playlist
+		}
+	}
+}
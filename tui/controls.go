@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+)
+
+// controlSettleTime is how long shuffle, repeat and volume changes made from amtui win over
+// polled status, so a poll that started before Music applied a change doesn't undo it on screen
+const controlSettleTime = 2 * time.Second
+
+// reconcileControls returns a polled status, keeping the shuffle, repeat and volume
+// settings the user changed too recently for the poll to know about
+func (m playbackModel) reconcileControls(status daemon.PlaybackStatus) daemon.PlaybackStatus {
+	if time.Since(m.controlsChanged) < controlSettleTime {
+		status.Shuffle = m.status.Shuffle
+		status.ShuffleMode = m.status.ShuffleMode
+		status.RepeatMode = m.status.RepeatMode
+		status.Volume = m.status.Volume
+	}
+	return status
+}
+
+// changeControls applies change to the last known shuffle, repeat and volume settings and
+// shows the result right away. ok is false until a full status has been fetched, as there
+// is nothing to apply the change to yet.
+func (m *Model) changeControls(change func(status *daemon.PlaybackStatus)) (status daemon.PlaybackStatus, ok bool) {
+	m.boxer.EditLeaf("playback", func(model tea.Model) (tea.Model, error) {
+		pb := model.(playbackModel)
+		if pb.lastFull.IsZero() {
+			return pb, nil
+		}
+		change(&pb.status)
+		pb.controlsChanged = time.Now()
+		status, ok = pb.status, true
+		return pb, nil
+	})
+	return status, ok
+}
+
+// clampVolume keeps a volume within Music's 0-100 range
+func clampVolume(volume int) int {
+	return max(0, min(100, volume))
+}
+
+// setShuffleMode switches Music to mode
+func setShuffleMode(mode daemon.ShuffleMode) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		return shuffleModeMsg{mode: mode, err: d.SetShuffleMode(mode)}
+	}
+}
+
+// changeVolume raises or lowers the volume by delta percentage points
+func (m *Model) changeVolume(delta int) tea.Cmd {
+	if m.currentFocus == focusSearch {
+		return nil
+	}
+	m.changeControls(func(s *daemon.PlaybackStatus) { s.Volume = clampVolume(s.Volume + delta) })
+	d := daemon.Daemon{}
+	return attempt("Error setting volume", func() error {
+		// Rapid presses are combined into one change
+		_, err := d.AdjustVolume(delta)
+		return err
+	})
+}
+
+// volumeStepSize is how far the volume keys move the volume: the step set with :volstep,
+// otherwise the configured one
+func (m *Model) volumeStepSize() int {
+	if m.volumeStep > 0 {
+		return m.volumeStep
+	}
+	return m.config.Playback.VolumeStep
+}
+
+// toggleMute silences Music, or brings back the volume it had before muting
+func (m *Model) toggleMute() tea.Cmd {
+	if m.currentFocus == focusSearch {
+		return nil
+	}
+	volume := 0
+	_, known := m.changeControls(func(s *daemon.PlaybackStatus) {
+		if s.Volume > 0 {
+			m.mutedVolume = s.Volume
+		} else {
+			// Unmuting after muting in Music itself has no level to go back to
+			volume = m.mutedVolume
+			if volume == 0 {
+				volume = m.volumeStepSize()
+			}
+		}
+		s.Volume = volume
+	})
+	if !known {
+		return nil
+	}
+	return playerCommand(func(d *daemon.Daemon) error { return d.SetVolume(volume) })
+}
+
+// volumeWidth is how many cells the volume bar takes
+const volumeWidth = 10
+
+// volumeWidget renders the volume as a small bar, e.g. "Vol ████░░░░░░ 40%"
+func volumeWidget(volume int) string {
+	if volume <= 0 {
+		return "Vol " + strings.Repeat("░", volumeWidth) + " Muted"
+	}
+	filled := min(volumeWidth, (clampVolume(volume)*volumeWidth+50)/100)
+	if filled == 0 {
+		filled = 1 // Any sound at all shows
+	}
+	return fmt.Sprintf("Vol %s%s %d%%", strings.Repeat("█", filled), strings.Repeat("░", volumeWidth-filled), volume)
+}
+
+// skipTrack moves to the next or previous track, then fetches the status so the new
+// track shows without waiting for the next poll
+func skipTrack(next bool) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		var err error
+		if next {
+			err = d.NextTrack()
+		} else {
+			err = d.PreviousTrack()
+		}
+		if err != nil {
+			return commandResultMsg{err: err}
+		}
+		status, err := d.GetPlaybackStatus()
+		return playbackStatusMsg{status: status, full: true, refresh: true, err: err}
+	}
+}
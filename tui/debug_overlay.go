@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+)
+
+const (
+	// debugMessages is how many of the latest messages the debug overlay lists
+	debugMessages = 8
+	// debugScripts is how many of the latest osascript runs it lists
+	debugScripts = 6
+	// debugSamples is how many update and frame times its averages and maximums cover
+	debugSamples = 120
+)
+
+// debugEvent is a message handled by Update, or a run of the same message
+type debugEvent struct {
+	name  string
+	count int
+	took  time.Duration // Time the last one spent in Update
+	at    time.Time
+}
+
+// debugStats collects what the F12 debug overlay shows. Every copy of the Model shares
+// it, so View can record how long frames take.
+type debugStats struct {
+	events  []debugEvent // Oldest first
+	updates []time.Duration
+	frames  []time.Duration
+}
+
+// debugMsgName names msg for the overlay, with the key for key presses
+func debugMsgName(msg tea.Msg) string {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		return "key " + key.String()
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", msg), "tui.")
+}
+
+// addSample appends d to samples, keeping the latest debugSamples
+func addSample(samples []time.Duration, d time.Duration) []time.Duration {
+	samples = append(samples, d)
+	if len(samples) > debugSamples {
+		samples = samples[len(samples)-debugSamples:]
+	}
+	return samples
+}
+
+// recordUpdate notes that msg took took in Update. Repeats of the same message (frames,
+// polls) are counted on one line rather than pushing everything else off the list.
+func (s *debugStats) recordUpdate(msg tea.Msg, took time.Duration) {
+	s.updates = addSample(s.updates, took)
+	name := debugMsgName(msg)
+	if last := len(s.events) - 1; last >= 0 && s.events[last].name == name {
+		s.events[last].count++
+		s.events[last].took = took
+		s.events[last].at = time.Now()
+		return
+	}
+	s.events = append(s.events, debugEvent{name: name, count: 1, took: took, at: time.Now()})
+	if len(s.events) > debugMessages {
+		s.events = s.events[len(s.events)-debugMessages:]
+	}
+}
+
+// recordFrame notes that rendering a frame took took
+func (s *debugStats) recordFrame(took time.Duration) {
+	s.frames = addSample(s.frames, took)
+}
+
+// summarize describes samples as "last 1.2ms · avg 0.8ms · max 4.1ms"
+func summarize(samples []time.Duration) string {
+	if len(samples) == 0 {
+		return "none yet"
+	}
+	var total time.Duration
+	for _, d := range samples {
+		total += d
+	}
+	return fmt.Sprintf("last %s · avg %s · max %s", roundDuration(samples[len(samples)-1]),
+		roundDuration(total/time.Duration(len(samples))), roundDuration(slices.Max(samples)))
+}
+
+// roundDuration keeps durations short enough to line up
+func roundDuration(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	}
+	return d.Round(time.Microsecond)
+}
+
+// debugLines is the content of the debug overlay
+func (m Model) debugLines() []string {
+	lines := []string{
+		"Debug · F12 to close",
+		"Frames:  " + summarize(m.debug.frames),
+		"Updates: " + summarize(m.debug.updates),
+		"",
+		"Messages:",
+	}
+	for i := len(m.debug.events) - 1; i >= 0; i-- {
+		event := m.debug.events[i]
+		name := event.name
+		if event.count > 1 {
+			name += fmt.Sprintf(" ×%d", event.count)
+		}
+		lines = append(lines, fmt.Sprintf("  %-32s %9s %5s ago", name, roundDuration(event.took),
+			time.Since(event.at).Round(time.Second)))
+	}
+
+	lines = append(lines, "", "Scripts:")
+	scripts := daemon.RecentScripts()
+	var runs []time.Duration
+	for _, timing := range scripts {
+		runs = append(runs, timing.Run)
+	}
+	slices.Reverse(runs) // Oldest first, as summarize expects
+	lines = append(lines, "  "+summarize(runs))
+	for _, timing := range scripts[:min(len(scripts), debugScripts)] {
+		label := timing.Label
+		if timing.Failed {
+			label = "✗ " + label
+		}
+		lines = append(lines, fmt.Sprintf("  %-32s %9s  queued %s", label, roundDuration(timing.Run), roundDuration(timing.Wait)))
+	}
+
+	tracks := 0
+	for _, playlist := range m.playlistCache {
+		tracks += len(playlist.Tracks)
+	}
+	lines = append(lines, "", "Caches:",
+		fmt.Sprintf("  playlists  %d, %d tracks", len(m.playlistCache), tracks),
+		fmt.Sprintf("  songs      %d pages of %d tracks", len(m.songs.requested), len(m.songs.tracks)))
+	if m.library != nil {
+		lines = append(lines, fmt.Sprintf("  library    %d albums, %d artists", len(m.library.albums), len(m.library.artists)))
+	}
+	return lines
+}
+
+// renderDebug draws the debug overlay over the top right of view, leaving the rest of
+// view as it is so the app can be used while it's open
+func (m Model) renderDebug(view string) string {
+	return renderPanel(view, m.lastWidth, min(max(m.lastWidth/2, 60), m.lastWidth-2), m.debugLines())
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/locale"
+)
+
+// Message carrying a health report of the connection to Music
+type diagnosticsMsg struct {
+	diag daemon.Diagnostics
+}
+
+// runDiagnostics checks the connection to Music
+func runDiagnostics() tea.Msg {
+	d := daemon.Daemon{}
+	return diagnosticsMsg{diag: d.Diagnose()}
+}
+
+// diagnosticLines renders a health report, one check per line
+func diagnosticLines(diag daemon.Diagnostics) []string {
+	yesNo := func(ok bool) string {
+		if ok {
+			return "yes"
+		}
+		return "no"
+	}
+	orUnknown := func(value string, known bool) string {
+		if !known {
+			return "-"
+		}
+		return value
+	}
+	answered := diag.Permission == daemon.PermissionGranted
+
+	lines := []string{
+		"Music.app running:  " + yesNo(diag.Running),
+		"Music.app version:  " + orUnknown(diag.Version, diag.Version != ""),
+		"Automation access:  " + diag.Permission.String(),
+		"Library tracks:     " + orUnknown(fmt.Sprint(diag.Tracks), answered),
+		"Playlists:          " + orUnknown(fmt.Sprint(diag.Playlists), answered),
+		"Script round trip:  " + orUnknown(diag.Latency.Round(time.Millisecond).String(), diag.Latency > 0),
+		"Calls paused:       " + yesNo(diag.Circuit.Open),
+		"",
+	}
+	if diag.Healthy() {
+		return append(lines, "Everything looks fine.")
+	}
+	for _, problem := range diag.Problems {
+		lines = append(lines, "✗ "+problem)
+	}
+	return lines
+}
+
+// Doctor prints a health report of the connection to Music, for "amtui doctor".
+// It returns false when something looks wrong.
+func Doctor(w io.Writer) bool {
+	d := daemon.Daemon{}
+	diag := d.Diagnose()
+	for _, line := range diagnosticLines(diag) {
+		fmt.Fprintln(w, line)
+	}
+	return diag.Healthy()
+}
+
+// doctorModel is the diagnostics screen
+type doctorModel struct {
+	width, height int
+	diag          daemon.Diagnostics
+	running       bool // Checks are still in progress
+}
+
+func (m doctorModel) lines() []string {
+	if m.running {
+		return []string{"Checking the connection to Music…"}
+	}
+	return diagnosticLines(m.diag)
+}
+
+func (m doctorModel) View() string {
+	overlayWidth := int(float64(m.width) * 0.6)
+	if overlayWidth < 60 {
+		overlayWidth = 60
+	}
+	// Title + separator + lines + spacer + footer, plus borders
+	overlayHeight := len(m.lines()) + 4 + 2
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, m.getContentLine)
+}
+
+func (m doctorModel) getContentLine(lineIndex int, maxWidth int) string {
+	lines := m.lines()
+	switch {
+	case lineIndex == 0:
+		return " Diagnostics"
+	case lineIndex == 1:
+		return " " + strings.Repeat("─", maxWidth-2)
+	case lineIndex-2 < len(lines):
+		line := lines[lineIndex-2]
+		if strings.HasPrefix(line, "✗") {
+			return "  " + warningStyle.Render(line)
+		}
+		return "  " + line
+	case lineIndex == len(lines)+3:
+		return " " + locale.T("hint.doctor")
+	}
+	return ""
+}
+
+// updateDoctor handles keys while the diagnostics screen is shown
+func (m *Model) updateDoctor(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "r":
+		if !m.doctor.running {
+			m.doctor.running = true
+			return runDiagnostics
+		}
+	case "esc", "q":
+		m.doctorVisible = false
+	case "ctrl+c":
+		return tea.Quit
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// escapeHold is how long sequences sent with sendEscape stay in the view, long enough
+// for the renderer to write at least one frame with them
+const escapeHold = 200 * time.Millisecond
+
+// Message sent once the sequences of sendEscape had time to reach the terminal
+type escapesSentMsg struct {
+	seq int
+}
+
+// sendEscape writes sequence to the terminal ahead of the next frames, as writing it
+// beside the renderer would garble the screen. Sequences sent in a row are all written;
+// they are dropped together once the hold of the last one is over.
+func (m *Model) sendEscape(sequence string) tea.Cmd {
+	m.escapeSeq++
+	m.escapes += sequence
+	seq := m.escapeSeq
+	return tea.Tick(escapeHold, func(time.Time) tea.Msg { return escapesSentMsg{seq: seq} })
+}
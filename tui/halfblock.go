@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// halfBlockArt draws img in cols x rows cells with "▀" characters: each cell shows two
+// pixels, the top one as the foreground color and the bottom one as the background.
+// Colors are brought down to what profile has.
+func halfBlockArt(img image.Image, cols, rows int, profile termenv.Profile) []string {
+	if cols < 1 || rows < 1 {
+		return nil
+	}
+	pixels := scaleImage(img, cols, rows*2)
+
+	lines := make([]string, rows)
+	for row := range lines {
+		var b strings.Builder
+		var lastTop, lastBottom string
+		for x := 0; x < cols; x++ {
+			top := colorCode(pixels.RGBAAt(x, row*2), profile, false)
+			bottom := colorCode(pixels.RGBAAt(x, row*2+1), profile, true)
+			// Neighbouring cells often share colors, so only send changes
+			if top != lastTop {
+				fmt.Fprintf(&b, "\x1b[%sm", top)
+				lastTop = top
+			}
+			if bottom != lastBottom {
+				fmt.Fprintf(&b, "\x1b[%sm", bottom)
+				lastBottom = bottom
+			}
+			b.WriteString("▀")
+		}
+		b.WriteString("\x1b[0m")
+		lines[row] = b.String()
+	}
+	return lines
+}
+
+// colorCode is the SGR parameters setting c as the foreground or background color, in
+// 24 bits, the 256-color palette or the 16 basic colors depending on profile
+func colorCode(c color.RGBA, profile termenv.Profile, background bool) string {
+	layer := 38
+	if background {
+		layer = 48
+	}
+	switch profile {
+	case termenv.TrueColor:
+		return fmt.Sprintf("%d;2;%d;%d;%d", layer, c.R, c.G, c.B)
+	case termenv.ANSI256:
+		return fmt.Sprintf("%d;5;%d", layer, ansi256(c))
+	}
+	hex := fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	return termenv.ANSI.Convert(termenv.RGBColor(hex)).Sequence(background)
+}
+
+// ansi256 maps c to the closest entry of the 6x6x6 color cube or the grayscale ramp of
+// the 256-color palette
+func ansi256(c color.RGBA) int {
+	level := func(v uint8) int {
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (int(v) - 35) / 40
+	}
+	cubeValue := func(level int) int {
+		if level == 0 {
+			return 0
+		}
+		return 55 + level*40
+	}
+	distance := func(r, g, b int) int {
+		dr, dg, db := int(c.R)-r, int(c.G)-g, int(c.B)-b
+		return dr*dr + dg*dg + db*db
+	}
+
+	r, g, b := level(c.R), level(c.G), level(c.B)
+	cube := 16 + 36*r + 6*g + b
+	cubeDistance := distance(cubeValue(r), cubeValue(g), cubeValue(b))
+
+	// Grays 232-255 run from 8 to 238 in steps of 10
+	average := (int(c.R) + int(c.G) + int(c.B)) / 3
+	gray := max(0, min(23, (average-3)/10))
+	grayValue := 8 + gray*10
+	if distance(grayValue, grayValue, grayValue) < cubeDistance {
+		return 232 + gray
+	}
+	return cube
+}
@@ -0,0 +1,212 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/layout"
+	"main/locale"
+)
+
+// helpSections lists the help overlay's sections in order
+var helpSections = []string{"Global", "Views", "Playlists", "Song list", "Pane navigation (after Ctrl+W)", "Queue", "Lyrics", "Context menu", "Up next", "Now Playing", "Search", "Find in song list", "Motions"}
+
+// helpSectionOf files global actions that only make sense in one pane under that pane
+var helpSectionOf = map[keyAction]string{
+	actionQueueList:     "Playlists",
+	actionToggleStats:   "Playlists",
+	actionJumpToName:    "Playlists",
+	actionContextMenu:   "Song list",
+	actionQueueAlbum:    "Song list",
+	actionToggleAdded:   "Song list",
+	actionCycleSort:     "Song list",
+	actionFilter:        "Song list",
+	actionCenterPlaying: "Song list",
+	actionVisual:        "Song list",
+	actionYank:          "Song list",
+	actionYankLink:      "Song list",
+	actionInspectTrack:  "Song list",
+	actionRepeatLast:    "Song list",
+	// Switching between the views in the tab bar
+	actionViewPlaylists: "Views",
+	actionViewSearch:    "Views",
+	actionViewQueue:     "Views",
+	actionViewLyrics:    "Views",
+	actionViewHistory:   "Views",
+	actionViewStats:     "Views",
+	actionNextView:      "Views",
+	actionPrevView:      "Views",
+	// Songs, albums, artists and playlists found by a search
+	actionNextCategory: "Search",
+	actionPrevCategory: "Search",
+}
+
+// searchHelpKeys are the keys of the search box, which can't be remapped
+var searchHelpKeys = [][2]string{
+	{"enter", "run the search"},
+	{"↑/↓", "recall earlier searches"},
+	{"esc", "cancel and clear the search"},
+	{"ctrl+w j/l", "go to the playlists or the song list, keeping the search"},
+	{"←/→ home/end", "move the cursor"},
+	{"backspace/delete", "delete characters"},
+	{"space", "play/pause"},
+}
+
+// helpSection returns the help overlay section a binding is listed under
+func helpSection(binding keyBinding) string {
+	switch binding.scope {
+	case scopePane:
+		return "Pane navigation (after Ctrl+W)"
+	case scopeQueue:
+		return "Queue"
+	case scopeLyrics:
+		return "Lyrics"
+	case scopeMenu:
+		return "Context menu"
+	case scopeUpNext:
+		return "Up next"
+	case scopeNowPlaying:
+		return "Now Playing"
+	}
+	if section, ok := helpSectionOf[binding.action]; ok {
+		return section
+	}
+	return "Global"
+}
+
+// helpEntry is one key and what it does
+type helpEntry struct {
+	keys, help string
+}
+
+// helpEntries groups the effective keymap by section, keeping the order of defaultBindings
+func helpEntries(km keyMap) map[string][]helpEntry {
+	entries := make(map[string][]helpEntry)
+	for _, binding := range defaultBindings {
+		keys := km.keys(binding.action)
+		if len(keys) == 0 {
+			continue // Unbound by the user
+		}
+		names := make([]string, len(keys))
+		for i, key := range keys {
+			names[i] = displayKey(key)
+		}
+		section := helpSection(binding)
+		entries[section] = append(entries[section], helpEntry{keys: strings.Join(names, "/"), help: binding.help})
+	}
+	for _, key := range searchHelpKeys {
+		entries["Search"] = append(entries["Search"], helpEntry{keys: key[0], help: key[1]})
+	}
+	// Letters jump through the sidebar, the jump key first for the ones bound to actions
+	entries["Playlists"] = append(entries["Playlists"], helpEntry{keys: "a-z", help: "next playlist starting with the letter"})
+	for _, key := range findHelpKeys {
+		entries["Find in song list"] = append(entries["Find in song list"], helpEntry{keys: key[0], help: key[1]})
+	}
+	for _, key := range motionHelpKeys {
+		entries["Motions"] = append(entries["Motions"], helpEntry{keys: key[0], help: key[1]})
+	}
+	return entries
+}
+
+// helpLines renders the keymap as a list of sections, keys padded to one column
+func helpLines(km keyMap) []string {
+	entries := helpEntries(km)
+	keyWidth := 0
+	for _, section := range entries {
+		for _, entry := range section {
+			keyWidth = max(keyWidth, layout.Width(entry.keys))
+		}
+	}
+
+	var lines []string
+	for _, section := range helpSections {
+		if len(entries[section]) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, titleStyle.Render(section))
+		for _, entry := range entries[section] {
+			lines = append(lines, "  "+layout.Pad(entry.keys, keyWidth)+"  "+entry.help)
+		}
+	}
+	return lines
+}
+
+// helpModel is the "?" overlay listing every keybinding
+type helpModel struct {
+	width, height int
+	lines         []string
+	scrollOffset  int
+}
+
+// visibleLines is how many keymap lines fit between the header and the footer
+func (m helpModel) visibleLines() int {
+	// Borders, title, separator, spacer and footer
+	return max(1, min(len(m.lines), m.height-2-4))
+}
+
+// maxScroll is the furthest the keymap can scroll at this height
+func (m helpModel) maxScroll() int {
+	return max(0, len(m.lines)-m.visibleLines())
+}
+
+// resize fits the overlay to the terminal, keeping the scroll inside the keymap
+func (m *helpModel) resize(width, height int) {
+	m.width, m.height = width, height
+	m.scrollOffset = min(m.scrollOffset, m.maxScroll())
+}
+
+// update scrolls the overlay; done is true when it should close
+func (m helpModel) update(msg tea.KeyMsg, km keyMap) (helpModel, bool) {
+	maxScroll := m.maxScroll()
+	switch msg.String() {
+	case "esc", "q":
+		return m, true
+	case "up", "k":
+		m.scrollOffset = max(0, m.scrollOffset-1)
+	case "down", "j":
+		m.scrollOffset = min(maxScroll, m.scrollOffset+1)
+	case "pgup":
+		m.scrollOffset = max(0, m.scrollOffset-m.visibleLines())
+	case "pgdown", " ":
+		m.scrollOffset = min(maxScroll, m.scrollOffset+m.visibleLines())
+	default:
+		// The key that opened the overlay closes it too
+		if km.action(scopeGlobal, msg.String()) == actionHelp {
+			return m, true
+		}
+	}
+	return m, false
+}
+
+func (m helpModel) View() string {
+	overlayWidth := int(float64(m.width) * 0.6)
+	if overlayWidth < 60 {
+		overlayWidth = 60
+	}
+	overlayHeight := m.visibleLines() + 4 + 2
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, m.getContentLine)
+}
+
+func (m helpModel) getContentLine(lineIndex int, maxWidth int) string {
+	visible := m.visibleLines()
+	switch {
+	case lineIndex == 0:
+		return " Keybindings"
+	case lineIndex == 1:
+		return " " + strings.Repeat("─", maxWidth-2)
+	case lineIndex-2 < visible:
+		if i := m.scrollOffset + lineIndex - 2; i < len(m.lines) {
+			return " " + m.lines[i]
+		}
+	case lineIndex == visible+3:
+		if len(m.lines) > visible {
+			return " " + locale.T("hint.scroll")
+		}
+		return " " + locale.T("hint.close")
+	}
+	return ""
+}
@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"strings"
+
+	"main/locale"
+)
+
+// hintKey writes a key the way the instructions bar shows it: arrows as arrows and
+// named keys capitalized, "ctrl+w" as "Ctrl+W"
+func hintKey(key string) string {
+	switch key {
+	case " ":
+		return "Space"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	}
+	if len([]rune(key)) == 1 {
+		return key
+	}
+	parts := strings.Split(key, "+")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "+")
+}
+
+// keyHint is the first key bound to each action, joined by "/", or "" when one of them
+// was unbound by the user
+func keyHint(km keyMap, actions ...keyAction) string {
+	keys := make([]string, len(actions))
+	for i, action := range actions {
+		bound := km.keys(action)
+		if len(bound) == 0 {
+			return ""
+		}
+		keys[i] = hintKey(bound[0])
+	}
+	return strings.Join(keys, "/")
+}
+
+// paneHint is the pane prefix followed by the keys moving left, down, up and right,
+// "Ctrl+W+hjkl" with the default keymap
+func paneHint(km keyMap) string {
+	prefix := keyHint(km, actionPanePrefix)
+	moves := keyHint(km, actionPaneLeft, actionPaneDown, actionPaneUp, actionPaneRight)
+	if prefix == "" || moves == "" {
+		return ""
+	}
+	if single := strings.ReplaceAll(moves, "/", ""); len([]rune(single)) == 4 {
+		moves = single
+	}
+	return prefix + "+" + moves
+}
+
+// keyInstructions lists the main keys for the focused pane as the keymap binds them,
+// leaving out actions the user unbound
+func keyInstructions(km keyMap, focus focusArea) string {
+	selectLabel := "instructions.select"
+	if focus == focusMain {
+		selectLabel = "instructions.play_song"
+	}
+	hints := [][2]string{
+		{keyHint(km, actionQuit), "instructions.quit"},
+		{keyHint(km, actionHelp), "instructions.help"},
+		{keyHint(km, actionCycleFocus), "instructions.cycle"},
+		{paneHint(km), "instructions.panes"},
+		{keyHint(km, actionUp, actionDown), "instructions.navigate"},
+		{keyHint(km, actionSelect), selectLabel},
+	}
+	if focus == focusSearch {
+		hints = append(hints, [2]string{keyHint(km, actionSearch), "instructions.search"})
+	}
+	hints = append(hints,
+		[2]string{keyHint(km, actionPlayPause), "instructions.play_pause"},
+		[2]string{keyHint(km, actionShuffle), "instructions.shuffle"},
+		[2]string{keyHint(km, actionRepeat), "instructions.repeat"},
+		[2]string{keyHint(km, actionVolumeUp, actionVolumeDown), "instructions.volume"},
+		[2]string{keyHint(km, actionVolumeUpFine, actionVolumeDownFine), "instructions.volume_fine"},
+	)
+	return joinHints(hints)
+}
+
+// queueKeyHints lists the queue overlay's keys as the keymap binds them
+func queueKeyHints(km keyMap) string {
+	return locale.T("hint.queue.navigation") + joinHints([][2]string{
+		{keyHint(km, actionQueueUp, actionQueueDown), "hint.queue.select"},
+		{keyHint(km, actionQueuePlay), "hint.queue.play"},
+		{keyHint(km, actionQueueMoveDn, actionQueueMoveUp), "hint.queue.move"},
+		{keyHint(km, actionQueueAdd), "hint.queue.add"},
+		{keyHint(km, actionQueueRemove), "hint.queue.remove"},
+		{keyHint(km, actionQueueClear), "hint.queue.clear"},
+		{keyHint(km, actionQueueCurrent), "hint.queue.current"},
+		{keyHint(km, actionQueueFilter), "hint.queue.filter"},
+		{keyHint(km, actionQueueClose), "hint.queue.close"},
+		{keyHint(km, actionQueueRefresh), "hint.queue.refresh"},
+	})
+}
+
+// joinHints writes each pair of keys and message ID as "<keys> <text>", leaving out
+// those with no keys
+func joinHints(hints [][2]string) string {
+	var items []string
+	for _, hint := range hints {
+		if hint[0] != "" {
+			items = append(items, hint[0]+" "+locale.T(hint[1]))
+		}
+	}
+	return strings.Join(items, " • ")
+}
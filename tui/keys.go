@@ -0,0 +1,464 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"main/config"
+	"main/locale"
+)
+
+// keyScope is the part of the UI in which a key binding is active
+type keyScope int
+
+const (
+	scopeGlobal     keyScope = iota // Playlists and main panes
+	scopePane                       // Second key after the Ctrl+W prefix
+	scopeQueue                      // Queue overlay
+	scopeLyrics                     // Lyrics overlay
+	scopeMenu                       // Song context menu
+	scopeUpNext                     // While the "Up next" notice is shown
+	scopeNowPlaying                 // Full-screen Now Playing view
+)
+
+func (s keyScope) String() string {
+	switch s {
+	case scopePane:
+		return "pane"
+	case scopeQueue:
+		return "queue"
+	case scopeLyrics:
+		return "lyrics"
+	case scopeMenu:
+		return "menu"
+	case scopeUpNext:
+		return "up_next"
+	case scopeNowPlaying:
+		return "now_playing"
+	default:
+		return "global"
+	}
+}
+
+// keyAction names an action that can be bound to keys in the [keys] section of the config
+type keyAction string
+
+const (
+	actionQuit           keyAction = "quit"
+	actionSearch         keyAction = "search"
+	actionPanePrefix     keyAction = "pane_prefix"
+	actionCycleFocus     keyAction = "cycle_focus"
+	actionUp             keyAction = "up"
+	actionDown           keyAction = "down"
+	actionSelect         keyAction = "select"
+	actionToggleQueue    keyAction = "queue"
+	actionToggleLyrics   keyAction = "lyrics"
+	actionContextMenu    keyAction = "context_menu"
+	actionQueueAlbum     keyAction = "add_album_to_queue"
+	actionQueueList      keyAction = "add_playlist_to_queue"
+	actionToggleStats    keyAction = "toggle_playlist_stats"
+	actionToggleAdded    keyAction = "toggle_added_column"
+	actionFilter         keyAction = "filter"
+	actionCenterPlaying  keyAction = "center_playing"
+	actionJumpToName     keyAction = "jump_to_playlist"
+	actionTimeLeft       keyAction = "toggle_remaining_time"
+	actionVisual         keyAction = "visual_select"
+	actionYank           keyAction = "yank"
+	actionYankLink       keyAction = "yank_link"
+	actionRevealInMusic  keyAction = "reveal_in_music"
+	actionOpenWebPage    keyAction = "open_web_page"
+	actionNextCategory   keyAction = "next_search_category"
+	actionPrevCategory   keyAction = "previous_search_category"
+	actionCycleSort      keyAction = "cycle_sort"
+	actionStartStation   keyAction = "start_station"
+	actionDoctor         keyAction = "doctor"
+	actionLibraryStats   keyAction = "library_stats"
+	actionInspectTrack   keyAction = "inspect_track"
+	actionDebugOverlay   keyAction = "debug_overlay"
+	actionCycleTheme     keyAction = "cycle_theme"
+	actionThemePicker    keyAction = "theme_picker"
+	actionNowPlaying     keyAction = "now_playing"
+	actionRepeatLast     keyAction = "repeat_last_action"
+	actionRecordMacro    keyAction = "record_macro"
+	actionPlayMacro      keyAction = "play_macro"
+	actionHelp           keyAction = "help"
+	actionPalette        keyAction = "command_palette"
+	actionCommandLine    keyAction = "command_line"
+	actionPlayPause      keyAction = "play_pause"
+	actionShuffle        keyAction = "shuffle"
+	actionShuffleMode    keyAction = "shuffle_mode"
+	actionRepeat         keyAction = "repeat"
+	actionVolumeUp       keyAction = "volume_up"
+	actionVolumeDown     keyAction = "volume_down"
+	actionVolumeUpFine   keyAction = "volume_up_fine"
+	actionVolumeDownFine keyAction = "volume_down_fine"
+	actionMute           keyAction = "mute"
+	actionNextTrack      keyAction = "next_track"
+	actionPrevTrack      keyAction = "previous_track"
+	actionTabPlaylists   keyAction = "tab_playlists"
+	actionTabAlbums      keyAction = "tab_albums"
+	actionTabArtists     keyAction = "tab_artists"
+	actionTabSongs       keyAction = "tab_songs"
+	actionViewPlaylists  keyAction = "view_playlists"
+	actionViewSearch     keyAction = "view_search"
+	actionViewQueue      keyAction = "view_queue"
+	actionViewLyrics     keyAction = "view_lyrics"
+	actionViewHistory    keyAction = "view_history"
+	actionViewStats      keyAction = "view_stats"
+	actionNextView       keyAction = "next_view"
+	actionPrevView       keyAction = "previous_view"
+	actionSeekBack       keyAction = "seek_back"
+	actionSeekForward    keyAction = "seek_forward"
+	actionJumpBack       keyAction = "jump_back"
+	actionJumpForward    keyAction = "jump_forward"
+
+	actionPaneLeft          keyAction = "pane_left"
+	actionPaneDown          keyAction = "pane_down"
+	actionPaneUp            keyAction = "pane_up"
+	actionPaneRight         keyAction = "pane_right"
+	actionPaneWider         keyAction = "sidebar_wider"
+	actionPaneNarrower      keyAction = "sidebar_narrower"
+	actionPaneResetWidth    keyAction = "sidebar_reset_width"
+	actionPaneToggleSidebar keyAction = "toggle_sidebar"
+
+	actionQueueClose   keyAction = "queue_close"
+	actionQueueRefresh keyAction = "queue_refresh"
+	actionQueueUp      keyAction = "queue_up"
+	actionQueueDown    keyAction = "queue_down"
+	actionQueuePlay    keyAction = "queue_play"
+	actionQueueMoveUp  keyAction = "queue_move_up"
+	actionQueueMoveDn  keyAction = "queue_move_down"
+	actionQueueRemove  keyAction = "queue_remove"
+	actionQueueClear   keyAction = "queue_clear"
+	actionQueueCurrent keyAction = "queue_current"
+	actionQueueAdd     keyAction = "queue_add"
+	actionQueueFilter  keyAction = "queue_filter"
+
+	actionLyricsClose      keyAction = "lyrics_close"
+	actionLyricsUp         keyAction = "lyrics_up"
+	actionLyricsDown       keyAction = "lyrics_down"
+	actionLyricsAutoScroll keyAction = "lyrics_autoscroll"
+
+	actionMenuClose keyAction = "menu_close"
+	actionMenuUp    keyAction = "menu_up"
+	actionMenuDown  keyAction = "menu_down"
+	actionMenuLeft  keyAction = "menu_left"
+	actionMenuRight keyAction = "menu_right"
+	actionMenuRun   keyAction = "menu_select"
+
+	actionUpNextSkip   keyAction = "up_next_skip"
+	actionUpNextRemove keyAction = "up_next_remove"
+
+	actionNowPlayingClose keyAction = "now_playing_close"
+	actionVisualizer      keyAction = "cycle_visualizer"
+)
+
+// keyBinding binds an action to one or more keys (as reported by tea.KeyMsg.String())
+type keyBinding struct {
+	action keyAction
+	scope  keyScope
+	keys   []string
+	help   string
+	user   bool // Keys come from the config file rather than the built-in defaults
+}
+
+// defaultBindings is the built-in keymap. Every entry can be remapped from the config file.
+var defaultBindings = []keyBinding{
+	{action: actionQuit, scope: scopeGlobal, keys: []string{"q", "ctrl+c"}, help: "quit"},
+	{action: actionSearch, scope: scopeGlobal, keys: []string{"/"}, help: "search, or find in the song list when it's focused"},
+	{action: actionHelp, scope: scopeGlobal, keys: []string{"?"}, help: "show all keybindings"},
+	{action: actionPalette, scope: scopeGlobal, keys: []string{"ctrl+p"}, help: "command palette"},
+	{action: actionCommandLine, scope: scopeGlobal, keys: []string{":"}, help: "command line (:playlist, :volume, :seek, :q…)"},
+	{action: actionPanePrefix, scope: scopeGlobal, keys: []string{"ctrl+w"}, help: "pane navigation prefix"},
+	{action: actionCycleFocus, scope: scopeGlobal, keys: []string{"tab"}, help: "cycle focus"},
+	{action: actionUp, scope: scopeGlobal, keys: []string{"up", "k"}, help: "move up"},
+	{action: actionDown, scope: scopeGlobal, keys: []string{"down", "j"}, help: "move down"},
+	{action: actionSelect, scope: scopeGlobal, keys: []string{"enter"}, help: "select / play song"},
+	{action: actionToggleQueue, scope: scopeGlobal, keys: []string{"Q"}, help: "toggle queue"},
+	{action: actionToggleLyrics, scope: scopeGlobal, keys: []string{"l", "L"}, help: "toggle lyrics"},
+	{action: actionContextMenu, scope: scopeGlobal, keys: []string{"K", "shift+k"}, help: "song context menu"},
+	{action: actionQueueAlbum, scope: scopeGlobal, keys: []string{"A"}, help: "add album to queue"},
+	{action: actionQueueList, scope: scopeGlobal, keys: []string{"P"}, help: "add playlist to queue"},
+	{action: actionToggleStats, scope: scopeGlobal, keys: []string{"#"}, help: "toggle playlist counts and durations"},
+	{action: actionToggleAdded, scope: scopeGlobal, keys: []string{"D"}, help: "toggle date added column"},
+	{action: actionFilter, scope: scopeGlobal, keys: []string{"f"}, help: "filter songs in the playlist"},
+	{action: actionCenterPlaying, scope: scopeGlobal, keys: []string{"c"}, help: "center the list on the playing song"},
+	{action: actionJumpToName, scope: scopeGlobal, keys: []string{"'"}, help: "type the start of a playlist name to jump to it"},
+	{action: actionYank, scope: scopeGlobal, keys: []string{"y"}, help: "copy \"Title – Artist\" of the song"},
+	{action: actionYankLink, scope: scopeGlobal, keys: []string{"Y"}, help: "copy the song's Apple Music link"},
+	{action: actionVisual, scope: scopeGlobal, keys: []string{"v"}, help: "mark songs, then a queue · p playlist · d remove · 0-5 rate"},
+	{action: actionNextCategory, scope: scopeGlobal, keys: []string{"]"}, help: "next search result category"},
+	{action: actionPrevCategory, scope: scopeGlobal, keys: []string{"["}, help: "previous search result category"},
+	{action: actionCycleSort, scope: scopeGlobal, keys: []string{"o"}, help: "cycle song sort order"},
+	{action: actionStartStation, scope: scopeGlobal, keys: []string{"R"}, help: "start station from playing track"},
+	{action: actionRevealInMusic, scope: scopeGlobal, keys: []string{"M"}, help: "show the selected or playing song in Music.app"},
+	{action: actionOpenWebPage, scope: scopeGlobal, keys: []string{"W"}, help: "open the selected or playing song's Apple Music page"},
+	{action: actionDoctor, scope: scopeGlobal, keys: []string{"!"}, help: "diagnose the connection to Music"},
+	{action: actionLibraryStats, scope: scopeGlobal, keys: []string{"I"}, help: "library statistics"},
+	{action: actionInspectTrack, scope: scopeGlobal, keys: []string{"i"}, help: "show all details of the song: tags, plays, file, persistent ID"},
+	{action: actionDebugOverlay, scope: scopeGlobal, keys: []string{"f12"}, help: "show message, script and frame timings"},
+	{action: actionCycleTheme, scope: scopeGlobal, keys: []string{"T"}, help: "cycle color theme"},
+	{action: actionThemePicker, scope: scopeGlobal, keys: []string{"ctrl+t"}, help: "pick a color theme, previewing each"},
+	{action: actionNowPlaying, scope: scopeGlobal, keys: []string{"F"}, help: "show the playing track full screen"},
+	{action: actionRepeatLast, scope: scopeGlobal, keys: []string{"."}, help: "repeat the last action on the selected song"},
+	{action: actionRecordMacro, scope: scopeGlobal, keys: []string{"ctrl+q"}, help: "record keys into a register (a-z, 0-9), again to stop"},
+	{action: actionPlayMacro, scope: scopeGlobal, keys: []string{"@"}, help: "play the keys recorded in a register, @@ the last one"},
+	{action: actionPlayPause, scope: scopeGlobal, keys: []string{" "}, help: "play/pause"},
+	{action: actionTimeLeft, scope: scopeGlobal, keys: []string{"t"}, help: "show elapsed or remaining time"},
+	{action: actionShuffle, scope: scopeGlobal, keys: []string{"s"}, help: "toggle shuffle"},
+	{action: actionShuffleMode, scope: scopeGlobal, keys: []string{"S"}, help: "cycle shuffle mode (songs, albums, groupings)"},
+	{action: actionRepeat, scope: scopeGlobal, keys: []string{"r"}, help: "cycle repeat"},
+	{action: actionVolumeUp, scope: scopeGlobal, keys: []string{"="}, help: "volume up"},
+	{action: actionVolumeDown, scope: scopeGlobal, keys: []string{"-"}, help: "volume down"},
+	{action: actionVolumeUpFine, scope: scopeGlobal, keys: []string{"+"}, help: "volume up 1%"},
+	{action: actionVolumeDownFine, scope: scopeGlobal, keys: []string{"_"}, help: "volume down 1%"},
+	{action: actionMute, scope: scopeGlobal, keys: []string{"m"}, help: "mute/unmute"},
+	{action: actionNextTrack, scope: scopeGlobal, keys: []string{">"}, help: "next track"},
+	{action: actionPrevTrack, scope: scopeGlobal, keys: []string{"<"}, help: "previous track"},
+	{action: actionTabPlaylists, scope: scopeGlobal, keys: []string{"1"}, help: "show playlists"},
+	{action: actionTabAlbums, scope: scopeGlobal, keys: []string{"2"}, help: "browse albums"},
+	{action: actionTabArtists, scope: scopeGlobal, keys: []string{"3"}, help: "browse artists"},
+	{action: actionTabSongs, scope: scopeGlobal, keys: []string{"4"}, help: "browse all songs"},
+	{action: actionViewPlaylists, scope: scopeGlobal, keys: []string{"alt+1"}, help: "Playlists view"},
+	{action: actionViewSearch, scope: scopeGlobal, keys: []string{"alt+2"}, help: "Search view"},
+	{action: actionViewQueue, scope: scopeGlobal, keys: []string{"alt+3"}, help: "Queue view"},
+	{action: actionViewLyrics, scope: scopeGlobal, keys: []string{"alt+4"}, help: "Lyrics view"},
+	{action: actionViewHistory, scope: scopeGlobal, keys: []string{"alt+5"}, help: "History view, the tracks played lately"},
+	{action: actionViewStats, scope: scopeGlobal, keys: []string{"alt+6"}, help: "Stats view"},
+	{action: actionNextView, scope: scopeGlobal, keys: []string{"shift+tab"}, help: "next view"},
+	{action: actionPrevView, scope: scopeGlobal, keys: []string{"alt+shift+tab"}, help: "previous view"},
+	{action: actionSeekBack, scope: scopeGlobal, keys: []string{"left"}, help: "seek back 5s"},
+	{action: actionSeekForward, scope: scopeGlobal, keys: []string{"right"}, help: "seek forward 5s"},
+	{action: actionJumpBack, scope: scopeGlobal, keys: []string{"shift+left"}, help: "seek back 30s"},
+	{action: actionJumpForward, scope: scopeGlobal, keys: []string{"shift+right"}, help: "seek forward 30s"},
+
+	{action: actionPaneLeft, scope: scopePane, keys: []string{"h", "left"}, help: "focus the pane to the left"},
+	{action: actionPaneDown, scope: scopePane, keys: []string{"j", "down"}, help: "focus the pane below"},
+	{action: actionPaneUp, scope: scopePane, keys: []string{"k", "up"}, help: "focus the pane above"},
+	{action: actionPaneRight, scope: scopePane, keys: []string{"l", "right"}, help: "focus the pane to the right"},
+	{action: actionPaneWider, scope: scopePane, keys: []string{">"}, help: "widen the sidebar"},
+	{action: actionPaneNarrower, scope: scopePane, keys: []string{"<"}, help: "narrow the sidebar"},
+	{action: actionPaneResetWidth, scope: scopePane, keys: []string{"="}, help: "reset the sidebar width"},
+	{action: actionPaneToggleSidebar, scope: scopePane, keys: []string{"o"}, help: "hide/show the sidebar"},
+
+	{action: actionQueueClose, scope: scopeQueue, keys: []string{"q", "esc"}, help: "close queue"},
+	{action: actionQueueRefresh, scope: scopeQueue, keys: []string{"u"}, help: "refresh queue"},
+	{action: actionQueueUp, scope: scopeQueue, keys: []string{"up", "k"}, help: "move up"},
+	{action: actionQueueDown, scope: scopeQueue, keys: []string{"down", "j"}, help: "move down"},
+	{action: actionQueuePlay, scope: scopeQueue, keys: []string{"enter"}, help: "skip to track"},
+	{action: actionQueueMoveUp, scope: scopeQueue, keys: []string{"K", "shift+k"}, help: "move track up"},
+	{action: actionQueueMoveDn, scope: scopeQueue, keys: []string{"J", "shift+j"}, help: "move track down"},
+	{action: actionQueueRemove, scope: scopeQueue, keys: []string{"d"}, help: "remove track"},
+	{action: actionQueueClear, scope: scopeQueue, keys: []string{"c"}, help: "clear upcoming tracks"},
+	{action: actionQueueCurrent, scope: scopeQueue, keys: []string{"z"}, help: "jump to playing track"},
+	{action: actionQueueAdd, scope: scopeQueue, keys: []string{"a"}, help: "search and add tracks"},
+	{action: actionQueueFilter, scope: scopeQueue, keys: []string{"/"}, help: "filter tracks"},
+
+	{action: actionLyricsClose, scope: scopeLyrics, keys: []string{"q", "esc", "l", "L"}, help: "close lyrics"},
+	{action: actionLyricsUp, scope: scopeLyrics, keys: []string{"up", "k"}, help: "scroll up"},
+	{action: actionLyricsDown, scope: scopeLyrics, keys: []string{"down", "j"}, help: "scroll down"},
+	{action: actionLyricsAutoScroll, scope: scopeLyrics, keys: []string{"a"}, help: "toggle auto-scroll"},
+
+	{action: actionMenuClose, scope: scopeMenu, keys: []string{"esc", "q"}, help: "close menu"},
+	{action: actionMenuUp, scope: scopeMenu, keys: []string{"up", "k"}, help: "move up"},
+	{action: actionMenuDown, scope: scopeMenu, keys: []string{"down", "j"}, help: "move down"},
+	{action: actionMenuLeft, scope: scopeMenu, keys: []string{"left", "h"}, help: "previous choice"},
+	{action: actionMenuRight, scope: scopeMenu, keys: []string{"right", "l"}, help: "next choice"},
+	{action: actionMenuRun, scope: scopeMenu, keys: []string{"enter"}, help: "run action"},
+
+	{action: actionUpNextSkip, scope: scopeUpNext, keys: []string{"n"}, help: "skip past the upcoming track"},
+	{action: actionUpNextRemove, scope: scopeUpNext, keys: []string{"x"}, help: "remove the upcoming track from the queue"},
+
+	{action: actionNowPlayingClose, scope: scopeNowPlaying, keys: []string{"q", "esc"}, help: "close Now Playing"},
+	{action: actionVisualizer, scope: scopeNowPlaying, keys: []string{"v"}, help: "cycle the visualizer (bars, wave, off)"},
+}
+
+// keyConflict describes a problem with the user's keymap
+type keyConflict struct {
+	key     string
+	scope   keyScope
+	actions []keyAction
+	message string
+}
+
+func (c keyConflict) String() string {
+	return c.message
+}
+
+// keyMap is the effective keymap: built-in defaults with the user's overrides applied
+type keyMap struct {
+	bindings []keyBinding // User bindings come first so they win lookups
+}
+
+// newKeyMap applies the [keys] overrides from the config on top of the defaults
+// and reports unknown actions and keys bound to more than one action in a scope
+func newKeyMap(overrides map[string][]string) (keyMap, []keyConflict) {
+	var conflicts []keyConflict
+
+	// Report unknown actions, sorted so the output is stable
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	known := make(map[keyAction]bool)
+	for _, binding := range defaultBindings {
+		known[binding.action] = true
+	}
+	for _, name := range names {
+		if !known[keyAction(name)] {
+			conflicts = append(conflicts, keyConflict{
+				message: fmt.Sprintf("unknown action %q in [keys]", name),
+			})
+		}
+	}
+
+	var user, builtin []keyBinding
+	for _, binding := range defaultBindings {
+		if keys, ok := overrides[string(binding.action)]; ok {
+			binding.keys = keys
+			binding.user = true
+			user = append(user, binding)
+		} else {
+			builtin = append(builtin, binding)
+		}
+	}
+	km := keyMap{bindings: append(user, builtin...)}
+
+	return km, append(conflicts, km.conflicts()...)
+}
+
+// conflicts finds keys bound to more than one action within the same scope where at
+// least one of the bindings comes from the user (built-in overlaps are intentional)
+func (k keyMap) conflicts() []keyConflict {
+	type slot struct {
+		scope keyScope
+		key   string
+	}
+	owners := make(map[slot][]keyBinding)
+	var order []slot
+	for _, binding := range k.bindings {
+		for _, key := range binding.keys {
+			s := slot{scope: binding.scope, key: key}
+			if len(owners[s]) == 0 {
+				order = append(order, s)
+			}
+			owners[s] = append(owners[s], binding)
+		}
+	}
+
+	var conflicts []keyConflict
+	for _, s := range order {
+		bindings := owners[s]
+		if len(bindings) < 2 || !bindings[0].user {
+			continue
+		}
+		actions := make([]keyAction, len(bindings))
+		for i, binding := range bindings {
+			actions[i] = binding.action
+		}
+
+		message := fmt.Sprintf("%s: %q is bound to %s", s.scope, displayKey(s.key), actions[0])
+		for _, other := range bindings[1:] {
+			if other.user {
+				message += fmt.Sprintf(", and also to %s by another user binding", other.action)
+			} else {
+				message += fmt.Sprintf(", shadowing built-in %s", other.action)
+			}
+		}
+		conflicts = append(conflicts, keyConflict{key: s.key, scope: s.scope, actions: actions, message: message})
+	}
+	return conflicts
+}
+
+// action returns the action bound to key in scope, or "" if the key is unbound
+func (k keyMap) action(scope keyScope, key string) keyAction {
+	for _, binding := range k.bindings {
+		if binding.scope != scope {
+			continue
+		}
+		for _, bound := range binding.keys {
+			if bound == key {
+				return binding.action
+			}
+		}
+	}
+	return ""
+}
+
+// keys returns the keys bound to an action
+func (k keyMap) keys(action keyAction) []string {
+	for _, binding := range k.bindings {
+		if binding.action == action {
+			return binding.keys
+		}
+	}
+	return nil
+}
+
+// displayKey renders a key name for humans
+func displayKey(key string) string {
+	if key == " " {
+		return "space"
+	}
+	return key
+}
+
+// VerifyKeys checks the keymap in the config file at path (or the default location when
+// path is empty) and writes any conflicts to w. It returns false if problems were found.
+func VerifyKeys(w io.Writer, path string) (bool, error) {
+	var cfg config.Config
+	var err error
+	if path == "" {
+		path, err = config.Path()
+		if err != nil {
+			return false, err
+		}
+	}
+	cfg, err = config.LoadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	_, conflicts := newKeyMap(cfg.Keys)
+	if len(conflicts) == 0 {
+		fmt.Fprintf(w, "%s: no keybinding conflicts\n", path)
+		return true, nil
+	}
+	for _, conflict := range conflicts {
+		fmt.Fprintf(w, "%s: %s\n", path, conflict)
+	}
+	fmt.Fprintf(w, "%d keybinding problem(s) found\n", len(conflicts))
+	return false, nil
+}
+
+// keyWarningsModel is the startup overlay listing keymap problems
+type keyWarningsModel struct {
+	width, height int
+	conflicts     []keyConflict
+}
+
+func (m keyWarningsModel) View() string {
+	overlayWidth := int(float64(m.width) * 0.7)
+	if overlayWidth < 40 {
+		overlayWidth = 40
+	}
+	overlayHeight := len(m.conflicts) + 6
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, m.getContentLine)
+}
+
+func (m keyWarningsModel) getContentLine(lineIndex int, maxWidth int) string {
+	switch {
+	case lineIndex == 0:
+		return fmt.Sprintf(" ⚠ %d problem(s) in your keybindings", len(m.conflicts))
+	case lineIndex == 1:
+		return " " + strings.Repeat("─", maxWidth-2)
+	case lineIndex-2 < len(m.conflicts):
+		return "  • " + m.conflicts[lineIndex-2].String()
+	case lineIndex == len(m.conflicts)+3:
+		return " " + locale.T("hint.key_conflicts")
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/locale"
+)
+
+// Message carrying statistics about the whole library
+type libraryStatsMsg struct {
+	stats daemon.LibraryStats
+	err   error
+}
+
+// fetchLibraryStats counts the tracks, albums and artists in the library
+func fetchLibraryStats() tea.Msg {
+	d := daemon.Daemon{}
+	stats, err := d.GetLibraryStats()
+	return libraryStatsMsg{stats: stats, err: err}
+}
+
+// formatTotalDuration formats a library-sized duration as "3d 4h 12m"
+func formatTotalDuration(d time.Duration) string {
+	minutes := int(d.Minutes())
+	days, hours := minutes/(24*60), minutes/60%24
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes%60)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes%60)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// formatSize formats a byte count as "12.3 GB"
+func formatSize(bytes int64) string {
+	const unit = 1000
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value, prefix := float64(bytes)/unit, 0
+	for value >= unit && prefix < 3 {
+		value /= unit
+		prefix++
+	}
+	return fmt.Sprintf("%.1f %cB", value, "kMGT"[prefix])
+}
+
+// libraryStatsModel is the library statistics overlay
+type libraryStatsModel struct {
+	width, height int
+	stats         daemon.LibraryStats
+	loading       bool
+	err           error
+}
+
+func (m libraryStatsModel) lines() []string {
+	switch {
+	case m.loading:
+		return []string{"Counting your library…"}
+	case m.err != nil:
+		return []string{"Couldn't load library statistics:", m.err.Error()}
+	}
+	return []string{
+		fmt.Sprintf("Tracks:          %d", m.stats.Tracks),
+		fmt.Sprintf("Albums:          %d", m.stats.Albums),
+		fmt.Sprintf("Artists:         %d", m.stats.Artists),
+		"Total duration:  " + formatTotalDuration(m.stats.Duration),
+		"Size on disk:    " + formatSize(m.stats.Size),
+	}
+}
+
+func (m libraryStatsModel) View() string {
+	overlayWidth := int(float64(m.width) * 0.4)
+	if overlayWidth < 44 {
+		overlayWidth = 44
+	}
+	// Title + separator + lines + spacer + footer, plus borders
+	overlayHeight := len(m.lines()) + 4 + 2
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, m.getContentLine)
+}
+
+func (m libraryStatsModel) getContentLine(lineIndex int, maxWidth int) string {
+	lines := m.lines()
+	switch {
+	case lineIndex == 0:
+		return " Library"
+	case lineIndex == 1:
+		return " " + strings.Repeat("─", maxWidth-2)
+	case lineIndex-2 < len(lines):
+		return "  " + lines[lineIndex-2]
+	case lineIndex == len(lines)+3:
+		return " " + locale.T("hint.close")
+	}
+	return ""
+}
@@ -0,0 +1,215 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+)
+
+// libraryTab is what the sidebar lists: playlists, or the library by album, artist or song
+type libraryTab int
+
+const (
+	tabPlaylists libraryTab = iota
+	tabAlbums
+	tabArtists
+	tabSongs
+	libraryTabCount
+)
+
+var libraryTabNames = [libraryTabCount]string{"Playlists", "Albums", "Artists", "Songs"}
+
+func (t libraryTab) String() string {
+	return libraryTabNames[t]
+}
+
+// tabBar renders the tab names with the current one highlighted, falling back to just
+// the current tab's name when they don't all fit
+func tabBar(current libraryTab, width int) string {
+	full := 0
+	for _, name := range libraryTabNames {
+		full += layout.Width(name) + 1
+	}
+	if full-1 > width {
+		return titleStyle.Render(fmt.Sprintf("%s %d/%d", current, current+1, libraryTabCount))
+	}
+	var bar string
+	for t, name := range libraryTabNames {
+		if t > 0 {
+			bar += " "
+		}
+		if libraryTab(t) == current {
+			bar += titleStyle.Render(name)
+		} else {
+			bar += playlistStatsStyle.Render(name)
+		}
+	}
+	return bar
+}
+
+// Message carrying the whole library, loaded the first time a library tab is opened
+type libraryMsg struct {
+	library daemon.Playlist
+	err     error
+}
+
+func fetchLibrary() tea.Msg {
+	d := daemon.Daemon{}
+	library, err := d.GetLibrary()
+	return libraryMsg{library: library, err: err}
+}
+
+// libraryIndex is the library grouped for the Albums and Artists tabs
+type libraryIndex struct {
+	albums  []daemon.TrackGroup
+	artists []daemon.TrackGroup
+}
+
+func newLibraryIndex(tracks []daemon.Track) *libraryIndex {
+	return &libraryIndex{
+		albums:  daemon.GroupByAlbum(tracks),
+		artists: daemon.GroupByArtist(tracks),
+	}
+}
+
+func (l *libraryIndex) groups(tab libraryTab) []daemon.TrackGroup {
+	switch tab {
+	case tabAlbums:
+		return l.albums
+	case tabArtists:
+		return l.artists
+	}
+	return nil
+}
+
+// items lists the sidebar entries of tab
+func (l *libraryIndex) items(tab libraryTab) []string {
+	groups := l.groups(tab)
+	items := make([]string, len(groups))
+	for i, group := range groups {
+		items[i] = group.Name
+	}
+	return items
+}
+
+// switchTab shows tab in the sidebar, loading the library the first time it's needed.
+// Each tab remembers its selection. The Songs tab pages through the library instead.
+func (m *Model) switchTab(tab libraryTab) tea.Cmd {
+	if tab == m.tab {
+		return nil
+	}
+	m.tabSelection[m.tab] = m.selectedPlaylistItem
+	m.tab = tab
+	m.selectedPlaylistItem = m.tabSelection[tab]
+
+	var cmd tea.Cmd
+	if (tab == tabAlbums || tab == tabArtists) && m.library == nil && !m.libraryLoading {
+		m.libraryLoading = true
+		cmd = fetchLibrary
+	}
+	m.syncTabItems()
+	m.currentFocus = focusPlaylists
+	m.updateFocus()
+	m.updatePlaylistSelection()
+
+	// There's only one thing to show on the Songs tab, so show it
+	if tab == tabSongs {
+		return m.openAllSongs()
+	}
+	return cmd
+}
+
+// syncTabItems hands the current tab's entries to the sidebar
+func (m *Model) syncTabItems() {
+	var items []string
+	switch {
+	case m.tab == tabSongs:
+		items = []string{m.songs.label()}
+	case m.library != nil:
+		items = m.library.items(m.tab)
+	}
+	m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+		pl := model.(playlistsModel)
+		if pl.tab != m.tab {
+			// Each tab keeps its open entry highlighted
+			if pl.tabActive == nil {
+				pl.tabActive = make(map[libraryTab]int)
+			}
+			pl.tabActive[pl.tab] = pl.activeItem
+			active, ok := pl.tabActive[m.tab]
+			if !ok {
+				active = -1
+			}
+			pl.activeItem = active
+		}
+		pl.tab = m.tab
+		pl.tabItems = items
+		pl.libraryError = m.libraryError
+		return pl, nil
+	})
+}
+
+// setLibrary indexes a freshly loaded library and refreshes the sidebar
+func (m *Model) setLibrary(msg libraryMsg) tea.Cmd {
+	m.libraryLoading = false
+	m.libraryError = msg.err
+	if msg.err == nil {
+		m.library = newLibraryIndex(msg.library.Tracks)
+	}
+	m.syncTabItems()
+	return nil
+}
+
+// openCollection shows the tracks of entry i of the current library tab in the main pane
+func (m *Model) openCollection(i int) tea.Cmd {
+	if m.tab == tabSongs {
+		return m.openAllSongs()
+	}
+	if m.library == nil {
+		return nil
+	}
+	groups := m.library.groups(m.tab)
+	if i < 0 || i >= len(groups) {
+		return nil
+	}
+	group := groups[i]
+
+	m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+		pl := model.(playlistsModel)
+		pl.activeItem = i
+		return pl, nil
+	})
+	m.showCollection(group, viewPlaylists)
+	return nil
+}
+
+// showCollection lists the tracks of an album or artist in the main pane, under the
+// Playlists or Search tab depending on where it was opened from
+func (m *Model) showCollection(group daemon.TrackGroup, view viewTab) {
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		m.views.remember(main)
+		main.view = view
+		main.collection = group.Name
+		main.searchQuery = ""
+		main.searchResults = group.Tracks
+		main.selectedSong = 0
+		main.scrollOffset = 0
+		return main, nil
+	})
+	m.currentFocus = focusMain
+	m.updateFocus()
+}
+
+// playCollection plays the track at index of the open album, artist or song list,
+// queueing the rest of it
+func (m *Model) playCollection(name string, tracks []daemon.Track, index int) tea.Cmd {
+	strategy := m.queueStrategy
+	d := daemon.Daemon{}
+	return attempt("Error playing song", func() error {
+		return d.PlayTracksWithStrategy(name, tracks, index+1, strategy)
+	})
+}
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// lineInput is the text typed in a one-line prompt, with a cursor that the readline
+// keys move and edit around
+type lineInput struct {
+	text string
+	pos  int // Cursor, in runes
+}
+
+// newLineInput is a prompt holding text, with the cursor after it
+func newLineInput(text string) lineInput {
+	return lineInput{text: text, pos: len([]rune(text))}
+}
+
+// View is the text with the cursor drawn as "_" where the next character goes
+func (l lineInput) View() string {
+	runes := []rune(l.text)
+	pos := min(max(l.pos, 0), len(runes))
+	return string(runes[:pos]) + "_" + string(runes[pos:])
+}
+
+// update applies an editing key, reporting whether the text changed. Typed and pasted
+// characters go in at the cursor, pastes on one line.
+func (l *lineInput) update(msg tea.KeyMsg) (changed bool) {
+	runes := []rune(l.text)
+	pos := min(max(l.pos, 0), len(runes))
+	edited := runes
+	switch msg.String() {
+	case "left", "ctrl+b":
+		pos = max(pos-1, 0)
+	case "right", "ctrl+f":
+		pos = min(pos+1, len(runes))
+	case "alt+b", "alt+left", "ctrl+left":
+		pos = wordStart(runes, pos)
+	case "alt+f", "alt+right", "ctrl+right":
+		pos = wordEnd(runes, pos)
+	case "home", "ctrl+a":
+		pos = 0
+	case "end", "ctrl+e":
+		pos = len(runes)
+	case "backspace", "ctrl+h":
+		if pos > 0 {
+			edited = splice(runes, pos-1, pos, nil)
+			pos--
+		}
+	case "delete", "ctrl+d":
+		if pos < len(runes) {
+			edited = splice(runes, pos, pos+1, nil)
+		}
+	case "ctrl+w", "alt+backspace":
+		start := wordStart(runes, pos)
+		edited, pos = splice(runes, start, pos, nil), start
+	case "alt+d":
+		edited = splice(runes, pos, wordEnd(runes, pos), nil)
+	case "ctrl+u":
+		edited, pos = runes[pos:], 0
+	case "ctrl+k":
+		edited = runes[:pos]
+	default:
+		var typed []rune
+		switch {
+		case msg.Type == tea.KeySpace:
+			typed = []rune{' '}
+		case msg.Type == tea.KeyRunes && (!msg.Alt || msg.Paste):
+			typed = oneLine(msg.Runes)
+		}
+		if len(typed) == 0 {
+			return false
+		}
+		edited = splice(runes, pos, pos, typed)
+		pos += len(typed)
+	}
+	l.pos = pos
+	if text := string(edited); text != l.text {
+		l.text = text
+		return true
+	}
+	return false
+}
+
+// splice replaces runes[from:to] with insert in a new slice
+func splice(runes []rune, from, to int, insert []rune) []rune {
+	spliced := make([]rune, 0, len(runes)-(to-from)+len(insert))
+	spliced = append(spliced, runes[:from]...)
+	spliced = append(spliced, insert...)
+	return append(spliced, runes[to:]...)
+}
+
+// oneLine turns the line breaks and tabs of pasted text into spaces, dropping the
+// break a copied line ends with and other control characters
+func oneLine(runes []rune) []rune {
+	text := strings.ReplaceAll(strings.TrimRight(string(runes), "\r\n"), "\r\n", "\n")
+	return []rune(strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n', r == '\r', r == '\t':
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, text))
+}
+
+// wordStart is where the word before pos starts, skipping the spaces right before it
+func wordStart(runes []rune, pos int) int {
+	for pos > 0 && unicode.IsSpace(runes[pos-1]) {
+		pos--
+	}
+	for pos > 0 && !unicode.IsSpace(runes[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// wordEnd is where the word after pos ends, skipping the spaces right after it
+func wordEnd(runes []rune, pos int) int {
+	for pos < len(runes) && unicode.IsSpace(runes[pos]) {
+		pos++
+	}
+	for pos < len(runes) && !unicode.IsSpace(runes[pos]) {
+		pos++
+	}
+	return pos
+}
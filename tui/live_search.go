@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+)
+
+// searchDebounce is how long typing in the search box has to pause before the query is
+// searched for
+const searchDebounce = 250 * time.Millisecond
+
+// Message sent once typing pauses, carrying the query typed by then
+type searchDebounceMsg struct {
+	seq   int
+	query string
+}
+
+// fetchSearchResults searches for songs, albums, artists and playlists by query, the
+// playlists among those in the sidebar. seq identifies the search so results of a
+// superseded one can be dropped.
+func fetchSearchResults(ctx context.Context, seq int, query string, playlists []string, live bool) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		results, err := d.SearchLibrary(ctx, query, playlists)
+		return searchResultsMsg{seq: seq, results: results, query: query, live: live, err: err}
+	}
+}
+
+// startSearch cancels any search still running and starts one for query. Live searches
+// fired while typing leave the focus in the search box.
+func (m *Model) startSearch(query string, live bool) tea.Cmd {
+	m.cancelSearch()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.searchCancel = cancel
+	return fetchSearchResults(ctx, m.searchSeq, query, m.sidebar().playlistItems, live)
+}
+
+// cancelSearch cancels the running search, if any, and makes its results stale
+func (m *Model) cancelSearch() {
+	if m.searchCancel != nil {
+		m.searchCancel()
+		m.searchCancel = nil
+	}
+	m.searchSeq++
+}
+
+// searchAsYouType schedules a search for query once typing pauses. Each key supersedes
+// the search scheduled or running for the previous one.
+func (m *Model) searchAsYouType(query string) tea.Cmd {
+	m.cancelSearch()
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+	seq := m.searchSeq
+	return tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+		return searchDebounceMsg{seq: seq, query: query}
+	})
+}
+
+// searchText is what's typed in the search box
+func (m *Model) searchText() string {
+	var text string
+	m.boxer.EditLeaf("searchHelp", func(model tea.Model) (tea.Model, error) {
+		text = model.(searchHelpModel).input.text
+		return model, nil
+	})
+	return text
+}
+
+// staleSearch reports whether msg is for a search that was superseded or canceled
+func (m *Model) staleSearch(msg searchResultsMsg) bool {
+	return msg.seq != m.searchSeq || errors.Is(msg.err, context.Canceled)
+}
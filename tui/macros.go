@@ -0,0 +1,103 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// macroAwait is the register key a macro key is waiting for
+type macroAwait int
+
+const (
+	macroAwaitNone   macroAwait = iota
+	macroAwaitRecord            // record_macro was pressed, the register comes next
+	macroAwaitPlay              // play_macro was pressed
+)
+
+// macroState records keys into registers and plays them back, like vim's q and @
+type macroState struct {
+	awaiting  macroAwait
+	recording string // Register being recorded into, "" when not recording
+	keys      []tea.KeyMsg
+	registers map[string][]tea.KeyMsg
+	last      string // Register played last, played again by "@@"
+	replaying bool   // The key being handled comes from a macro
+}
+
+// Message replaying a key recorded in a macro
+type macroKeyMsg struct {
+	key tea.KeyMsg
+}
+
+// validRegister tells whether key names a register: a lowercase letter or a digit
+func validRegister(key string) bool {
+	return len(key) == 1 && (key[0] >= 'a' && key[0] <= 'z' || key[0] >= '0' && key[0] <= '9')
+}
+
+// replayKeys sends keys back through Update one after the other
+func replayKeys(keys []tea.KeyMsg) tea.Cmd {
+	cmds := make([]tea.Cmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = func() tea.Msg { return macroKeyMsg{key: key} }
+	}
+	return tea.Sequence(cmds...)
+}
+
+// handleMacroKey records keys while recording, and takes the register after
+// record_macro or play_macro. It reports whether it used the key up.
+func (m *Model) handleMacroKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	key := msg.String()
+	// Keys played back from a macro aren't recorded again
+	if m.macro.recording != "" && !m.macro.replaying {
+		m.macro.keys = append(m.macro.keys, msg)
+	}
+	switch m.macro.awaiting {
+	case macroAwaitRecord:
+		m.macro.awaiting = macroAwaitNone
+		if key == "esc" {
+			return true, nil
+		}
+		if !validRegister(key) {
+			return true, m.toast(notifyError("Macros are recorded into a-z or 0-9, not %q", key))
+		}
+		m.macro.recording, m.macro.keys = key, nil
+		m.status.macro = key
+		m.setStatus()
+		return true, nil
+
+	case macroAwaitPlay:
+		m.macro.awaiting = macroAwaitNone
+		if key == "@" {
+			key = m.macro.last
+		}
+		// Macros don't start other macros, so one that plays itself can't run forever
+		if key == "esc" || m.macro.replaying {
+			return true, nil
+		}
+		keys := m.macro.registers[key]
+		if len(keys) == 0 {
+			return true, m.toast(notifyError("No macro recorded in @%s", key))
+		}
+		m.macro.last = key
+		return true, replayKeys(keys)
+	}
+	return false, nil
+}
+
+// toggleMacroRecording waits for a register to record into, or stops the recording
+func (m *Model) toggleMacroRecording() tea.Cmd {
+	if m.macro.recording == "" {
+		m.macro.awaiting = macroAwaitRecord
+		return nil
+	}
+	// The key that stopped the recording isn't part of it
+	keys := m.macro.keys[:max(0, len(m.macro.keys)-1)]
+	if m.macro.registers == nil {
+		m.macro.registers = make(map[string][]tea.KeyMsg)
+	}
+	register := m.macro.recording
+	m.macro.registers[register] = keys
+	m.macro.recording, m.macro.keys = "", nil
+	m.status.macro = ""
+	m.setStatus()
+	return m.toast(notify("Recorded %d keys into @%s", len(keys), register))
+}
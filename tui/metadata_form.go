@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/locale"
+)
+
+// Message carrying the full metadata of a track
+type trackInfoMsg struct {
+	track daemon.Track
+	info  daemon.TrackInfo
+	err   error
+}
+
+// fetchTrackInfo loads the full metadata of track
+func fetchTrackInfo(track daemon.Track) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		info, err := d.GetTrackInfo(track.Id)
+		return trackInfoMsg{track: track, info: info, err: err}
+	}
+}
+
+// Message sent after edited metadata was written to Music
+type metadataSavedMsg struct {
+	id   string
+	meta daemon.TrackMetadata
+	err  error
+}
+
+// saveTrackMetadata writes meta to the track with the given persistent ID
+func saveTrackMetadata(id string, meta daemon.TrackMetadata) tea.Cmd {
+	return inFlight("Saving tags", func() tea.Msg {
+		d := daemon.Daemon{}
+		return metadataSavedMsg{id: id, meta: meta, err: d.SetTrackMetadata(id, meta)}
+	})
+}
+
+// Fields of the metadata form, in display order
+const (
+	metadataName = iota
+	metadataArtist
+	metadataAlbum
+	metadataGenre
+	metadataYear
+	metadataFieldCount
+)
+
+var metadataLabels = [metadataFieldCount]string{"Name", "Artist", "Album", "Genre", "Year"}
+
+// metadataFormModel is the "Edit Metadata" overlay
+type metadataFormModel struct {
+	width, height int
+	id            string
+	values        [metadataFieldCount]lineInput
+	focused       int
+	err           string // Validation problem shown above the footer
+}
+
+// newMetadataForm fills the form from a track's current tags
+func newMetadataForm(info daemon.TrackInfo) metadataFormModel {
+	form := metadataFormModel{id: info.Id}
+	form.values[metadataName] = newLineInput(info.Name)
+	form.values[metadataArtist] = newLineInput(info.Artist)
+	form.values[metadataAlbum] = newLineInput(info.Album)
+	form.values[metadataGenre] = newLineInput(info.Genre)
+	if info.Year > 0 {
+		form.values[metadataYear] = newLineInput(strconv.Itoa(info.Year))
+	}
+	return form
+}
+
+// metadata validates the form, returning the tags to write
+func (m metadataFormModel) metadata() (daemon.TrackMetadata, error) {
+	meta := daemon.TrackMetadata{
+		Name:   strings.TrimSpace(m.values[metadataName].text),
+		Artist: strings.TrimSpace(m.values[metadataArtist].text),
+		Album:  strings.TrimSpace(m.values[metadataAlbum].text),
+		Genre:  strings.TrimSpace(m.values[metadataGenre].text),
+	}
+	if meta.Name == "" {
+		return meta, fmt.Errorf("name can't be empty")
+	}
+	if year := strings.TrimSpace(m.values[metadataYear].text); year != "" {
+		n, err := strconv.Atoi(year)
+		if err != nil || n < 0 || n > 9999 {
+			return meta, fmt.Errorf("year must be a number like 1999")
+		}
+		meta.Year = n
+	}
+	return meta, nil
+}
+
+// update edits the focused field. It returns done when the form should close,
+// with the command that saves it (nil when cancelled).
+func (m metadataFormModel) update(msg tea.KeyMsg) (form metadataFormModel, cmd tea.Cmd, done bool) {
+	switch msg.String() {
+	case "esc":
+		return m, nil, true
+	case "enter":
+		meta, err := m.metadata()
+		if err != nil {
+			m.err = err.Error()
+			return m, nil, false
+		}
+		return m, saveTrackMetadata(m.id, meta), true
+	case "tab", "down":
+		m.focused = (m.focused + 1) % metadataFieldCount
+	case "shift+tab", "up":
+		m.focused = (m.focused + metadataFieldCount - 1) % metadataFieldCount
+	default:
+		m.values[m.focused].update(msg)
+	}
+	m.err = ""
+	return m, nil, false
+}
+
+func (m metadataFormModel) View() string {
+	overlayWidth := int(float64(m.width) * 0.6)
+	if overlayWidth < 50 {
+		overlayWidth = 50
+	}
+	// Title + separator + fields + spacer + error + footer, plus borders
+	overlayHeight := metadataFieldCount + 5 + 2
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, m.getContentLine)
+}
+
+func (m metadataFormModel) getContentLine(lineIndex int, maxWidth int) string {
+	switch {
+	case lineIndex == 0:
+		return " Edit Metadata"
+	case lineIndex == 1:
+		return " " + strings.Repeat("─", maxWidth-2)
+	case lineIndex-2 < metadataFieldCount:
+		field := lineIndex - 2
+		label := fmt.Sprintf("%-7s", metadataLabels[field])
+		if field == m.focused {
+			return " ▶ " + label + selectedItemStyle.Render(m.values[field].View())
+		}
+		return "   " + label + m.values[field].text
+	case lineIndex == metadataFieldCount+3 && m.err != "":
+		return " " + warningStyle.Render(m.err)
+	case lineIndex == metadataFieldCount+4:
+		return " " + locale.T("hint.metadata")
+	}
+	return ""
+}
+
+// applyMetadata updates every cached copy of a track after its tags were edited
+func applyMetadata(cache map[string]daemon.Playlist, id string, meta daemon.TrackMetadata) {
+	for _, playlist := range cache {
+		for i := range playlist.Tracks {
+			if playlist.Tracks[i].Id == id {
+				playlist.Tracks[i].Name = meta.Name
+				playlist.Tracks[i].Artist = meta.Artist
+				playlist.Tracks[i].Album = meta.Album
+			}
+		}
+	}
+}
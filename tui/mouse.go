@@ -0,0 +1,223 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/treilik/bubbleboxer"
+)
+
+// doubleClickTime is the longest gap between two clicks on the same row that counts as a double-click
+const doubleClickTime = 400 * time.Millisecond
+
+// wheelStep is how many rows one notch of the scroll wheel moves the selection
+const wheelStep = 3
+
+// mouseClick remembers the last click, to recognize double-clicks
+type mouseClick struct {
+	leaf string
+	row  int
+	at   time.Time
+}
+
+// leafAt finds the boxer leaf at x, y (relative to node) and the position within it.
+// Children are separated by a one cell border, which belongs to no leaf.
+func leafAt(node bubbleboxer.Node, x, y int) (address string, col, row int, ok bool) {
+	if node.IsLeaf() {
+		if x < 0 || y < 0 || x >= node.GetWidth() || y >= node.GetHeight() {
+			return "", 0, 0, false
+		}
+		return node.GetAddress(), x, y, true
+	}
+
+	offset := 0
+	for _, child := range node.Children {
+		if node.VerticalStacked {
+			if y < offset+child.GetHeight() {
+				return leafAt(child, x, y-offset)
+			}
+			offset += child.GetHeight() + 1
+		} else {
+			if x < offset+child.GetWidth() {
+				return leafAt(child, x-offset, y)
+			}
+			offset += child.GetWidth() + 1
+		}
+	}
+	return "", 0, 0, false
+}
+
+// itemAt returns the sidebar item drawn on line row of the playlists view
+func (m playlistsModel) itemAt(row int) (int, bool) {
+	line := 2 // Title + empty line
+	end := min(m.scrollOffset+m.visibleItems(), m.itemCount())
+	for i := m.scrollOffset; i < end; i++ {
+		if _, isStation := m.station(i); isStation && (i == len(m.playlistItems) || i == m.scrollOffset) {
+			line++ // "Stations" section title
+		}
+		if line == row {
+			return i, true
+		}
+		line++
+	}
+	return 0, false
+}
+
+// songAt returns the song list row drawn on line row of the main view
+func (m mainContentModel) songAt(row int) (int, bool) {
+	const headerLines = 3 // title + header + separator
+	if row < headerLines {
+		return 0, false
+	}
+
+	count := 0
+	switch {
+	case m.showsResults():
+		count = m.searchRowCount()
+	case m.currentPlaylist != "" && m.playlistCache != nil:
+		count = m.rowCount((*m.playlistCache)[m.currentPlaylist].Tracks)
+	}
+	song := m.scrollOffset + row - headerLines
+	return song, song < count
+}
+
+// updateMouse handles clicks and the scroll wheel. Overlays other than the queue take
+// the keyboard only, so the mouse does nothing while one of them is open.
+func (m *Model) updateMouse(msg tea.MouseMsg) tea.Cmd {
+	// The views drawn over the screen leave the tab bar at the bottom to click
+	if m.queueVisible || m.lyricsVisible || m.historyVisible || m.libraryStatsVisible {
+		if cmd, ok := m.clickViewTab(msg); ok {
+			return cmd
+		}
+	}
+	if m.preflightVisible || m.musicDownVisible || m.keyWarningsVisible || m.queueRestoreVisible || m.permissionVisible || m.doctorVisible ||
+		m.libraryStatsVisible || m.historyVisible || m.themePickerVisible || m.helpVisible || m.paletteVisible || m.metadataFormVisible || m.trackInspectorVisible ||
+		m.confirmVisible || m.trackPickerVisible || m.playlistPickerVisible || m.queueSearchVisible || m.lyricsVisible || m.nowPlayingVisible || m.contextVisible || m.filtering || m.finding {
+		return nil
+	}
+
+	wheel := 0
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp:
+		wheel = -wheelStep
+	case msg.Button == tea.MouseButtonWheelDown:
+		wheel = wheelStep
+	case msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress:
+	default:
+		return nil
+	}
+
+	if m.queueVisible {
+		if wheel != 0 {
+			for range wheelStep {
+				m.moveQueueSelection(wheel)
+			}
+		}
+		return nil
+	}
+
+	// The compact layout has no panes to click, but the wheel still scrolls
+	if m.compact() {
+		switch {
+		case wheel == 0:
+		case m.currentFocus == focusPlaylists:
+			m.scrollPlaylists(wheel)
+		default:
+			m.updateSongSelection(wheel)
+		}
+		return nil
+	}
+
+	// The layout is drawn inside baseStyle's margin
+	x := msg.X - baseStyle.GetMarginLeft()
+	y := msg.Y - baseStyle.GetMarginTop()
+	leaf, col, row, ok := leafAt(m.boxer.LayoutTree, x, y)
+	if !ok {
+		return nil
+	}
+
+	switch leaf {
+	case "tabs":
+		if wheel != 0 {
+			return nil
+		}
+		view, found := viewTab(0), false
+		m.boxer.EditLeaf("tabs", func(model tea.Model) (tea.Model, error) {
+			view, found = model.(viewTabsModel).tabAt(col)
+			return model, nil
+		})
+		if found {
+			return m.switchView(view)
+		}
+
+	case "playback":
+		if wheel == 0 {
+			return m.clickPlayback(col, row)
+		}
+
+	case "playlists":
+		m.currentFocus = focusPlaylists
+		m.updateFocus()
+		if wheel != 0 {
+			m.scrollPlaylists(wheel)
+			return nil
+		}
+
+		item, found := -1, false
+		m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+			pl := model.(playlistsModel)
+			item, found = pl.itemAt(row)
+			return pl, nil
+		})
+		if !found {
+			return nil
+		}
+		// A click opens the playlist or starts the station, like enter
+		return m.openSidebarItem(item)
+
+	case "main":
+		m.currentFocus = focusMain
+		m.updateFocus()
+		if wheel != 0 {
+			m.updateSongSelection(wheel)
+			return nil
+		}
+
+		song, current, found := 0, 0, false
+		m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+			main := model.(mainContentModel)
+			song, found = main.songAt(row)
+			current = main.selectedSong
+			return main, nil
+		})
+		if !found {
+			return nil
+		}
+		m.updateSongSelection(song - current)
+
+		// Clicking the same row again soon after plays it
+		click := mouseClick{leaf: leaf, row: song, at: time.Now()}
+		previous := m.lastClick
+		m.lastClick = click
+		if previous.leaf == click.leaf && previous.row == click.row && click.at.Sub(previous.at) <= doubleClickTime {
+			m.lastClick = mouseClick{}
+			return m.activateSelection()
+		}
+	}
+	return nil
+}
+
+// scrollPlaylists moves the sidebar selection by delta items, staying within the list
+func (m *Model) scrollPlaylists(delta int) {
+	var count int
+	m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+		pl := model.(playlistsModel)
+		count = pl.itemCount()
+		return pl, nil
+	})
+	if count == 0 {
+		return
+	}
+	m.selectedPlaylistItem = max(0, min(count-1, m.selectedPlaylistItem+delta))
+	m.updatePlaylistSelection()
+}
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+	"main/locale"
+)
+
+// musicProbeInterval is how often amtui checks whether Music.app is back while it's closed
+const musicProbeInterval = 2 * time.Second
+
+// Message carrying whether Music.app answered a probe while it was closed
+type musicProbeMsg struct {
+	ready bool
+}
+
+// musicDownModel stands in for every pane while Music.app is closed, offering to launch it
+type musicDownModel struct {
+	width, height int
+	launching     bool
+	err           error // Why the last launch failed
+}
+
+// probeMusic checks whether Music.app is back after musicProbeInterval
+func probeMusic() tea.Cmd {
+	return tea.Tick(musicProbeInterval, func(time.Time) tea.Msg {
+		d := daemon.Daemon{}
+		return musicProbeMsg{ready: d.Ready()}
+	})
+}
+
+// launchMusic starts Music.app and waits until it's ready
+func launchMusic() tea.Msg {
+	d := daemon.Daemon{}
+	return musicReadyMsg{err: d.EnsureRunning(musicLaunchTimeout)}
+}
+
+// checkMusicDown shows the placeholder once a poll finds Music.app closed, and starts
+// probing for it to come back
+func (m *Model) checkMusicDown(err error) tea.Cmd {
+	if m.musicDownVisible || m.preflightVisible || !daemon.NotRunning(err) {
+		return nil
+	}
+	m.musicDownVisible = true
+	m.musicDown = musicDownModel{}
+	return probeMusic()
+}
+
+// handleMusicProbe leaves the placeholder once Music.app answers, or probes again
+func (m *Model) handleMusicProbe(msg musicProbeMsg) tea.Cmd {
+	if !m.musicDownVisible || m.musicDown.launching {
+		return nil
+	}
+	if msg.ready {
+		return func() tea.Msg { return musicReadyMsg{} }
+	}
+	return probeMusic()
+}
+
+// recoverMusic leaves the placeholder and loads the library again once Music.app is
+// back, or shows why launching it failed
+func (m *Model) recoverMusic(err error) tea.Cmd {
+	// Music is up when it only refuses Automation, the permission screen takes over
+	if err != nil && !errors.Is(err, daemon.ErrAutomationDenied) {
+		m.musicDown.launching, m.musicDown.err = false, err
+		return probeMusic()
+	}
+	m.musicDownVisible = false
+	m.checkPermission(err)
+	return tea.Batch(fetchPlaylists, fetchAllPlaylists())
+}
+
+// updateMusicDown handles keys while the placeholder is shown
+func (m *Model) updateMusicDown(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		if !m.musicDown.launching {
+			m.musicDown.launching, m.musicDown.err = true, nil
+			return launchMusic
+		}
+	case "q", "ctrl+c":
+		return tea.Quit
+	}
+	return nil
+}
+
+func (m musicDownModel) lines() []string {
+	switch {
+	case m.launching:
+		return []string{locale.T("music_down.starting")}
+	case m.err != nil:
+		return []string{locale.T("music_down.launch"), "", warningStyle.Render(locale.T("error", m.err))}
+	}
+	return []string{locale.T("music_down.launch"), "", statusMutedStyle.Render(locale.T("music_down.waiting"))}
+}
+
+func (m musicDownModel) View() string {
+	lines := m.lines()
+	// Title and separator on top, the hint at the bottom, the rest centered between
+	rows := max(0, m.height-2)
+	top := max(0, (rows-3-len(lines))/2)
+	return renderOverlay(m.width, m.height, m.width, m.height, func(lineIndex, maxWidth int) string {
+		switch {
+		case lineIndex == 0:
+			return " " + titleStyle.Render(locale.T("music_down.title"))
+		case lineIndex == 1:
+			return " " + strings.Repeat("─", maxWidth-2)
+		case lineIndex == rows-1:
+			return " " + locale.T("hint.music_down")
+		case lineIndex-2-top >= 0 && lineIndex-2-top < len(lines):
+			return layout.Center(lines[lineIndex-2-top], maxWidth)
+		}
+		return ""
+	})
+}
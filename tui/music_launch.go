@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/layout"
+)
+
+// musicLaunchTimeout is how long to wait for Music.app to start before giving up
+const musicLaunchTimeout = 30 * time.Second
+
+// Message sent once Music.app can be scripted
+type musicReadyMsg struct {
+	err error
+}
+
+// setStartupNotice shows or clears the startup banner in the instructions area
+func (m *Model) setStartupNotice(notice string) {
+	m.boxer.EditLeaf("instructions", func(model tea.Model) (tea.Model, error) {
+		instr := model.(instructionsModel)
+		instr.startup = notice
+		return instr, nil
+	})
+}
+
+// renderStartupBanner renders the startup notice to fit the instructions area
+func (m instructionsModel) renderStartupBanner() string {
+	return upNextStyle.Render(layout.Fit(m.startup, m.width, "..."))
+}
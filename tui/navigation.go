@@ -0,0 +1,241 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// motionTimeout is how long a count or the "g" of "gg" waits for the rest of a motion.
+// Keys bound to something else, like "1" for the playlists tab, only wait in the song
+// list, and do that once the wait is over. Elsewhere they start no motion.
+const motionTimeout = 500 * time.Millisecond
+
+// motionKind is what a vim motion does to the selection of a list
+type motionKind int
+
+const (
+	motionLines    motionKind = iota // count rows down, up when negative (15j, 3k)
+	motionTop                        // First row, or row count with a count (gg, 15gg)
+	motionBottom                     // Last row, or row count with a count (G, 15G)
+	motionHalfPage                   // count half pages down, up when negative (ctrl+d, ctrl+u)
+)
+
+// motion is a complete vim motion
+type motion struct {
+	kind  motionKind
+	count int
+}
+
+// motionHelpKeys are the vim motions of the lists, which can't be remapped
+var motionHelpKeys = [][2]string{
+	{"gg/G", "first/last row"},
+	{"ctrl+d/ctrl+u", "half a page down/up"},
+	{"<n>j/<n>k", "n rows down/up, e.g. 15j"},
+	{"<n>G/<n>gg", "row n"},
+}
+
+// Message sent when keys held back for a motion have waited long enough
+type motionTimeoutMsg struct {
+	seq int
+}
+
+// motionState collects a count and the "g" of "gg" between keys
+type motionState struct {
+	count   int
+	pending []string // Keys held back: the count's digits and a "g"
+	seq     int      // Numbers each wait so only the latest times out
+	replay  bool     // Keys are being replayed and bypass motions
+}
+
+// feed takes the next key in a list, where up and down tell whether the key moves the
+// selection a row, and wait whether it may be held back to start a motion. It returns
+// the motion the keys complete, or held when key was kept back for more. Otherwise
+// replay has the keys held back before that turned out not to start a motion, to handle
+// as usual ahead of key.
+func (s *motionState) feed(key string, up, down, wait bool) (mo motion, ok bool, held bool, replay []string) {
+	waitingForG := len(s.pending) > 0 && s.pending[len(s.pending)-1] == "g"
+	// Once a motion is started, keys go on with it whatever they are bound to
+	starts := wait || len(s.pending) > 0
+	switch {
+	case starts && (len(key) == 1 && key[0] >= '1' && key[0] <= '9' || key == "0" && s.count > 0):
+		if waitingForG {
+			replay = s.reset()
+		}
+		s.count = s.count*10 + int(key[0]-'0')
+		s.pending = append(s.pending, key)
+		return motion{}, false, true, replay
+	case key == "g" && waitingForG:
+		mo = motion{kind: motionTop, count: s.count}
+	case key == "g" && starts:
+		s.pending = append(s.pending, key)
+		return motion{}, false, true, nil
+	case key == "G":
+		mo = motion{kind: motionBottom, count: s.count}
+	case key == "ctrl+d":
+		mo = motion{kind: motionHalfPage, count: max(s.count, 1)}
+	case key == "ctrl+u":
+		mo = motion{kind: motionHalfPage, count: -max(s.count, 1)}
+	case (up || down) && s.count > 0 && !waitingForG:
+		mo = motion{kind: motionLines, count: s.count}
+		if up {
+			mo.count = -mo.count
+		}
+	default:
+		return motion{}, false, false, s.reset()
+	}
+	s.reset()
+	return mo, true, false, nil
+}
+
+// reset forgets the keys held back. A single one is returned to be handled as usual; a
+// longer count is dropped like vim drops a count no motion follows.
+func (s *motionState) reset() []string {
+	var replay []string
+	if len(s.pending) == 1 {
+		replay = s.pending
+	}
+	s.count = 0
+	s.pending = nil
+	return replay
+}
+
+// wait returns the command timing out the keys held back
+func (s *motionState) wait() tea.Cmd {
+	s.seq++
+	seq := s.seq
+	return tea.Tick(motionTimeout, func(time.Time) tea.Msg { return motionTimeoutMsg{seq: seq} })
+}
+
+// target returns the row mo selects from current in a list of length rows starting at
+// first, page rows tall
+func (mo motion) target(current, first, length, page int) int {
+	var row int
+	switch mo.kind {
+	case motionLines:
+		row = current + mo.count
+	case motionTop, motionBottom:
+		switch {
+		case mo.count > 0:
+			row = mo.count - 1
+		case mo.kind == motionTop:
+			row = first
+		default:
+			row = length - 1
+		}
+	case motionHalfPage:
+		row = current + mo.count*max(page/2, 1)
+	}
+	return min(max(row, first), length-1)
+}
+
+// handleMotion feeds msg to the vim motions of the list the keys of scope move in. It
+// reports whether the key was used up; if not, msg is handled as usual by the returned
+// model, which may have replayed keys held back before it.
+func (m Model) handleMotion(msg tea.KeyMsg, scope keyScope) (Model, tea.Cmd, bool) {
+	if m.motion.replay {
+		return m, nil, false
+	}
+	up, down := actionUp, actionDown
+	if scope == scopeQueue {
+		up, down = actionQueueUp, actionQueueDown
+	}
+	action := m.keys.action(scope, msg.String())
+	// Keys with an action of their own are worth the wait only in the song list, where
+	// counts are of use. Elsewhere they don't hold up the tab keys.
+	wait := action == "" || scope == scopeGlobal && m.currentFocus == focusMain
+	mo, ok, held, replay := m.motion.feed(msg.String(), action == up, action == down, wait)
+	switch {
+	case ok:
+		if scope == scopeQueue {
+			m.moveQueueTo(mo)
+		} else {
+			m.moveListTo(mo)
+		}
+		return m, nil, true
+	case held:
+		replayed, cmd := m.replayKeys(replay)
+		return replayed, tea.Batch(cmd, replayed.motion.wait()), true
+	}
+	replayed, cmd := m.replayKeys(replay)
+	return replayed, cmd, false
+}
+
+// resolveMotionTimeout handles keys held back for a motion that never came
+func (m Model) resolveMotionTimeout(msg motionTimeoutMsg) (Model, tea.Cmd) {
+	if msg.seq != m.motion.seq {
+		return m, nil
+	}
+	return m.replayKeys(m.motion.reset())
+}
+
+// replayKeys handles keys held back for a motion as if they were typed without one
+func (m Model) replayKeys(keys []string) (Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, key := range keys {
+		m.motion.replay = true
+		updated, cmd := m.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		if model, ok := updated.(Model); ok {
+			m = model
+		}
+		m.motion.replay = false
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// moveListTo applies mo to the focused sidebar or song list
+func (m *Model) moveListTo(mo motion) {
+	switch m.currentFocus {
+	case focusPlaylists:
+		var count, page int
+		m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+			pl := model.(playlistsModel)
+			count, page = pl.itemCount(), pl.visibleItems()
+			return pl, nil
+		})
+		if count == 0 {
+			return
+		}
+		m.selectedPlaylistItem = mo.target(m.selectedPlaylistItem, 0, count, page)
+		m.updatePlaylistSelection()
+	case focusMain:
+		current, count, page := m.songListPosition()
+		if count == 0 {
+			return
+		}
+		m.updateSongSelection(mo.target(current, 0, count, page) - current)
+	}
+}
+
+// songListPosition returns the selected row, the number of rows and the visible rows
+// of the main pane
+func (m *Model) songListPosition() (current, count, page int) {
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		current = main.selectedSong
+		page = max(main.height-3, 1) // Title, header and separator
+		if main.showsResults() {
+			count = main.searchRowCount()
+		} else if playlist, ok := m.playlistCache[m.selectedPlaylist]; ok && m.selectedPlaylist != "" {
+			count = main.rowCount(playlist.Tracks)
+		}
+		return main, nil
+	})
+	return current, count, page
+}
+
+// moveQueueTo applies mo to the rows of the queue overlay, which are numbered by queue
+// position for a count given to gg and G
+func (m *Model) moveQueueTo(mo motion) {
+	l := &m.queueOverlay.list
+	count := len(l.VisibleItems())
+	if count == 0 {
+		return
+	}
+	if (mo.kind == motionTop || mo.kind == motionBottom) && mo.count > 0 {
+		m.queueOverlay.selectIndex(mo.count - 1)
+		return
+	}
+	l.Select(mo.target(l.Index(), 0, count, l.Paginator.PerPage))
+}
@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/layout"
+	"main/locale"
+)
+
+// nowPlayingActions are the global actions that still work in the Now Playing view
+var nowPlayingActions = map[keyAction]bool{
+	actionPlayPause: true, actionNextTrack: true, actionPrevTrack: true,
+	actionShuffle: true, actionRepeat: true, actionMute: true, actionTimeLeft: true,
+	actionVolumeUp: true, actionVolumeDown: true, actionVolumeUpFine: true, actionVolumeDownFine: true,
+	actionSeekBack: true, actionSeekForward: true, actionJumpBack: true, actionJumpForward: true,
+	actionQuit: true,
+}
+
+// playback is the playback bar's model, for views that show the playing track elsewhere
+func (m Model) playback() playbackModel {
+	var pb playbackModel
+	m.boxer.EditLeaf("playback", func(model tea.Model) (tea.Model, error) {
+		pb = model.(playbackModel)
+		return pb, nil
+	})
+	return pb
+}
+
+// openNowPlaying shows the playing track full screen
+func (m *Model) openNowPlaying() tea.Cmd {
+	m.nowPlayingVisible = true
+	return m.startVisualizerFrames()
+}
+
+// updateNowPlaying handles keys in the Now Playing view. Playback keys keep working;
+// anything else is ignored.
+func (m Model) updateNowPlaying(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.action(scopeNowPlaying, msg.String()) {
+	case actionNowPlayingClose:
+		m.nowPlayingVisible = false
+		return m, nil
+	case actionVisualizer:
+		m.visualizerMode = (m.visualizerMode + 1) % visualizerModeCount
+		return m, m.startVisualizerFrames()
+	}
+	action := m.keys.action(scopeGlobal, msg.String())
+	if action == actionNowPlaying {
+		m.nowPlayingVisible = false
+		return m, nil
+	}
+	if nowPlayingActions[action] {
+		return m.runAction(action, nil)
+	}
+	return m, nil
+}
+
+// startVisualizerFrames keeps the visualizer moving while it's on screen and a track
+// plays, unless frames are already coming
+func (m *Model) startVisualizerFrames() tea.Cmd {
+	if m.visualizerFraming || !m.visualizerMoving() {
+		return nil
+	}
+	m.visualizerFraming = true
+	return nextVisualizerFrame()
+}
+
+// visualizerMoving tells whether the visualizer is on screen with a track playing
+func (m Model) visualizerMoving() bool {
+	return m.nowPlayingVisible && m.visualizerMode != visualizerOff &&
+		m.playback().status.PlayerState == "playing"
+}
+
+// nextVisualizerFrame waits for the next frame of the visualizer
+func nextVisualizerFrame() tea.Cmd {
+	return tea.Tick(visualizerFrameInterval, func(time.Time) tea.Msg { return visualizerFrameMsg{} })
+}
+
+// handleVisualizerFrame schedules the next frame, or lets frames stop with the music or
+// once the view is closed
+func (m *Model) handleVisualizerFrame() tea.Cmd {
+	if !m.visualizerMoving() {
+		m.visualizerFraming = false
+		return nil
+	}
+	return nextVisualizerFrame()
+}
+
+// renderNowPlaying draws the playing track full screen: cover art, title, artist and
+// album, progress and the visualizer, each centered
+func (m Model) renderNowPlaying() string {
+	width, height := m.lastWidth, m.lastHeight
+	if width < 20 || height < 8 {
+		return ""
+	}
+	inner, rows := width-2, height-2
+	pb := m.playback()
+	status := pb.status
+
+	footer := []string{"", locale.T("hint.now_playing", m.visualizerMode)}
+	var body []string
+	if status.Track.Name == "" {
+		body = []string{locale.T("nothing_playing")}
+	} else {
+		body = []string{
+			strings.TrimSpace(stateGlyph(status.PlayerState) + " " + titleStyle.Render(status.Track.Name)),
+			statusMutedStyle.Render(strings.Join(nonEmpty(status.Track.Artist, status.Track.Album), " — ")),
+			"",
+		}
+		if status.Duration > 0 {
+			barWidth := min(inner-layout.Width(pb.timeInfo())-6, 60)
+			if barWidth >= 10 {
+				body = append(body, progressBar(pb.position()/status.Duration, barWidth)+"  "+pb.timeInfo())
+			} else {
+				body = append(body, pb.timeInfo())
+			}
+		}
+
+		// The visualizer gets a quarter of the room, the art what's left
+		vizRows := 0
+		if m.visualizerMode != visualizerOff {
+			vizRows = min(max(rows/4, 3), 8)
+		}
+		artRows := min(rows-2-len(body)-len(footer)-vizRows-2, inner/2, 20)
+		if vizRows > 0 {
+			color := selectedItemStyle
+			if status.PlayerState != "playing" {
+				color = playbackOffStyle
+			}
+			viz := newVisualizer(status.Track).render(m.visualizerMode, min(inner-4, 96), vizRows, pb.position())
+			body = append(body, "")
+			for _, line := range viz {
+				body = append(body, color.Render(line))
+			}
+		}
+		if artRows >= 4 {
+			if art := pb.artwork.lines(artworkSlotNowPlaying, artRows*2, artRows); len(art) > 0 {
+				body = slices.Concat(art, []string{""}, body)
+			}
+		}
+	}
+
+	// Title and separator on top, the hint at the bottom, the rest centered between
+	top := max(0, (rows-2-len(footer)-len(body))/2)
+	lines := []string{" " + titleStyle.Render(locale.T("now_playing.title")), " " + strings.Repeat("─", max(0, inner-2))}
+	lines = append(lines, make([]string, top)...)
+	for _, line := range body {
+		lines = append(lines, layout.Center(line, inner))
+	}
+	for len(lines) < rows-len(footer) {
+		lines = append(lines, "")
+	}
+	lines = append(lines[:min(len(lines), rows-len(footer))], footer...)
+	lines[len(lines)-1] = " " + lines[len(lines)-1]
+
+	return renderOverlay(width, height, width, height, func(lineIndex, maxWidth int) string {
+		if lineIndex < len(lines) {
+			return lines[lineIndex]
+		}
+		return ""
+	})
+}
+
+// nonEmpty drops the empty strings from values
+func nonEmpty(values ...string) []string {
+	var kept []string
+	for _, v := range values {
+		if v != "" {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
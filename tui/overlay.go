@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"strings"
+
+	"main/layout"
+)
+
+// renderOverlay draws a bordered box of overlayWidth x overlayHeight centered on a
+// width x height transparent screen. line returns the content of each inner row.
+func renderOverlay(width, height, overlayWidth, overlayHeight int, line func(lineIndex, maxWidth int) string) string {
+	// Ensure overlay doesn't exceed terminal bounds
+	if overlayWidth > width {
+		overlayWidth = width
+	}
+	if overlayHeight > height {
+		overlayHeight = height
+	}
+	if overlayWidth < 2 || overlayHeight < 2 {
+		return ""
+	}
+
+	// Center the overlay
+	leftPadding := (width - overlayWidth) / 2
+	topPadding := (height - overlayHeight) / 2
+	rightPadding := width - leftPadding - overlayWidth
+	availableContentWidth := overlayWidth - 2 // Account for left and right borders
+
+	var content strings.Builder
+	for row := 0; row < height; row++ {
+		if row > 0 {
+			content.WriteString("\n")
+		}
+
+		// Rows outside the overlay are transparent
+		if row < topPadding || row >= topPadding+overlayHeight {
+			content.WriteString(strings.Repeat(" ", width))
+			continue
+		}
+
+		overlayRow := row - topPadding
+		content.WriteString(strings.Repeat(" ", leftPadding))
+		switch overlayRow {
+		case 0:
+			content.WriteString("┌" + strings.Repeat("─", overlayWidth-2) + "┐")
+		case overlayHeight - 1:
+			content.WriteString("└" + strings.Repeat("─", overlayWidth-2) + "┘")
+		default:
+			contentLine := line(overlayRow-1, availableContentWidth)
+			content.WriteString("│" + layout.Fit(contentLine, availableContentWidth, "...") + "│")
+		}
+		content.WriteString(strings.Repeat(" ", rightPadding))
+	}
+
+	return content.String()
+}
+
+// renderPanel draws lines in a panel room cells wide over the top right of a view width
+// cells wide, leaving the rest of view as it is
+func renderPanel(view string, width, room int, lines []string) string {
+	if width < 20 || room < 4 {
+		return view
+	}
+	boxes := make([]string, len(lines))
+	for i, text := range lines {
+		boxes[i] = toastStyle.Render(" " + layout.Fit(text, room-2, layout.Ellipsis) + " ")
+	}
+	return placeRight(view, width, 1, boxes) // Keep clear of the top border
+}
+
+// placeRight draws boxes one per row of view from row down, against the right edge of a
+// view width cells wide, leaving the rest of view as it is. Boxes that would fall above
+// the view or on its last line are left out.
+func placeRight(view string, width, row int, boxes []string) string {
+	rows := strings.Split(view, "\n")
+	for i, box := range boxes {
+		r := row + i
+		if r < 0 {
+			continue
+		}
+		if r >= len(rows)-1 {
+			break
+		}
+		// Keep the styled start of the line and put the box after it
+		left := width - layout.Width(box) - 1
+		rows[r] = layout.Fit(rows[r], left, "") + "\x1b[0m" + box + " "
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+	"main/locale"
+)
+
+// paletteResults is how many matches the command palette shows at once
+const paletteResults = 12
+
+// paletteHidden are actions that only make sense as keys, so the palette leaves them out
+var paletteHidden = map[keyAction]bool{
+	actionUp:         true,
+	actionDown:       true,
+	actionSelect:     true,
+	actionPanePrefix: true,
+	actionPalette:    true,
+}
+
+// paletteEntry is something the command palette can run: a global action, or opening
+// the sidebar item at index item
+type paletteEntry struct {
+	label  string
+	keys   string // Keys bound to the action, shown as a reminder
+	action keyAction
+	item   int
+}
+
+// paletteEntries lists every global action followed by the sidebar's playlists and stations
+func paletteEntries(km keyMap, pl playlistsModel) []paletteEntry {
+	var entries []paletteEntry
+	for _, binding := range defaultBindings {
+		if binding.scope != scopeGlobal || paletteHidden[binding.action] {
+			continue
+		}
+		keys := km.keys(binding.action)
+		names := make([]string, len(keys))
+		for i, key := range keys {
+			names[i] = displayKey(key)
+		}
+		label := binding.help
+		if label != "" {
+			label = strings.ToUpper(label[:1]) + label[1:]
+		}
+		entries = append(entries, paletteEntry{label: label, keys: strings.Join(names, "/"), action: binding.action, item: -1})
+	}
+	for i, name := range pl.playlistItems {
+		entries = append(entries, paletteEntry{label: "Open playlist: " + name, item: i})
+	}
+	for i, station := range pl.stations {
+		entries = append(entries, paletteEntry{label: "Play station: " + station.Name, item: len(pl.playlistItems) + i})
+	}
+	return entries
+}
+
+// paletteModel is the Ctrl+P overlay for finding and running any action by name
+type paletteModel struct {
+	width, height int
+	entries       []paletteEntry
+	query         lineInput
+	matches       []paletteEntry
+	selected      int
+}
+
+func newPalette(entries []paletteEntry) paletteModel {
+	m := paletteModel{entries: entries}
+	m.filter()
+	return m
+}
+
+// filter ranks the entries against the query, best match first
+func (m *paletteModel) filter() {
+	type scored struct {
+		entry paletteEntry
+		score int
+	}
+	var results []scored
+	for _, entry := range m.entries {
+		if score, ok := daemon.FuzzyScore(m.query.text, entry.label); ok {
+			results = append(results, scored{entry, score})
+		}
+	}
+	if m.query.text != "" {
+		sort.SliceStable(results, func(i, j int) bool { return results[i].score > results[j].score })
+	}
+	m.matches = make([]paletteEntry, 0, len(results))
+	for _, result := range results {
+		m.matches = append(m.matches, result.entry)
+	}
+	m.selected = 0
+}
+
+// update edits the query and moves the selection. It returns the entry to run once
+// enter is pressed, and done when the palette should close.
+func (m paletteModel) update(msg tea.KeyMsg) (paletteModel, *paletteEntry, bool) {
+	switch msg.String() {
+	case "esc":
+		return m, nil, true
+	case "enter":
+		if m.selected < len(m.matches) {
+			entry := m.matches[m.selected]
+			return m, &entry, true
+		}
+		return m, nil, true
+	case "up", "ctrl+p", "ctrl+k":
+		m.selected = max(0, m.selected-1)
+	case "down", "ctrl+n", "ctrl+j":
+		m.selected = min(max(0, len(m.matches)-1), m.selected+1)
+	default:
+		if m.query.update(msg) {
+			m.filter()
+		}
+	}
+	return m, nil, false
+}
+
+func (m paletteModel) View() string {
+	overlayWidth := int(float64(m.width) * 0.6)
+	if overlayWidth < 50 {
+		overlayWidth = 50
+	}
+	// Query + separator + results + spacer + footer, plus borders
+	overlayHeight := paletteResults + 4 + 2
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, m.getContentLine)
+}
+
+func (m paletteModel) getContentLine(lineIndex int, maxWidth int) string {
+	// Keep the selection on screen
+	offset := max(0, m.selected-paletteResults+1)
+	switch {
+	case lineIndex == 0:
+		return " > " + m.query.View()
+	case lineIndex == 1:
+		return " " + strings.Repeat("─", maxWidth-2)
+	case lineIndex-2 < paletteResults:
+		i := offset + lineIndex - 2
+		if i >= len(m.matches) {
+			if i == 0 {
+				return "  No matching commands"
+			}
+			return ""
+		}
+		entry := m.matches[i]
+		label := entry.label
+		keys := ""
+		if entry.keys != "" {
+			keys = "  " + entry.keys
+		}
+		label = layout.Fit(label, max(maxWidth-4-layout.Width(keys), 1), "...")
+		if i == m.selected {
+			return " > " + selectedItemStyle.Render(label) + playlistStatsStyle.Render(keys)
+		}
+		return "   " + label + playlistStatsStyle.Render(keys)
+	case lineIndex == paletteResults+3:
+		return " " + locale.T("hint.palette")
+	}
+	return ""
+}
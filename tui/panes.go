@@ -0,0 +1,39 @@
+package tui
+
+// paneDirection is a way Ctrl+W moves the focus
+type paneDirection int
+
+const (
+	paneLeft paneDirection = iota
+	paneDown
+	paneUp
+	paneRight
+)
+
+// paneGraph lays out the panes that take the focus as they are drawn: the search box
+// above the playlists in the sidebar and the song list to the right of both. Overlays
+// like the queue and the lyrics take every key while they're open; a pane that joins
+// the layout gets its neighbours here.
+var paneGraph = map[focusArea]map[paneDirection]focusArea{
+	focusSearch:    {paneDown: focusPlaylists, paneRight: focusMain},
+	focusPlaylists: {paneUp: focusSearch, paneRight: focusMain},
+	focusMain:      {paneLeft: focusPlaylists},
+}
+
+// paneDirections are the directions of the Ctrl+W actions that move the focus
+var paneDirections = map[keyAction]paneDirection{
+	actionPaneLeft:  paneLeft,
+	actionPaneDown:  paneDown,
+	actionPaneUp:    paneUp,
+	actionPaneRight: paneRight,
+}
+
+// moveFocus focuses the pane next to the focused one in direction, if there is one
+func (m *Model) moveFocus(direction paneDirection) {
+	next, ok := paneGraph[m.currentFocus][direction]
+	if !ok {
+		return
+	}
+	m.currentFocus = next
+	m.updateFocus()
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/locale"
+)
+
+// permissionModel explains how to let amtui control Music after macOS refused it
+type permissionModel struct {
+	width, height int
+	dismissed     bool // Hidden with Esc for the rest of the session
+}
+
+// permissionSteps is the walkthrough shown on the permission screen
+var permissionSteps = []string{
+	"macOS didn't allow amtui to control Music (error -1743).",
+	"",
+	"1. Open System Settings › Privacy & Security › Automation",
+	"2. Find your terminal app (Terminal, iTerm, Ghostty…)",
+	"3. Turn on \"Music\" underneath it",
+	"4. Come back here and press r to retry",
+	"",
+	"If your terminal isn't listed, run in a shell:",
+	"   tccutil reset AppleEvents",
+	"then restart amtui and accept the prompt.",
+}
+
+func (m permissionModel) View() string {
+	overlayWidth := int(float64(m.width) * 0.7)
+	if overlayWidth < 60 {
+		overlayWidth = 60
+	}
+	// Title + separator + steps + spacer + footer, plus borders
+	overlayHeight := len(permissionSteps) + 4 + 2
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, m.getContentLine)
+}
+
+func (m permissionModel) getContentLine(lineIndex int, maxWidth int) string {
+	switch {
+	case lineIndex == 0:
+		return " " + warningStyle.Render("Permission needed to control Music")
+	case lineIndex == 1:
+		return " " + strings.Repeat("─", maxWidth-2)
+	case lineIndex-2 < len(permissionSteps):
+		return "  " + permissionSteps[lineIndex-2]
+	case lineIndex == len(permissionSteps)+3:
+		return " " + locale.T("hint.permission")
+	}
+	return ""
+}
+
+// checkPermission shows the permission screen if err says macOS refused Automation
+func (m *Model) checkPermission(err error) {
+	if errors.Is(err, daemon.ErrAutomationDenied) && !m.permission.dismissed {
+		m.permissionVisible = true
+	}
+}
+
+// updatePermission handles keys while the permission screen is shown
+func (m *Model) updatePermission(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "o":
+		return attempt("Error opening System Settings", daemon.OpenAutomationSettings)
+	case "r":
+		// Hide the screen and load the library again; it comes back if still denied
+		m.permissionVisible = false
+		return tea.Batch(fetchPlaylists, fetchAllPlaylists())
+	case "esc":
+		m.permissionVisible = false
+		m.permission.dismissed = true
+	case "q", "ctrl+c":
+		return tea.Quit
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/layout"
+)
+
+// pickerResults is how many results a picker shows at once
+const pickerResults = 10
+
+// picker is the query line and the result list shared by the playlist picker and the
+// queue's add prompt
+type picker struct {
+	query    lineInput
+	selected int
+}
+
+// move moves the selection among count results, reporting whether msg was a movement key
+func (p *picker) move(msg tea.KeyMsg, count int) bool {
+	switch msg.String() {
+	case "up", "ctrl+p", "ctrl+k":
+		p.selected = max(0, p.selected-1)
+	case "down", "ctrl+n", "ctrl+j":
+		p.selected = min(max(0, count-1), p.selected+1)
+	default:
+		return false
+	}
+	return true
+}
+
+// prompt renders the query line, after label when there is one
+func (p picker) prompt(label string) string {
+	if label == "" {
+		return " > " + p.query.View()
+	}
+	return " " + label + " > " + p.query.View()
+}
+
+// row renders the result shown on row, counting from the top of the list, keeping the
+// selection on screen. ok is false below the last result.
+func (p picker) row(row int, results []string, maxWidth int) (line string, ok bool) {
+	i := max(0, p.selected-pickerResults+1) + row
+	if i >= len(results) {
+		return "", false
+	}
+	text := layout.Truncate(results[i], max(maxWidth-4, 1), "...")
+	if i == p.selected {
+		return " > " + selectedItemStyle.Render(text), true
+	}
+	return "   " + text, true
+}
@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/config"
+	"main/daemon"
+	"main/layout"
+	"main/locale"
+)
+
+// maxPlayHistory is how many played tracks the History view keeps
+const maxPlayHistory = 200
+
+// playedTrack is a track that started playing, and when
+type playedTrack struct {
+	Track daemon.Track `json:"track"`
+	At    time.Time    `json:"at"`
+}
+
+// playHistoryPath is where the played tracks are kept between runs
+func playHistoryPath() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "play_history.json"), nil
+}
+
+// loadPlayHistory reads the played tracks, newest first. A missing or unreadable file
+// yields an empty history.
+func loadPlayHistory() []playedTrack {
+	path, err := playHistoryPath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var played []playedTrack
+	if err := json.Unmarshal(data, &played); err != nil {
+		return nil
+	}
+	return played
+}
+
+// savePlayHistory writes the played tracks to disk
+func savePlayHistory(played []playedTrack) error {
+	path, err := playHistoryPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(played, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode play history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write play history: %w", err)
+	}
+	return nil
+}
+
+// historyModel is the History view, the tracks played lately
+type historyModel struct {
+	width, height int
+	played        []playedTrack // Newest first
+	selected      int
+	offset        int // First row shown
+}
+
+// add records track as played at, unless it's the one played last
+func (m *historyModel) add(track daemon.Track, at time.Time) {
+	if len(m.played) > 0 && m.played[0].Track.Id == track.Id && track.Id != "" {
+		return
+	}
+	m.played = append([]playedTrack{{Track: track, At: at}}, m.played...)
+	if len(m.played) > maxPlayHistory {
+		m.played = m.played[:maxPlayHistory]
+	}
+	// Keep the same track selected while the list grows above it
+	if m.selected > 0 {
+		m.selected++
+	}
+}
+
+// recordPlayed adds the track that just started to the history and saves it
+func (m *Model) recordPlayed(track daemon.Track) tea.Cmd {
+	m.history.add(track, time.Now())
+	if m.safeMode {
+		return nil
+	}
+	if err := savePlayHistory(m.history.played); err != nil {
+		return m.toast(notifyError("Error saving play history: %v", err))
+	}
+	return nil
+}
+
+// openHistory shows the History view with the newest track selected
+func (m *Model) openHistory() {
+	m.historyVisible = true
+	m.history.selected, m.history.offset = 0, 0
+}
+
+// rows is how many tracks fit in the view
+func (m historyModel) rows() int {
+	// Borders, title, separator, spacer and footer
+	return max(1, m.height-2-4)
+}
+
+// updateHistory handles keys while the History view is shown
+func (m *Model) updateHistory(msg tea.KeyMsg) tea.Cmd {
+	h := &m.history
+	switch msg.String() {
+	case "up", "k":
+		h.selected = max(0, h.selected-1)
+	case "down", "j":
+		h.selected = min(max(0, len(h.played)-1), h.selected+1)
+	case "enter":
+		if h.selected >= len(h.played) {
+			return nil
+		}
+		track := h.played[h.selected].Track
+		if track.Id == "" {
+			return m.toast(notifyError("Can't play '%s' by %s, Music gave no ID for it", track.Name, track.Artist))
+		}
+		d := daemon.Daemon{}
+		return attempt("Error playing song by ID", func() error { return d.PlaySongById(track.Id) })
+	case "esc", "q":
+		m.historyVisible = false
+	case "ctrl+c":
+		return tea.Quit
+	}
+	// Scroll the selection into view
+	rows := h.rows()
+	h.offset = min(h.offset, h.selected)
+	h.offset = max(h.offset, h.selected-rows+1)
+	return nil
+}
+
+func (m historyModel) View() string {
+	overlayWidth := max(60, int(float64(m.width)*0.7))
+	rows := m.rows()
+	return renderOverlay(m.width, m.height, overlayWidth, m.height, func(lineIndex, maxWidth int) string {
+		switch {
+		case lineIndex == 0:
+			return " " + locale.T("history.title")
+		case lineIndex == 1:
+			return " " + strings.Repeat("─", maxWidth-2)
+		case lineIndex == rows+3:
+			return " " + locale.T("hint.history")
+		case len(m.played) == 0 && lineIndex == 2:
+			return "  " + locale.T("history.empty")
+		}
+		i := m.offset + lineIndex - 2
+		if lineIndex < 2 || lineIndex >= rows+2 || i >= len(m.played) {
+			return ""
+		}
+		played := m.played[i]
+		when := played.At.Format("15:04")
+		if !sameDay(played.At, time.Now()) {
+			when = played.At.Format("Jan 2")
+		}
+		line := fmt.Sprintf("%-6s %s – %s", when, played.Track.Name, played.Track.Artist)
+		line = layout.Fit(line, maxWidth-4, "…")
+		if i == m.selected {
+			return "  " + selectedSongStyle.Render(line)
+		}
+		return "  " + line
+	})
+}
+
+// sameDay reports whether a and b fall on the same calendar day
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+)
+
+// stateGlyph is the icon for a player state, "" when it's unknown
+func stateGlyph(state string) string {
+	switch state {
+	case "playing":
+		return "▶"
+	case "paused":
+		return "⏸"
+	case "stopped":
+		return "■"
+	}
+	return ""
+}
+
+// shuffleGlyph shows shuffle as "⇄" with what is shuffled, dimmed when it's off
+func shuffleGlyph(status daemon.PlaybackStatus) string {
+	if !status.Shuffle {
+		return playbackOffStyle.Render("⇄")
+	}
+	return "⇄ " + status.ShuffleMode.Label()
+}
+
+// repeatGlyph shows repeat as "↻" for all and "↻¹" for one, dimmed when it's off
+func repeatGlyph(mode string) string {
+	switch mode {
+	case "all":
+		return "↻"
+	case "one":
+		return "↻¹"
+	case "", "off":
+		return playbackOffStyle.Render("↻")
+	}
+	return "↻ " + mode
+}
+
+// infoLine is the player state, shuffle, repeat and volume as compact icons, centered,
+// e.g. "▶ • ⇄ Songs • ↻ • Vol ████░░░░░░ 40%"
+func (m playbackModel) infoLine() string {
+	var items []string
+	if glyph := stateGlyph(m.status.PlayerState); glyph != "" {
+		items = append(items, glyph)
+	}
+	items = append(items, shuffleGlyph(m.status), repeatGlyph(m.status.RepeatMode), volumeWidget(m.status.Volume))
+
+	// Center the status info
+	return layout.Center(layout.Truncate(strings.Join(items, " • "), m.width, ""), m.width)
+}
+
+// timeInfo is the position in the track as elapsed and total time, or as the time left
+// once "t" switched to it
+func (m playbackModel) timeInfo() string {
+	if m.remaining {
+		return "-" + formatDuration(int(m.status.Duration)-int(m.position()))
+	}
+	return fmt.Sprintf("%s/%s", formatDuration(int(m.position())), formatDuration(int(m.status.Duration)))
+}
+
+// toggleRemainingTime switches the playback bar between elapsed and remaining time
+func (m *Model) toggleRemainingTime() {
+	m.boxer.EditLeaf("playback", func(model tea.Model) (tea.Model, error) {
+		pb := model.(playbackModel)
+		pb.remaining = !pb.remaining
+		return pb, nil
+	})
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+)
+
+// isPlaying reports whether track is the one Music is playing, by persistent ID
+func (m mainContentModel) isPlaying(track daemon.Track) bool {
+	return m.playing != "" && track.Id == m.playing
+}
+
+// playingRow flags the row of the playing track with "▶" where the selection and
+// visual marks leave room for it
+func playingRow(row string) string {
+	if !strings.HasPrefix(row, " ") {
+		return row
+	}
+	return "▶" + row[1:]
+}
+
+// setPlaying tells the song list the persistent ID of the playing track, empty when
+// none is playing
+func (m *Model) setPlaying(persistentId string) {
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		main.playing = persistentId
+		return main, nil
+	})
+}
+
+// centerOnPlaying selects the playing track in the open playlist and scrolls it to the
+// middle of the song list
+func (m *Model) centerOnPlaying() tea.Cmd {
+	playlist, ok := m.playlistCache[m.selectedPlaylist]
+	if m.selectedPlaylist == "" || !ok {
+		return nil
+	}
+	found, searching := false, false
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		if searching = main.showsResults(); searching {
+			return main, nil
+		}
+		order := main.rowOrder(playlist.Tracks)
+		for i, track := range playlist.Tracks {
+			if !main.isPlaying(track) {
+				continue
+			}
+			row := i
+			if order != nil {
+				if row = slices.Index(order, i); row < 0 {
+					continue // Filtered out
+				}
+			}
+			visible := max(main.height-3, 1) // Title, header and separator
+			main.selectedSong = row
+			main.scrollOffset = max(min(row-visible/2, main.rowCount(playlist.Tracks)-visible), 0)
+			found = true
+			break
+		}
+		return main, nil
+	})
+	if searching {
+		return nil
+	}
+	if !found {
+		return m.toast(notify("The playing track isn't in %s", m.selectedPlaylist))
+	}
+	m.currentFocus = focusMain
+	m.updateFocus()
+	return nil
+}
@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+	"main/locale"
+)
+
+// playlistPickerModel asks which playlist to add songs to, narrowed by typing
+type playlistPickerModel struct {
+	width, height int
+	title         string
+	playlists     []string // Playlists songs can be added to, in sidebar order
+	picker
+	matches []string
+	onPick  func(playlist string) tea.Cmd
+}
+
+func newPlaylistPicker(title string, playlists []string, onPick func(string) tea.Cmd) playlistPickerModel {
+	m := playlistPickerModel{title: title, playlists: playlists, onPick: onPick}
+	m.filter()
+	return m
+}
+
+// filter ranks the playlists against the query, best match first
+func (m *playlistPickerModel) filter() {
+	type scored struct {
+		name  string
+		score int
+	}
+	var results []scored
+	for _, name := range m.playlists {
+		if score, ok := daemon.FuzzyScore(m.query.text, name); ok {
+			results = append(results, scored{name, score})
+		}
+	}
+	if m.query.text != "" {
+		sort.SliceStable(results, func(i, j int) bool { return results[i].score > results[j].score })
+	}
+	m.matches = make([]string, 0, len(results))
+	for _, result := range results {
+		m.matches = append(m.matches, result.name)
+	}
+	m.selected = 0
+}
+
+// update edits the query and moves the selection. It returns the command for the
+// playlist picked with Enter, and done when the picker should close.
+func (m playlistPickerModel) update(msg tea.KeyMsg) (playlistPickerModel, tea.Cmd, bool) {
+	switch msg.String() {
+	case "esc":
+		return m, nil, true
+	case "enter":
+		if m.selected < len(m.matches) {
+			return m, m.onPick(m.matches[m.selected]), true
+		}
+		return m, nil, true
+	default:
+		if m.move(msg, len(m.matches)) {
+			break
+		}
+		if m.query.update(msg) {
+			m.filter()
+		}
+	}
+	return m, nil, false
+}
+
+func (m playlistPickerModel) View() string {
+	overlayWidth := int(float64(m.width) * 0.5)
+	if overlayWidth < 50 {
+		overlayWidth = 50
+	}
+	// Title + query + separator + results + spacer + footer, plus borders
+	overlayHeight := pickerResults + 5 + 2
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, m.getContentLine)
+}
+
+func (m playlistPickerModel) getContentLine(lineIndex int, maxWidth int) string {
+	switch {
+	case lineIndex == 0:
+		return " " + titleStyle.Render(layout.Truncate(m.title, max(maxWidth-2, 1), "..."))
+	case lineIndex == 1:
+		return m.prompt("")
+	case lineIndex == 2:
+		return " " + strings.Repeat("─", maxWidth-2)
+	case lineIndex-3 < pickerResults:
+		if line, ok := m.row(lineIndex-3, m.matches, maxWidth); ok {
+			return line
+		}
+		if lineIndex == 3 {
+			return "  No matching playlists"
+		}
+	case lineIndex == pickerResults+4:
+		return " " + locale.T("hint.playlist_picker")
+	}
+	return ""
+}
+
+// editablePlaylists lists the sidebar's playlists that songs can be added to, leaving
+// out smart playlists, which Music fills itself
+func (m *Model) editablePlaylists() []string {
+	var names []string
+	m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+		for _, name := range model.(playlistsModel).playlistItems {
+			if playlist, ok := m.playlistCache[name]; !ok || !playlist.Smart {
+				names = append(names, name)
+			}
+		}
+		return model, nil
+	})
+	return names
+}
+
+// openPlaylistPicker asks which playlist to add songs to, titled title, and runs the
+// command onPick returns for the one picked
+func (m *Model) openPlaylistPicker(title string, onPick func(playlist string) tea.Cmd) {
+	m.playlistPicker = newPlaylistPicker(title, m.editablePlaylists(), onPick)
+	m.playlistPickerVisible = true
+}
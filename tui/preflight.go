@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+	"main/locale"
+)
+
+// preflightStatus is how far a startup check got
+type preflightStatus int
+
+const (
+	preflightPending preflightStatus = iota
+	preflightRunning
+	preflightPassed
+	preflightFailed
+	preflightSkipped // An earlier check failed, so this one wasn't run
+)
+
+// preflightResult is what a startup check found
+type preflightResult struct {
+	status preflightStatus
+	detail string
+	err    error
+}
+
+// preflightModel is the checklist shown at startup while amtui makes sure it can talk
+// to Music. It stays up with a hint when a check fails, rather than leaving the panes
+// to show raw errors.
+type preflightModel struct {
+	width, height int
+	checks        []daemon.PreflightCheck
+	results       []preflightResult
+}
+
+// Message carrying the result of one startup check
+type preflightResultMsg struct {
+	index  int
+	detail string
+	err    error
+}
+
+// runPreflightCheck runs the startup check at index
+func runPreflightCheck(check daemon.PreflightCheck, index int) tea.Cmd {
+	return func() tea.Msg {
+		detail, err := check.Run()
+		return preflightResultMsg{index: index, detail: detail, err: err}
+	}
+}
+
+// failed is the index of the check that failed, or -1
+func (m preflightModel) failed() int {
+	for i, result := range m.results {
+		if result.status == preflightFailed {
+			return i
+		}
+	}
+	return -1
+}
+
+// newPreflightModel sets up the startup checks, the first one running
+func newPreflightModel() preflightModel {
+	d := daemon.Daemon{}
+	checks := d.PreflightChecks(musicLaunchTimeout)
+	results := make([]preflightResult, len(checks))
+	results[0].status = preflightRunning
+	return preflightModel{checks: checks, results: results}
+}
+
+// start runs the first startup check
+func (m preflightModel) start() tea.Cmd {
+	return runPreflightCheck(m.checks[0], 0)
+}
+
+// restartPreflight runs the startup checks again from the first one
+func (m *Model) restartPreflight() tea.Cmd {
+	m.preflight = newPreflightModel()
+	return m.preflight.start()
+}
+
+// handlePreflightResult records a check's result and runs the next one. Once all have
+// passed the checklist goes away and the library loads.
+func (m *Model) handlePreflightResult(msg preflightResultMsg) tea.Cmd {
+	results := m.preflight.results
+	if msg.index >= len(results) || results[msg.index].status != preflightRunning {
+		return nil
+	}
+	if msg.err != nil {
+		results[msg.index] = preflightResult{status: preflightFailed, detail: msg.err.Error(), err: msg.err}
+		for i := msg.index + 1; i < len(results); i++ {
+			results[i].status = preflightSkipped
+		}
+		return nil
+	}
+	results[msg.index] = preflightResult{status: preflightPassed, detail: msg.detail}
+	if next := msg.index + 1; next < len(results) {
+		results[next].status = preflightRunning
+		return runPreflightCheck(m.preflight.checks[next], next)
+	}
+	m.preflightVisible = false
+	return func() tea.Msg { return musicReadyMsg{} }
+}
+
+// updatePreflight handles keys while the startup checklist is shown
+func (m *Model) updatePreflight(msg tea.KeyMsg) tea.Cmd {
+	failed := m.preflight.failed()
+	switch msg.String() {
+	case "r":
+		if failed >= 0 {
+			return m.restartPreflight()
+		}
+	case "o":
+		if failed >= 0 {
+			return attempt("Error opening System Settings", daemon.OpenAutomationSettings)
+		}
+	case "c":
+		if failed >= 0 {
+			// Carry on with whatever works; the permission walkthrough would only repeat
+			// the checklist
+			m.preflightVisible = false
+			result := m.preflight.results[failed]
+			if errors.Is(result.err, daemon.ErrAutomationDenied) {
+				m.permission.dismissed = true
+			}
+			err := fmt.Errorf("%s check failed: %w", m.preflight.checks[failed].Name, result.err)
+			return func() tea.Msg { return musicReadyMsg{err: err} }
+		}
+	case "q", "ctrl+c":
+		return tea.Quit
+	}
+	return nil
+}
+
+// lines renders the checklist wrapped to width
+func (m preflightModel) lines(width int) []string {
+	var lines []string
+	for i, check := range m.checks {
+		result := m.results[i]
+		mark := map[preflightStatus]string{
+			preflightPending: "·",
+			preflightRunning: "…",
+			preflightPassed:  "✓",
+			preflightFailed:  "✗",
+			preflightSkipped: "-",
+		}[result.status]
+		line := mark + " " + check.Name
+		if result.detail != "" && result.status == preflightPassed {
+			line += "  " + statusMutedStyle.Render(result.detail)
+		}
+		if result.status == preflightFailed {
+			line = warningStyle.Render(line)
+		}
+		lines = append(lines, line)
+		if result.status != preflightFailed {
+			continue
+		}
+		for _, wrapped := range layout.Wrap(result.detail, width-4) {
+			lines = append(lines, "    "+statusMutedStyle.Render(wrapped))
+		}
+		for _, wrapped := range layout.Wrap("→ "+check.Hint, width-4) {
+			lines = append(lines, "    "+wrapped)
+		}
+	}
+	return lines
+}
+
+func (m preflightModel) View() string {
+	overlayWidth := min(max(int(float64(m.width)*0.6), 60), m.width)
+	// Title + separator + lines + spacer + footer, plus borders
+	lines := m.lines(overlayWidth - 4)
+	overlayHeight := len(lines) + 4 + 2
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, func(lineIndex, maxWidth int) string {
+		switch {
+		case lineIndex == 0:
+			return " " + locale.T("preflight.title")
+		case lineIndex == 1:
+			return " " + strings.Repeat("─", maxWidth-2)
+		case lineIndex-2 < len(lines):
+			return "  " + lines[lineIndex-2]
+		case lineIndex == len(lines)+3:
+			if m.failed() >= 0 {
+				return " " + locale.T("hint.preflight")
+			}
+			return " " + locale.T("hint.preflight_wait")
+		}
+		return ""
+	})
+}
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// progressFrameInterval is how often the progress bar is redrawn while a track plays.
+// Frames only redraw; the position comes from the last poll, moved on by the time since.
+const progressFrameInterval = 250 * time.Millisecond
+
+// maxExtrapolation is how far past the last poll the position is carried, so the bar
+// stops rather than runs ahead when polls stop coming
+const maxExtrapolation = 5 * time.Second
+
+// Message redrawing the progress bar between polls
+type progressFrameMsg struct{}
+
+// position is where the playing track is now: the position last read from Music, moved
+// on by the time since while it plays. lastUpdate carries Go's monotonic clock, so
+// changes to the wall clock don't move the bar.
+func (m playbackModel) position() float64 {
+	position := m.status.Position
+	if m.status.PlayerState == "playing" && !m.lastUpdate.IsZero() {
+		position += min(time.Since(m.lastUpdate), maxExtrapolation).Seconds()
+	}
+	if m.status.Duration > 0 {
+		position = min(position, m.status.Duration)
+	}
+	return max(position, 0)
+}
+
+// startFrames keeps the progress bar moving while a track plays, unless frames are
+// already coming
+func (m *playbackModel) startFrames() tea.Cmd {
+	if m.framing || m.status.PlayerState != "playing" {
+		return nil
+	}
+	m.framing = true
+	return nextFrame()
+}
+
+// nextFrame waits for the next redraw of the progress bar
+func nextFrame() tea.Cmd {
+	return tea.Tick(progressFrameInterval, func(time.Time) tea.Msg { return progressFrameMsg{} })
+}
+
+// progressBubble draws the progress bars, set up for the theme by applyTheme
+var progressBubble progress.Model
+
+// newProgressBubble makes the progress bar for t, filled with a gradient from
+// ProgressStart to ProgressEnd. The gradient is blended from hex colors, so the bar is
+// filled with ProgressStart alone when either is an ANSI number.
+func newProgressBubble(t theme) progress.Model {
+	fill := progress.WithGradient(string(t.ProgressStart), string(t.ProgressEnd))
+	if !hexColor.MatchString(string(t.ProgressStart)) || !hexColor.MatchString(string(t.ProgressEnd)) {
+		fill = progress.WithSolidFill(string(t.ProgressStart))
+	}
+	bar := progress.New(fill, progress.WithoutPercentage(), progress.WithColorProfile(colorProfile))
+	bar.EmptyColor = string(t.Muted)
+	return bar
+}
+
+// progressBar draws fraction (0-1) of a bar width cells wide
+func progressBar(fraction float64, width int) string {
+	bar := progressBubble
+	bar.Width = max(width, 0)
+	return bar.ViewAs(min(1, max(0, fraction)))
+}
@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+	"main/locale"
+)
+
+// queueHeaderLines are the rows of the queue overlay above its track list
+const queueHeaderLines = 7
+
+// queueItem is an upcoming track in the queue overlay
+type queueItem struct {
+	index int // Position in the queue, 0-based
+	track daemon.Track
+}
+
+func (i queueItem) FilterValue() string {
+	return i.track.Name + " " + i.track.Artist + " " + i.track.Album
+}
+
+// queueDelegate draws a track a row, numbered by its position in the queue
+type queueDelegate struct{}
+
+func (d queueDelegate) Height() int                         { return 1 }
+func (d queueDelegate) Spacing() int                        { return 0 }
+func (d queueDelegate) Update(tea.Msg, *list.Model) tea.Cmd { return nil }
+
+func (d queueDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	queued, ok := item.(queueItem)
+	if !ok {
+		return
+	}
+	prefix := "   "
+	if index == m.Index() {
+		prefix = " > "
+	}
+	line := fmt.Sprintf("%s%d. %s - %s", prefix, queued.index+1, queued.track.Name, queued.track.Artist)
+	fmt.Fprint(w, layout.Truncate(line, m.Width(), "..."))
+}
+
+// newQueueList makes the track list of the queue overlay. Our own keys move it, it
+// only sees keys while a filter is typed.
+func newQueueList() list.Model {
+	l := list.New(nil, queueDelegate{}, 0, 0)
+	l.SetShowTitle(false)
+	l.SetShowHelp(false)
+	l.SetStatusBarItemName("track", "tracks")
+	l.DisableQuitKeybindings()
+	l.FilterInput.Prompt = locale.T("queue.filter")
+	l.FilterInput.Cursor.SetMode(cursor.CursorStatic)
+	l.Styles.TitleBar = l.Styles.TitleBar.Padding(0, 0, 0, 1)
+	l.Styles.StatusBar = l.Styles.StatusBar.Padding(0, 0, 0, 1)
+	l.Styles.PaginationStyle = l.Styles.PaginationStyle.PaddingLeft(1)
+	l.Styles.NoItems = l.Styles.NoItems.PaddingLeft(1)
+	return l
+}
+
+// queueOverlaySize is the size of the queue overlay on a width x height screen
+func queueOverlaySize(width, height int) (int, int) {
+	// 80% of the screen, but at least 40x10 when it fits
+	overlayWidth := min(max(int(float64(width)*0.8), 40), width)
+	overlayHeight := min(max(int(float64(height)*0.8), 10), height)
+	return overlayWidth, overlayHeight
+}
+
+// resize fits the overlay and its track list to a width x height screen
+func (m *queueModel) resize(width, height int) {
+	m.width, m.height = width, height
+	overlayWidth, overlayHeight := queueOverlaySize(width, height)
+	// Inside the borders, below the header
+	m.list.SetSize(max(overlayWidth-2, 1), max(overlayHeight-2-queueHeaderLines, 1))
+}
+
+// setQueue lists the upcoming tracks of info, keeping the same queue position selected.
+// A list that was empty starts on the first upcoming track.
+func (m *queueModel) setQueue(info *daemon.QueueInfo) tea.Cmd {
+	selected := m.selected()
+	m.queueInfo = info
+	var items []list.Item
+	if info != nil {
+		if len(m.list.Items()) == 0 {
+			selected = max(info.CurrentPosition, 0)
+		}
+		// CurrentPosition is 1-based, so it's the index of the first upcoming track
+		for i := max(info.CurrentPosition, 0); i < len(info.Tracks); i++ {
+			items = append(items, queueItem{index: i, track: info.Tracks[i]})
+		}
+	}
+	cmd := m.list.SetItems(items)
+	m.fitPages()
+	m.selectIndex(selected)
+	return cmd
+}
+
+// fitPages sizes the pages of the list again. It sizes them before working out whether
+// the page dots are shown, so a change in the number of tracks can leave a page a row
+// too long.
+func (m *queueModel) fitPages() {
+	m.list.SetHeight(m.list.Height())
+}
+
+// selected is the queue position (0-based) of the selected track, or -1
+func (m queueModel) selected() int {
+	if item, ok := m.list.SelectedItem().(queueItem); ok {
+		return item.index
+	}
+	return -1
+}
+
+// selectIndex selects the track at queue position index, or the next one shown after it
+func (m *queueModel) selectIndex(index int) {
+	items := m.list.VisibleItems()
+	for i, item := range items {
+		if item.(queueItem).index >= index {
+			m.list.Select(i)
+			return
+		}
+	}
+	if len(items) > 0 {
+		m.list.Select(len(items) - 1)
+	}
+}
+
+// updateList passes msg to the track list, for the keys of a filter being typed and
+// the matches it finds
+func (m *queueModel) updateList(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	m.fitPages()
+	return cmd
+}
+
+// reset empties the list and drops its filter, for the next time the queue opens
+func (m *queueModel) reset() {
+	m.list.ResetFilter()
+	m.list.SetItems(nil)
+}
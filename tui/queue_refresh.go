@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// queueRefreshInterval is how often the open queue overlay refetches the queue
+const queueRefreshInterval = 5 * time.Second
+
+// Message sent when the open queue overlay is due for a refresh
+type queueRefreshMsg struct {
+	seq int
+}
+
+// scheduleQueueRefresh waits for the next refresh of the overlay opened as seq
+func scheduleQueueRefresh(seq int) tea.Cmd {
+	return tea.Tick(queueRefreshInterval, func(time.Time) tea.Msg { return queueRefreshMsg{seq: seq} })
+}
+
+// startQueueRefresh keeps the overlay being opened up to date. Each opening numbers its
+// refreshes, so those left from an earlier one stop.
+func (m *Model) startQueueRefresh() tea.Cmd {
+	m.queueRefreshSeq++
+	return scheduleQueueRefresh(m.queueRefreshSeq)
+}
+
+// refreshQueue refetches the queue behind the open overlay, leaving what it shows in
+// place until the new queue arrives
+func (m *Model) refreshQueue() tea.Cmd {
+	if !m.queueVisible || m.queueOverlay.loading || m.queueOverlay.refreshing {
+		return nil
+	}
+	m.queueOverlay.refreshing = true
+	return fetchQueueInfo()
+}
+
+// handleQueueRefresh refreshes the overlay when msg comes from its current opening
+func (m *Model) handleQueueRefresh(msg queueRefreshMsg) tea.Cmd {
+	if !m.queueVisible || msg.seq != m.queueRefreshSeq {
+		return nil
+	}
+	return tea.Batch(m.refreshQueue(), scheduleQueueRefresh(msg.seq))
+}
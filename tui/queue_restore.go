@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/config"
+	"main/daemon"
+	"main/locale"
+)
+
+// queueSnapshotPath is where the amtui Queue is saved between runs
+func queueSnapshotPath() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "queue.json"), nil
+}
+
+// Message carrying a queue saved by a previous run
+type queueSnapshotMsg struct {
+	snapshot daemon.QueueSnapshot
+	ok       bool
+	err      error
+}
+
+// Message sent once a saved queue has been restored
+type queueRestoredMsg struct {
+	err error
+}
+
+// loadQueueSnapshot looks for a queue saved when amtui last quit
+func loadQueueSnapshot() tea.Msg {
+	path, err := queueSnapshotPath()
+	if err != nil {
+		return queueSnapshotMsg{err: err}
+	}
+	snapshot, ok, err := daemon.LoadQueueSnapshot(path)
+	return queueSnapshotMsg{snapshot: snapshot, ok: ok, err: err}
+}
+
+// saveQueueSnapshot saves the amtui Queue so the next run can offer to resume it.
+// When Music isn't playing from the queue, any older snapshot is removed instead.
+func saveQueueSnapshot() error {
+	path, err := queueSnapshotPath()
+	if err != nil {
+		return err
+	}
+	d := daemon.Daemon{}
+	snapshot, ok, err := d.SnapshotQueue()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return discardQueueSnapshot()
+	}
+	return daemon.SaveQueueSnapshot(path, snapshot)
+}
+
+// discardQueueSnapshot removes the saved queue, if any
+func discardQueueSnapshot() error {
+	path, err := queueSnapshotPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove queue snapshot: %w", err)
+	}
+	return nil
+}
+
+// restoreQueue rebuilds the saved queue in Music and resumes playback
+func restoreQueue(snapshot daemon.QueueSnapshot) tea.Cmd {
+	return inFlight("Restoring queue", func() tea.Msg {
+		d := daemon.Daemon{}
+		if err := d.RestoreQueue(snapshot); err != nil {
+			return queueRestoredMsg{err: err}
+		}
+		return queueRestoredMsg{err: discardQueueSnapshot()}
+	})
+}
+
+// queueRestoreModel is the startup prompt offering to resume the previous queue
+type queueRestoreModel struct {
+	width, height int
+	snapshot      daemon.QueueSnapshot
+}
+
+func (m queueRestoreModel) View() string {
+	overlayWidth := int(float64(m.width) * 0.5)
+	if overlayWidth < 44 {
+		overlayWidth = 44
+	}
+	return renderOverlay(m.width, m.height, overlayWidth, 8, m.getContentLine)
+}
+
+func (m queueRestoreModel) getContentLine(lineIndex int, maxWidth int) string {
+	switch lineIndex {
+	case 0:
+		return " Resume your previous queue?"
+	case 1:
+		return " " + strings.Repeat("─", maxWidth-2)
+	case 2:
+		return fmt.Sprintf("  %d tracks, saved %s", len(m.snapshot.Tracks), m.snapshot.SavedAt.Format(time.DateTime))
+	case 3:
+		if current, ok := m.snapshot.Current(); ok {
+			return fmt.Sprintf("  at '%s' by %s", current.Name, current.Artist)
+		}
+	case 5:
+		return " " + locale.T("hint.queue_restore")
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+	"main/locale"
+)
+
+// Message carrying the results of a search made from the queue's add prompt
+type queueSearchResultsMsg struct {
+	query  string
+	tracks []daemon.Track
+	err    error
+}
+
+// fetchQueueSearchResults searches the library for the queue's add prompt
+func fetchQueueSearchResults(query string) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		tracks, err := d.SearchTracks(query)
+		return queueSearchResultsMsg{query: query, tracks: tracks, err: err}
+	}
+}
+
+// queueSearchModel is the prompt opened with "a" in the queue overlay. Enter searches the
+// library, then appends the selected result to the queue; the prompt stays open so
+// several tracks can be added in a row.
+type queueSearchModel struct {
+	width, height int
+	picker
+	searched string // Query the results are for
+	results  []daemon.Track
+	loading  bool
+	err      error
+	added    []string // Names of the tracks added so far
+}
+
+// update edits the query and picks results. It returns a command to search or add a
+// track, and done when the prompt should close.
+func (m queueSearchModel) update(msg tea.KeyMsg) (queueSearchModel, tea.Cmd, bool) {
+	switch msg.String() {
+	case "esc":
+		return m, nil, true
+	case "enter":
+		query := strings.TrimSpace(m.query.text)
+		if query != "" && query != m.searched {
+			m.loading = true
+			m.err = nil
+			return m, fetchQueueSearchResults(query), false
+		}
+		if m.selected < len(m.results) {
+			track := m.results[m.selected]
+			m.added = append(m.added, track.Name)
+			return m, addToQueue([]daemon.Track{track}), false
+		}
+	default:
+		if !m.move(msg, len(m.results)) {
+			m.query.update(msg)
+		}
+	}
+	return m, nil, false
+}
+
+// setResults shows the results of a search, unless the prompt moved on to another query
+func (m *queueSearchModel) setResults(msg queueSearchResultsMsg) {
+	if msg.query != strings.TrimSpace(m.query.text) {
+		return
+	}
+	m.loading = false
+	m.searched = msg.query
+	m.results = msg.tracks
+	m.err = msg.err
+	m.selected = 0
+}
+
+func (m queueSearchModel) View() string {
+	overlayWidth := int(float64(m.width) * 0.6)
+	if overlayWidth < 50 {
+		overlayWidth = 50
+	}
+	// Query + separator + results + status + spacer + footer, plus borders
+	overlayHeight := pickerResults + 5 + 2
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, m.getContentLine)
+}
+
+func (m queueSearchModel) getContentLine(lineIndex int, maxWidth int) string {
+	switch {
+	case lineIndex == 0:
+		return m.prompt("Add to queue")
+	case lineIndex == 1:
+		return " " + strings.Repeat("─", maxWidth-2)
+	case lineIndex-2 < pickerResults:
+		results := make([]string, len(m.results))
+		for i, track := range m.results {
+			results[i] = fmt.Sprintf("%s — %s · %s", track.Name, track.Artist, track.Album)
+		}
+		if line, ok := m.row(lineIndex-2, results, maxWidth); ok {
+			return line
+		}
+		if lineIndex == 2 {
+			switch {
+			case m.loading:
+				return "  Searching..."
+			case m.err != nil:
+				return "  " + warningStyle.Render(fmt.Sprintf("Error: %v", m.err))
+			case m.searched != "":
+				return "  No matching tracks"
+			}
+			return "  Type to search your library, then press Enter"
+		}
+	case lineIndex == pickerResults+2:
+		if len(m.added) > 0 {
+			return layout.Truncate(fmt.Sprintf("  Added %d: %s", len(m.added), strings.Join(m.added, ", ")), maxWidth, "...")
+		}
+	case lineIndex == pickerResults+4:
+		return " " + locale.T("hint.queue_search")
+	}
+	return ""
+}
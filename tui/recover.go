@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/layout"
+)
+
+// recoverView stands in for a pane whose View panicked, drawing an error box of width x
+// height in its place so the rest of the screen keeps working. It must be deferred
+// directly by View.
+func recoverView(pane string, width, height int, view *string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	slog.Error("panic rendering pane", "pane", pane, "panic", r, "stack", string(debug.Stack()))
+	*view = errorBox(fmt.Sprintf("⚠ %s pane failed to render: %v", pane, r), width, height)
+}
+
+// recoverUpdate keeps a pane as it was before an Update that panicked and reports the
+// panic in a toast. It must be deferred directly by Update.
+func recoverUpdate(pane string, before tea.Model, model *tea.Model, cmd *tea.Cmd) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	slog.Error("panic updating pane", "pane", pane, "panic", r, "stack", string(debug.Stack()))
+	*model = before
+	*cmd = func() tea.Msg { return notifyError("Error in the %s pane: %v", pane, r) }
+}
+
+// errorBox draws message in a bordered box of width x height, or on a line when there's
+// no room for the border
+func errorBox(message string, width, height int) string {
+	if height < 3 {
+		lines := make([]string, max(height, 1))
+		lines[0] = warningStyle.Render(layout.Fit(message, width, "..."))
+		return strings.Join(lines, "\n")
+	}
+	return renderOverlay(width, height, width, height, func(lineIndex, maxWidth int) string {
+		wrapped := layout.Wrap(message, maxWidth-2)
+		if lineIndex < len(wrapped) {
+			return " " + warningStyle.Render(wrapped[lineIndex])
+		}
+		return ""
+	})
+}
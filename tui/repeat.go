@@ -0,0 +1,86 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+)
+
+// songTarget is a song an action runs on, with the playlist it was picked from
+type songTarget struct {
+	track    daemon.Track
+	playlist string
+	index    int // Position of track in playlist, from 0
+}
+
+// repeatable is an action on a song that "." runs again on the song selected now
+type repeatable struct {
+	label string
+	run   func(m *Model, target songTarget) tea.Cmd
+}
+
+// Message recording the action "." repeats, for actions only settled after a picker
+type repeatableMsg struct {
+	action repeatable
+}
+
+// remember records action for "." once cmd has been started
+func remember(action repeatable, cmd tea.Cmd) tea.Cmd {
+	return tea.Batch(cmd, func() tea.Msg { return repeatableMsg{action: action} })
+}
+
+// selectedTarget is the song selected in the song list, with the playlist it's in
+// unless it's one of the songs found by a search or opened from a library tab
+func (m *Model) selectedTarget() (songTarget, bool) {
+	if m.currentFocus != focusMain {
+		return songTarget{}, false
+	}
+	var target songTarget
+	var ok bool
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		target.track, ok = main.trackAtRow(main.selectedSong)
+		if ok && !main.showsResults() {
+			target.playlist = main.currentPlaylist
+			target.index = main.trackIndex(main.selectedSong)
+		}
+		return main, nil
+	})
+	return target, ok
+}
+
+// rememberMenuAction records the context menu entry about to run so "." can run it on
+// another song, with the same "Play As" strategy. Add To Playlist is remembered once a
+// playlist has been picked.
+func (m *Model) rememberMenuAction() {
+	option, strategyIndex := m.contextMenu.option(), m.contextMenu.strategyIndex
+	if option < 0 || option == contextAddToPlaylist {
+		return
+	}
+	label := m.contextMenu.label(option)
+	m.lastAction = &repeatable{
+		label: label,
+		run: func(m *Model, target songTarget) tea.Cmd {
+			if playlistOnlyOptions[option] && target.playlist == "" {
+				return m.toast(notifyError("'%s' needs a song of a playlist", label))
+			}
+			m.contextMenu.targetSong = target.track
+			m.contextMenu.targetPlaylist = target.playlist
+			m.contextMenu.targetSongIndex = target.index
+			m.contextMenu.strategyIndex = strategyIndex
+			return m.runContextMenuOption(option)
+		},
+	}
+}
+
+// repeatLastAction runs the last repeatable action again on the selected song
+func (m *Model) repeatLastAction() tea.Cmd {
+	if m.lastAction == nil {
+		return m.toast(notifyError("Nothing to repeat"))
+	}
+	target, ok := m.selectedTarget()
+	if !ok {
+		return m.toast(notifyError("Select a song to repeat '%s' on", m.lastAction.label))
+	}
+	return m.lastAction.run(m, target)
+}
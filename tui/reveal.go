@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+)
+
+// actionTrack returns the song an action applies to: the song selected in the main pane
+// while it has focus, otherwise the playing track, reported by playing
+func (m *Model) actionTrack() (track daemon.Track, playing bool, ok bool) {
+	if m.currentFocus == focusMain {
+		if track, ok := m.highlightedTrack(); ok {
+			return track, false, true
+		}
+	}
+	m.boxer.EditLeaf("playback", func(model tea.Model) (tea.Model, error) {
+		track = model.(playbackModel).status.Track
+		return model, nil
+	})
+	return track, true, track.Name != ""
+}
+
+// revealInMusic shows the selected or playing song in Music.app, for what only the full
+// app can do
+func (m *Model) revealInMusic() tea.Cmd {
+	track, playing, ok := m.actionTrack()
+	if !ok {
+		return nil
+	}
+	d := daemon.Daemon{}
+	return attempt(fmt.Sprintf("Error showing '%s' in Music", track.Name), func() error {
+		if playing {
+			return d.RevealPlayingInMusic()
+		}
+		return d.RevealInMusic(track)
+	})
+}
+
+// openWebPage opens the Apple Music page of the selected or playing song in the browser
+func (m *Model) openWebPage() tea.Cmd {
+	track, _, ok := m.actionTrack()
+	if !ok {
+		return nil
+	}
+	return inFlight("Looking up web page", func() tea.Msg {
+		d := daemon.Daemon{}
+		link, err := d.CatalogURL(track)
+		if err == nil {
+			err = daemon.OpenURL(link)
+		}
+		if errors.Is(err, daemon.ErrNotInCatalog) {
+			return notifyError("'%s' isn't in the Apple Music catalog, it has no web page", track.Name)
+		} else if err != nil {
+			return notifyError("Error opening '%s' on the web: %v", track.Name, err)
+		}
+		return nil
+	})
+}
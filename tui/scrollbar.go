@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"strings"
+
+	"main/layout"
+)
+
+// scrollbar returns one glyph per visible row for a list of total rows scrolled down
+// offset rows with height of them showing: a thumb as tall as the share of the list in
+// view, placed as far down as the list is scrolled, on a thin track
+func scrollbar(height, total, offset int) []string {
+	bar := make([]string, height)
+	thumb := min(max(height*height/max(total, 1), 1), height)
+	top := 0
+	if total > height {
+		top = (offset*(height-thumb) + (total-height)/2) / (total - height)
+	}
+	top = min(max(top, 0), height-thumb)
+	for i := range bar {
+		if i >= top && i < top+thumb {
+			bar[i] = scrollbarThumbStyle.Render("┃")
+		} else {
+			bar[i] = scrollbarTrackStyle.Render("│")
+		}
+	}
+	return bar
+}
+
+// withScrollbar pads rows to width-1 columns and puts the scrollbar of a list of total
+// rows scrolled down offset rows in the last column. Rows are returned as they are
+// when they all fit.
+func withScrollbar(rows []string, width, total, offset int) []string {
+	if total <= len(rows) || len(rows) == 0 {
+		return rows
+	}
+	bar := scrollbar(len(rows), total, offset)
+	for i, row := range rows {
+		rows[i] = row + strings.Repeat(" ", max(width-1-layout.Width(row), 0)) + bar[i]
+	}
+	return rows
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+)
+
+const (
+	// seekStep is how far left/right move in the playing track
+	seekStep = 5 * time.Second
+	// seekJump is how far shift+left/right move
+	seekJump = 30 * time.Second
+)
+
+// progressLine renders the centered progress bar and "position/duration", along with
+// the column the bar starts at and its width, for mapping clicks to positions
+func (m playbackModel) progressLine() (line string, barStart, barWidth int) {
+	progress := 0.0
+	if m.status.Duration > 0 {
+		progress = m.position() / m.status.Duration
+	}
+	timeInfo := m.timeInfo()
+
+	// Most of the width goes to the bar, leaving room for the time
+	barWidth = min(int(float64(m.width)*0.8), m.width-layout.Width(timeInfo)-2)
+	if barWidth < 1 {
+		return timeInfo, 0, 0
+	}
+	line = progressBar(progress, barWidth) + " " + timeInfo
+	barStart = max(0, (m.width-layout.Width(line))/2)
+	return strings.Repeat(" ", barStart) + line, barStart, barWidth
+}
+
+// statusOffset is the column renderStatus starts at, to the right of the cover art
+func (m playbackModel) statusOffset() int {
+	artRows := m.height
+	artCols := artRows * 2
+	if len(m.artwork.lines(artworkSlotPlayback, artCols, artRows)) == 0 || m.width < artCols+30 {
+		return 0
+	}
+	return artCols + 1
+}
+
+// positionAt maps a click at col, row of the playback bar to a position in the track
+func (m playbackModel) positionAt(col, row int) (float64, bool) {
+	if row != 1 || m.status.Duration <= 0 {
+		return 0, false // Only the progress line scrubs
+	}
+	status := m
+	offset := m.statusOffset()
+	status.width -= offset
+	_, barStart, barWidth := status.progressLine()
+	col -= offset + barStart
+	if barWidth == 0 || col < 0 || col >= barWidth {
+		return 0, false
+	}
+	// Aim for the middle of the clicked cell
+	return (float64(col) + 0.5) / float64(barWidth) * m.status.Duration, true
+}
+
+// seek moves the playing track by offset, or to position when absolute is true. The bar
+// moves right away; Music catches up in the background.
+func (m *Model) seek(offset float64, absolute bool) tea.Cmd {
+	target, ok := 0.0, false
+	m.boxer.EditLeaf("playback", func(model tea.Model) (tea.Model, error) {
+		pb := model.(playbackModel)
+		if pb.status.Track.Name == "" {
+			return pb, nil
+		}
+		target = offset
+		if !absolute {
+			target += pb.position()
+		}
+		if pb.status.Duration > 0 {
+			target = min(target, pb.status.Duration)
+		}
+		target = max(target, 0)
+		pb.status.Position = target
+		pb.lastUpdate = time.Now()
+		ok = true
+		return pb, nil
+	})
+	if !ok {
+		return nil
+	}
+	return playerCommand(func(d *daemon.Daemon) error { return d.SetPosition(target) })
+}
+
+// clickPlayback scrubs to the clicked spot of the progress bar
+func (m *Model) clickPlayback(col, row int) tea.Cmd {
+	var position float64
+	found := false
+	m.boxer.EditLeaf("playback", func(model tea.Model) (tea.Model, error) {
+		pb := model.(playbackModel)
+		position, found = pb.positionAt(col, row)
+		return pb, nil
+	})
+	if !found {
+		return nil
+	}
+	return m.seek(position, true)
+}
@@ -0,0 +1,217 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+)
+
+// searchCategory is the kind of search result the main pane lists
+type searchCategory int
+
+const (
+	categorySongs searchCategory = iota
+	categoryAlbums
+	categoryArtists
+	categoryPlaylists
+	searchCategoryCount
+)
+
+var searchCategoryNames = [searchCategoryCount]string{"Songs", "Albums", "Artists", "Playlists"}
+
+func (c searchCategory) String() string {
+	return searchCategoryNames[c]
+}
+
+// showsResults reports whether the song list shows search results or a collection
+// rather than a playlist
+func (m mainContentModel) showsResults() bool {
+	return m.view == viewSearch || m.collection != ""
+}
+
+// showsSearchHits reports whether the main pane lists albums, artists or playlists found
+// by a search rather than songs
+func (m mainContentModel) showsSearchHits() bool {
+	return m.view == viewSearch && m.collection == "" && m.searchCategory != categorySongs
+}
+
+// searchCount is how many results of category the last search found
+func (m mainContentModel) searchCount(category searchCategory) int {
+	switch category {
+	case categoryAlbums:
+		return len(m.searchHits.Albums)
+	case categoryArtists:
+		return len(m.searchHits.Artists)
+	case categoryPlaylists:
+		return len(m.searchHits.Playlists)
+	}
+	return len(m.searchResults)
+}
+
+// searchRowCount is how many rows the search view lists
+func (m mainContentModel) searchRowCount() int {
+	if m.showsSearchHits() {
+		return m.searchCount(m.searchCategory)
+	}
+	return len(m.searchResults)
+}
+
+// searchHitName is the name of album, artist or playlist i of the current category
+func (m mainContentModel) searchHitName(i int) string {
+	switch m.searchCategory {
+	case categoryAlbums:
+		return m.searchHits.Albums[i].Name
+	case categoryArtists:
+		return m.searchHits.Artists[i].Name
+	case categoryPlaylists:
+		return m.searchHits.Playlists[i]
+	}
+	return m.searchResults[i].Name
+}
+
+// setSearchResults shows what a search found, opening the first category with results
+func (m *mainContentModel) setSearchResults(query string, results daemon.SearchResults) {
+	m.searchHits = results
+	m.searchResults = results.Songs
+	m.searchQuery = query
+	m.view = viewSearch
+	m.collection = ""
+	m.visual = false
+	m.selectedSong = 0
+	m.scrollOffset = 0
+	m.searchCategory = categorySongs
+	for c := categorySongs; c < searchCategoryCount; c++ {
+		if m.searchCount(c) > 0 {
+			m.searchCategory = c
+			break
+		}
+	}
+}
+
+// searchCategoryBar renders the categories with their counts and the current one
+// highlighted, falling back to just the current one when they don't all fit
+func (m mainContentModel) searchCategoryBar(width int) string {
+	labels := make([]string, searchCategoryCount)
+	full := 0
+	for c := categorySongs; c < searchCategoryCount; c++ {
+		labels[c] = fmt.Sprintf("%s %d", c, m.searchCount(c))
+		full += layout.Width(labels[c]) + 1
+	}
+	if full-1 > width {
+		return titleStyle.Render(labels[m.searchCategory])
+	}
+	var bar string
+	for c, label := range labels {
+		if c > 0 {
+			bar += " "
+		}
+		if searchCategory(c) == m.searchCategory {
+			bar += titleStyle.Render(label)
+		} else {
+			bar += playlistStatsStyle.Render(label)
+		}
+	}
+	return bar
+}
+
+// renderSearchHits lists the albums, artists or playlists a search found, below the same
+// three header lines as the song table
+func (m mainContentModel) renderSearchHits(title string) string {
+	var content strings.Builder
+	content.WriteString(title + "\n")
+
+	count := m.searchRowCount()
+	if count == 0 {
+		content.WriteString(fmt.Sprintf("\n No %s found.", strings.ToLower(m.searchCategory.String())))
+		return content.String()
+	}
+
+	nameWidth := max((m.width-4)*60/100, 8)
+	detailWidth := max(m.width-4-nameWidth, 4)
+	heading, detail := "Playlist", ""
+	switch m.searchCategory {
+	case categoryAlbums:
+		heading, detail = "Album", "Artist"
+	case categoryArtists:
+		heading, detail = "Artist", "Songs"
+	}
+	content.WriteString(" " + layout.Pad(heading, nameWidth) + " " + detail + "\n")
+	content.WriteString(" " + strings.Repeat("─", m.width-2) + "\n")
+
+	visible := max(m.height-3, 1)
+	end := min(m.scrollOffset+visible, count)
+	for i := m.scrollOffset; i < end; i++ {
+		name := m.searchHitName(i)
+		var info string
+		switch m.searchCategory {
+		case categoryAlbums:
+			album := m.searchHits.Albums[i]
+			info = fmt.Sprintf("%s · %d songs", album.Tracks[0].Artist, len(album.Tracks))
+		case categoryArtists:
+			info = fmt.Sprintf("%d", len(m.searchHits.Artists[i].Tracks))
+		}
+		row := " " + layout.Pad(layout.Truncate(name, nameWidth, "..."), nameWidth) + " " + layout.Truncate(info, detailWidth, "...")
+		if i == m.selectedSong && m.focused {
+			row = selectedSongStyle.Render(row)
+		}
+		content.WriteString(row + "\n")
+	}
+
+	result := content.String()
+	if lines := strings.Split(result, "\n"); len(lines) > m.height {
+		result = strings.Join(lines[:m.height], "\n")
+	}
+	return result
+}
+
+// cycleSearchCategory shows the next (1) or previous (-1) category of search results
+func (m *Model) cycleSearchCategory(delta int) {
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		if main.view != viewSearch || main.collection != "" {
+			return main, nil
+		}
+		main.searchCategory = (main.searchCategory + searchCategory(delta) + searchCategoryCount) % searchCategoryCount
+		main.selectedSong = 0
+		main.scrollOffset = 0
+		return main, nil
+	})
+}
+
+// activateSearchHit plays the selected album, lists the selected artist's songs or opens
+// the selected playlist
+func (m *Model) activateSearchHit() tea.Cmd {
+	var main mainContentModel
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main = model.(mainContentModel)
+		return main, nil
+	})
+	i := main.selectedSong
+	if i < 0 || i >= main.searchRowCount() {
+		return nil
+	}
+
+	switch main.searchCategory {
+	case categoryAlbums:
+		album := main.searchHits.Albums[i]
+		return m.playCollection(album.Name, album.Tracks, 0)
+	case categoryArtists:
+		m.showCollection(main.searchHits.Artists[i], viewSearch)
+	case categoryPlaylists:
+		var index int
+		m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+			pl := model.(playlistsModel)
+			index = slices.Index(pl.playlistItems, main.searchHits.Playlists[i])
+			return pl, nil
+		})
+		if index >= 0 {
+			return m.openPlaylistItem(index)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/config"
+)
+
+// maxSearchHistory is how many past searches are kept
+const maxSearchHistory = 100
+
+// searchHistory is the past search queries, oldest first, recalled with Up and Down in
+// the search box like a shell's history
+type searchHistory struct {
+	queries []string
+	recall  int    // Query shown by Up/Down, len(queries) while typing a new one
+	draft   string // What was typed before recalling, restored past the newest query
+}
+
+// searchHistoryPath is where past searches are kept between runs
+func searchHistoryPath() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "search_history.json"), nil
+}
+
+// loadSearchHistory reads the saved searches. A missing or unreadable file yields an
+// empty history.
+func loadSearchHistory() searchHistory {
+	var queries []string
+	path, err := searchHistoryPath()
+	if err != nil {
+		return searchHistory{}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return searchHistory{}
+	}
+	if err := json.Unmarshal(data, &queries); err != nil {
+		return searchHistory{}
+	}
+	return searchHistory{queries: queries, recall: len(queries)}
+}
+
+// save writes the history to disk
+func (h searchHistory) save() error {
+	path, err := searchHistoryPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(h.queries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode search history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write search history: %w", err)
+	}
+	return nil
+}
+
+// add records query as the newest search, moving it there if it was searched before
+func (h *searchHistory) add(query string) {
+	if i := slices.Index(h.queries, query); i >= 0 {
+		h.queries = slices.Delete(h.queries, i, i+1)
+	}
+	h.queries = append(h.queries, query)
+	if len(h.queries) > maxSearchHistory {
+		h.queries = h.queries[len(h.queries)-maxSearchHistory:]
+	}
+	h.reset()
+}
+
+// reset goes back to typing a new query
+func (h *searchHistory) reset() {
+	h.recall = len(h.queries)
+	h.draft = ""
+}
+
+// step moves through the history, -1 towards older queries and 1 towards newer ones,
+// returning the query to show. current is kept as the draft when recalling starts.
+func (h *searchHistory) step(direction int, current string) (string, bool) {
+	next := h.recall + direction
+	if next < 0 || next > len(h.queries) {
+		return "", false
+	}
+	if h.recall == len(h.queries) {
+		h.draft = current
+	}
+	h.recall = next
+	if next == len(h.queries) {
+		return h.draft, true
+	}
+	return h.queries[next], true
+}
+
+// search runs query and records it in the history
+func (m *Model) search(query string) tea.Cmd {
+	m.searchHistory.add(query)
+	var saveErr tea.Cmd
+	if !m.safeMode {
+		if err := m.searchHistory.save(); err != nil {
+			saveErr = m.toast(notifyError("Error saving search history: %v", err))
+		}
+	}
+	return tea.Batch(saveErr, m.startSearch(query, false))
+}
+
+// recallSearch replaces the search box's text with an older (-1) or newer (1) query
+func (m *Model) recallSearch(direction int) {
+	m.boxer.EditLeaf("searchHelp", func(model tea.Model) (tea.Model, error) {
+		sh := model.(searchHelpModel)
+		if query, ok := m.searchHistory.step(direction, sh.input.text); ok {
+			sh.input = newLineInput(query)
+		}
+		return sh, nil
+	})
+}
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/layout"
+	"main/locale"
+)
+
+// searchCharLimit is the longest query the search box takes, in characters
+const searchCharLimit = 156
+
+// updateSearchInput passes msg to the search box, and searches as you type when that
+// changed the query
+func (m *Model) updateSearchInput(msg tea.KeyMsg) tea.Cmd {
+	before := m.searchText()
+	m.boxer.EditLeaf("searchHelp", func(model tea.Model) (tea.Model, error) {
+		updated, _ := model.(searchHelpModel).Update(msg)
+		return updated, nil
+	})
+	if after := m.searchText(); after != before {
+		return m.searchAsYouType(after)
+	}
+	return nil
+}
+
+// searchInputView is the query being typed, cut to width cells around the cursor,
+// or the muted prompt while nothing is typed
+func searchInputView(input lineInput, width int) string {
+	if input.text == "" {
+		return layout.Truncate("_"+statusMutedStyle.Render(locale.T("search.prompt")), width, "")
+	}
+	runes := []rune(input.View())
+	cursor := min(max(input.pos, 0), len(runes)-1)
+	start, end := 0, len(runes)
+	for start < cursor && layout.Width(string(runes[start:cursor+1])) > width {
+		start++
+	}
+	for end > cursor+1 && layout.Width(string(runes[start:end])) > width {
+		end--
+	}
+	return string(runes[start:end])
+}
@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/config"
+)
+
+// sessionState is where the TUI was left, restored on the next run. How the open
+// playlist was sorted and scrolled is kept with the rest of its view settings.
+type sessionState struct {
+	Playlist        string `json:"playlist"`
+	SidebarSelected int    `json:"sidebar_selected"`
+	SidebarScroll   int    `json:"sidebar_scroll"`
+	Focus           string `json:"focus"`
+	// Set with :volstep, 0 when the configured step is used
+	VolumeStep int `json:"volume_step,omitempty"`
+	// Columns the sidebar was widened by with Ctrl+W >, negative when narrowed, and
+	// whether it was hidden with Ctrl+W o
+	SidebarAdjust int  `json:"sidebar_adjust,omitempty"`
+	SidebarHidden bool `json:"sidebar_hidden,omitempty"`
+}
+
+// sessionFocusNames are the focus areas a session can be restored to
+var sessionFocusNames = map[focusArea]string{
+	focusPlaylists: "playlists",
+	focusMain:      "main",
+}
+
+// sessionStatePath is where the session is kept between runs
+func sessionStatePath() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "session.json"), nil
+}
+
+// loadSessionState reads the saved session. A missing or unreadable file yields nil,
+// so the TUI starts the usual way.
+func loadSessionState() *sessionState {
+	path, err := sessionStatePath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var session sessionState
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil
+	}
+	return &session
+}
+
+// save writes the session to disk
+func (s sessionState) save() error {
+	path, err := sessionStatePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
+
+// captureSession records where the TUI is now
+func (m *Model) captureSession() sessionState {
+	session := sessionState{
+		Playlist:        m.selectedPlaylist,
+		SidebarSelected: m.selectedPlaylistItem,
+		Focus:           sessionFocusNames[m.currentFocus],
+		VolumeStep:      m.volumeStep,
+		SidebarAdjust:   m.split.adjust,
+		SidebarHidden:   m.split.hidden,
+	}
+	m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+		session.SidebarScroll = model.(playlistsModel).scrollOffset
+		return model, nil
+	})
+	return session
+}
+
+// restoreSession reopens the playlist of the last run with the sidebar and focus as they
+// were. It runs once, when the playlist names first arrive.
+func (m *Model) restoreSession() tea.Cmd {
+	session := m.session
+	m.session = nil
+	if session == nil || m.tab != tabPlaylists {
+		return nil
+	}
+
+	var items []string
+	var count int
+	m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+		pl := model.(playlistsModel)
+		items, count = pl.playlistItems, pl.itemCount()
+		return pl, nil
+	})
+	if count == 0 {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if i := slices.Index(items, session.Playlist); i >= 0 {
+		cmd = m.openSidebarItem(i)
+	}
+
+	// Playlists may have come and gone since, so keep the sidebar within the list
+	m.selectedPlaylistItem = min(max(session.SidebarSelected, 0), count-1)
+	m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+		pl := model.(playlistsModel)
+		pl.scrollOffset = min(max(session.SidebarScroll, 0), m.selectedPlaylistItem)
+		return pl, nil
+	})
+	m.updatePlaylistSelection()
+
+	for focus, name := range sessionFocusNames {
+		if name == session.Focus && (focus != focusMain || m.selectedPlaylist != "") && (focus == focusMain || !m.split.hidden) {
+			m.currentFocus = focus
+		}
+	}
+	m.updateFocus()
+	return cmd
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// jumpKey returns the letter or digit to jump to for msg in the sidebar, any one not
+// bound to an action. Bound ones are typed after the jump key (see updateJump).
+func (m *Model) jumpKey(msg tea.KeyMsg) (rune, bool) {
+	if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 || msg.Paste || msg.Alt {
+		return 0, false
+	}
+	r := msg.Runes[0]
+	if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+		return 0, false
+	}
+	if m.keys.action(scopeGlobal, msg.String()) != "" {
+		return 0, false
+	}
+	return r, true
+}
+
+// initial returns the first letter or digit of name, lowercased
+func initial(name string) rune {
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+	}
+	return 0
+}
+
+// jumpToLetter selects the next sidebar item after the selected one starting with
+// letter, wrapping around, so pressing it again cycles through them
+func (m *Model) jumpToLetter(letter rune) {
+	letter = unicode.ToLower(letter)
+	m.jumpTo(1, func(name string) bool { return initial(name) == letter })
+}
+
+// jumpTo selects the first sidebar item from the one step items after the selected one
+// whose name matches, wrapping around, reporting whether there was one
+func (m *Model) jumpTo(step int, match func(name string) bool) bool {
+	found := -1
+	m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+		pl := model.(playlistsModel)
+		count := pl.itemCount()
+		for n := 0; n < count; n++ {
+			i := (m.selectedPlaylistItem + step + n) % count
+			if match(pl.itemName(i)) {
+				found = i
+				break
+			}
+		}
+		return pl, nil
+	})
+	if found < 0 {
+		return false
+	}
+	m.selectedPlaylistItem = found
+	m.updatePlaylistSelection()
+	return true
+}
+
+// startJump focuses the sidebar and starts typing the start of a playlist name to jump
+// to, which works for every letter, those bound to actions included
+func (m *Model) startJump() {
+	m.currentFocus = focusPlaylists
+	m.updateFocus()
+	m.setJumpPrefix("", true)
+}
+
+// setJumpPrefix shows prefix as typed after the jump key, or ends typing it
+func (m *Model) setJumpPrefix(prefix string, jumping bool) {
+	m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+		pl := model.(playlistsModel)
+		pl.jumping, pl.jumpPrefix = jumping, prefix
+		return pl, nil
+	})
+}
+
+// jumpPrefix is what's been typed after the jump key, and whether it's being typed
+func (m *Model) jumpPrefix() (string, bool) {
+	var prefix string
+	var jumping bool
+	m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+		pl := model.(playlistsModel)
+		prefix, jumping = pl.jumpPrefix, pl.jumping
+		return pl, nil
+	})
+	return prefix, jumping
+}
+
+// updateJump handles a key while the start of a playlist name is typed: characters
+// extend it and select the first playlist starting with it, and the same letter again
+// cycles through the playlists starting with it. Esc ends typing, and so does any other
+// key, which is then handled as usual (enter opening the playlist found).
+func (m *Model) updateJump(msg tea.KeyMsg) (handled bool) {
+	prefix, _ := m.jumpPrefix()
+	switch {
+	case msg.Type == tea.KeyEsc:
+		m.setJumpPrefix("", false)
+		return true
+	case msg.Type == tea.KeyBackspace:
+		if runes := []rune(prefix); len(runes) > 0 {
+			prefix = string(runes[:len(runes)-1])
+		}
+		m.setJumpPrefix(prefix, true)
+		return true
+	case msg.Type == tea.KeySpace:
+		msg.Runes = []rune{' '}
+	case msg.Type != tea.KeyRunes || msg.Alt || msg.Paste:
+		m.setJumpPrefix("", false)
+		return false
+	}
+	typed := strings.ToLower(prefix + string(msg.Runes))
+	if m.jumpTo(0, func(name string) bool { return strings.HasPrefix(strings.ToLower(name), typed) }) {
+		m.setJumpPrefix(typed, true)
+		return true
+	}
+	// "aa" finding nothing moves on to the next playlist starting with "a"
+	if letter := string([]rune(typed)[0]); strings.Trim(typed, letter) == "" {
+		m.jumpTo(1, func(name string) bool { return strings.HasPrefix(strings.ToLower(name), letter) })
+		m.setJumpPrefix(letter, true)
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/treilik/bubbleboxer"
+)
+
+const (
+	sidebarStep     = 5  // Columns Ctrl+W < and > move the split by
+	sidebarMinWidth = 20 // Narrowest the sidebar can be made
+	mainMinWidth    = 40 // Room always left to the song list
+)
+
+// sidebarSplit is how the sidebar is resized at runtime, kept with the session
+type sidebarSplit struct {
+	adjust int  // Columns added to the width that suits the terminal, negative to narrow it
+	hidden bool // The song list takes the whole width
+}
+
+// defaultSidebarWidth is the sidebar width that suits a terminal width columns wide
+func defaultSidebarWidth(width int) int {
+	switch {
+	case width <= 80:
+		// Small screens: sidebar gets 1/3 but minimum 25
+		return max(width/3, 25)
+	case width <= 120:
+		// Medium screens: fixed sidebar width
+		return 35
+	case width <= 160:
+		// Large screens: slightly larger sidebar
+		return 40
+	default:
+		// Very large screens: cap sidebar but allow more space
+		return 45
+	}
+}
+
+// width is how wide the sidebar is drawn in a terminal width columns wide, 0 when hidden
+func (l sidebarSplit) width(width int) int {
+	if l.hidden {
+		return 0
+	}
+	sidebar := defaultSidebarWidth(width)
+	if l.adjust == 0 {
+		return sidebar // As before, even where the terminal is too narrow for the minimums
+	}
+	return max(min(sidebar+l.adjust, width-mainMinWidth), sidebarMinWidth)
+}
+
+// resize moves the split between the sidebar and the song list by delta columns, within
+// what fits a terminal width columns wide
+func (l *sidebarSplit) resize(delta, width int) {
+	target := max(min(l.width(width)+delta, width-mainMinWidth), sidebarMinWidth)
+	l.adjust = target - defaultSidebarWidth(width)
+	l.hidden = false
+}
+
+// sidebarWidth is how wide the sidebar is drawn now
+func (m *Model) sidebarWidth() int {
+	return m.split.width(m.lastWidth)
+}
+
+// resizeSidebar moves the split by delta columns
+func (m *Model) resizeSidebar(delta int) {
+	m.split.resize(delta, m.lastWidth)
+	m.applySidebarLayout()
+}
+
+// setSidebarHidden hides or shows the sidebar. Focus leaves it while it's hidden.
+func (m *Model) setSidebarHidden(hidden bool) {
+	if m.split.hidden == hidden {
+		return
+	}
+	m.split.hidden = hidden
+	if hidden && m.currentFocus != focusMain {
+		m.currentFocus = focusMain
+		m.updateFocus()
+	}
+	m.applySidebarLayout()
+}
+
+// applySidebarLayout lays the panes out again after the sidebar changed. A hidden sidebar
+// is taken out of the layout, as the boxer can't draw a leaf no columns wide.
+func (m *Model) applySidebarLayout() {
+	panes := &m.boxer.LayoutTree.Children[0]
+	if m.split.hidden {
+		panes.Children = []bubbleboxer.Node{panes.Children[len(panes.Children)-1]}
+	} else if len(panes.Children) == 1 {
+		panes.Children = []bubbleboxer.Node{m.sidebarNode, panes.Children[0]}
+	}
+	if m.lastWidth > 0 && m.lastHeight > 0 {
+		m.boxer.UpdateSize(tea.WindowSizeMsg{Width: m.lastWidth, Height: m.lastHeight})
+	}
+}
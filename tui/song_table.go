@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+
+	"main/daemon"
+	"main/layout"
+	"main/locale"
+)
+
+// songTable lays tracks out in columns across width with a bubbles table, returning the
+// header and a row per track. The song list keeps the selection and scrolling and
+// passes only the tracks in view, then flags and styles the rows it gets back, so the
+// table's own cursor styling is left blank.
+func songTable(columns []tableColumn, width int, tracks []daemon.Track, now time.Time) (string, []string) {
+	widths := columnWidths(columns, width)
+	cols := make([]table.Column, len(columns))
+	rowWidth := 0
+	for i, c := range columns {
+		spec := columnSpecs[c.name]
+		cols[i] = table.Column{Title: alignCell(locale.T("column."+c.name), widths[i], spec.right), Width: widths[i]}
+		rowWidth += 1 + widths[i]
+	}
+	rows := make([]table.Row, len(tracks))
+	for r, track := range tracks {
+		row := make(table.Row, len(columns))
+		for i, c := range columns {
+			spec := columnSpecs[c.name]
+			// Cut here rather than in the table, which measures wide characters differently
+			row[i] = alignCell(layout.Truncate(spec.value(track, now), widths[i], "..."), widths[i], spec.right)
+		}
+		rows[r] = row
+	}
+
+	// Every cell has a space before it, the gap between columns and the song list's
+	// left padding. Rows too wide for the pane are cut, leaving the last column for the
+	// scrollbar.
+	cell := lipgloss.NewStyle().PaddingLeft(1)
+	t := table.New(
+		table.WithColumns(cols),
+		table.WithRows(rows),
+		table.WithStyles(table.Styles{Header: cell, Cell: cell}),
+		table.WithWidth(max(min(rowWidth, width-1), 1)),
+		table.WithHeight(len(rows)+1),
+	)
+	lines := strings.Split(t.View(), "\n")
+	header := layout.Truncate(lines[0], max(width-1, 1), "")
+	if len(tracks) == 0 {
+		return header, nil
+	}
+	return header, lines[1:]
+}
+
+// alignCell pads value to width, on the left for right-aligned columns
+func alignCell(value string, width int, right bool) string {
+	if right {
+		return layout.PadLeft(value, width)
+	}
+	return layout.Pad(value, width)
+}
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+)
+
+// connectionState is how amtui last got on with Music.app
+type connectionState int
+
+const (
+	connectionUnknown connectionState = iota // No playback status yet
+	connectionOK
+	connectionDown
+	connectionDenied // macOS refused Automation
+)
+
+// operation is a slow request to Music.app that hasn't finished yet, like "Adding to queue"
+type operation struct {
+	label string
+}
+
+// Message sent when an operation starts, before its command runs
+type opStartedMsg struct {
+	op *operation
+}
+
+// Message wrapping the result of an operation's command, handled once the operation is
+// taken off the status line
+type opDoneMsg struct {
+	op  *operation
+	msg tea.Msg
+}
+
+// inFlight shows label in the status line while cmd runs
+func inFlight(label string, cmd tea.Cmd) tea.Cmd {
+	op := &operation{label: label}
+	return tea.Sequence(
+		func() tea.Msg { return opStartedMsg{op: op} },
+		func() tea.Msg { return opDoneMsg{op: op, msg: cmd()} },
+	)
+}
+
+// statusLine is the strip at the right of the instructions showing operations in
+// flight, the last error and the connection with Music.app
+type statusLine struct {
+	operations []*operation // Oldest first
+	lastError  string       // Kept until a later operation succeeds
+	connection connectionState
+	alarm      *alarm // Set with :alarm, nil when there's none
+	macro      string // Register a macro is being recorded into
+}
+
+func (s *statusLine) start(op *operation) {
+	s.operations = append(s.operations, op)
+}
+
+func (s *statusLine) finish(op *operation) {
+	for i, o := range s.operations {
+		if o == op {
+			s.operations = append(s.operations[:i], s.operations[i+1:]...)
+			return
+		}
+	}
+}
+
+// connectionOf tells the connection state from the error of a playback status poll
+func connectionOf(err error) connectionState {
+	switch {
+	case err == nil:
+		return connectionOK
+	case errors.Is(err, daemon.ErrAutomationDenied):
+		return connectionDenied
+	}
+	return connectionDown
+}
+
+// render draws the status line in at most width columns, dropping the error first,
+// the pending alarm next and then the operations when it doesn't fit
+func (s statusLine) render(width int) string {
+	var connection string
+	switch s.connection {
+	case connectionUnknown:
+		connection = statusMutedStyle.Render("◌ Connecting")
+	case connectionOK:
+		connection = statusMutedStyle.Render("● Music")
+	case connectionDown:
+		connection = statusErrorStyle.Render("○ Music unreachable")
+	case connectionDenied:
+		connection = statusErrorStyle.Render("○ No permission")
+	}
+	parts := []string{connection}
+	used := layout.Width(connection)
+	if s.macro != "" {
+		recording := "recording @" + s.macro
+		parts = append([]string{statusBusyStyle.Render(recording)}, parts...)
+		used += layout.Width(recording) + 3
+	}
+
+	// Separators between the parts are 3 columns wide
+	if n := len(s.operations); n > 0 {
+		text := "⟳ " + s.operations[n-1].label + "…"
+		if n > 1 {
+			text += fmt.Sprintf(" +%d", n-1)
+		}
+		if used+layout.Width(text)+3 <= width {
+			parts = append([]string{statusBusyStyle.Render(text)}, parts...)
+			used += layout.Width(text) + 3
+		}
+	}
+	if s.alarm != nil && width-used-3 >= 10 {
+		text := layout.Truncate("⏰ "+s.alarm.label(), min(width-used-3, 30), "…")
+		// Next to the connection, right of any operations
+		parts = slices.Insert(parts, len(parts)-1, statusMutedStyle.Render(text))
+		used += layout.Width(text) + 3
+	}
+	if s.lastError != "" && width-used-3 > 10 {
+		text := layout.Truncate("✗ "+s.lastError, min(width-used-3, 50), "…")
+		parts = append([]string{statusErrorStyle.Render(text)}, parts...)
+	}
+
+	if used > width {
+		return ""
+	}
+	return strings.Join(parts, statusMutedStyle.Render(" • "))
+}
+
+// setStatus mirrors the status line into the instructions area
+func (m *Model) setStatus() {
+	m.boxer.EditLeaf("instructions", func(model tea.Model) (tea.Model, error) {
+		instr := model.(instructionsModel)
+		instr.status = m.status
+		return instr, nil
+	})
+}
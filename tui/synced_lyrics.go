@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+)
+
+// syncedLyricsInterval is how often the playback position is polled while synced lyrics are shown
+const syncedLyricsInterval = 250 * time.Millisecond
+
+// lrcLine represents a single line of time-synced lyrics
+type lrcLine struct {
+	timestamp float64 // Timestamp in seconds
+	text      string
+}
+
+// Message for playback position updates (for synced lyrics)
+type playbackPosMsg struct {
+	position float64
+	ok       bool // False when the position couldn't be read; keep the current line
+	seq      int  // Lyrics the poll was started for, see lyricsModel.syncSeq
+}
+
+// parseLRCTimestamp parses an LRC timestamp such as "01:23.45" or "1:23" into seconds
+func parseLRCTimestamp(tag string) (float64, bool) {
+	minutes, seconds, found := strings.Cut(tag, ":")
+	if !found {
+		return 0, false
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 {
+		return 0, false
+	}
+	s, err := strconv.ParseFloat(strings.Replace(seconds, ":", ".", 1), 64)
+	if err != nil || s < 0 {
+		return 0, false
+	}
+	return float64(m)*60 + s, true
+}
+
+// Parse LRC format lyrics into lines sorted by time. A line may carry several timestamps
+// ("[00:12.00][01:30.00] chorus"); metadata tags such as "[ar:Artist]" are skipped.
+func parseLRC(lrc string) []lrcLine {
+	if lrc == "" {
+		return nil
+	}
+
+	var lines []lrcLine
+	for _, line := range strings.Split(lrc, "\n") {
+		line = strings.TrimSpace(line)
+
+		var timestamps []float64
+		for strings.HasPrefix(line, "[") {
+			endBracket := strings.Index(line, "]")
+			if endBracket == -1 {
+				break
+			}
+			timestamp, ok := parseLRCTimestamp(line[1:endBracket])
+			if !ok {
+				break
+			}
+			timestamps = append(timestamps, timestamp)
+			line = line[endBracket+1:]
+		}
+
+		text := strings.TrimSpace(line)
+		for _, timestamp := range timestamps {
+			lines = append(lines, lrcLine{timestamp: timestamp, text: text})
+		}
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].timestamp < lines[j].timestamp })
+	return lines
+}
+
+// currentLyricLine returns the index of the line being sung at position, or -1 before the first line
+func currentLyricLine(lines []lrcLine, position float64) int {
+	return sort.Search(len(lines), func(i int) bool { return lines[i].timestamp > position }) - 1
+}
+
+// lyricProgress returns how far (0-1) position is through line i, judged by when the next line starts
+func lyricProgress(lines []lrcLine, i int, position float64) float64 {
+	if i < 0 || i >= len(lines) {
+		return 0
+	}
+	if i == len(lines)-1 {
+		return 1
+	}
+	length := lines[i+1].timestamp - lines[i].timestamp
+	if length <= 0 {
+		return 1
+	}
+	return max(0, min(1, (position-lines[i].timestamp)/length))
+}
+
+// karaokeLine renders the current lyric line, with the part already sung in the accent color
+func karaokeLine(text string, progress float64, maxWidth int) string {
+	const prefix = "  ▶ "
+	if layout.Width(prefix+text) > maxWidth {
+		text = layout.Truncate(text, maxWidth-layout.Width(prefix), "...")
+	}
+	runes := []rune(text)
+	sung := int(float64(len(runes)) * progress)
+	return lyricsCurrentStyle.Render(prefix) + lyricsSungStyle.Render(string(runes[:sung])) + lyricsCurrentStyle.Render(string(runes[sung:]))
+}
+
+// Tick command for updating playback position
+func tickPlaybackPosition(seq int) tea.Cmd {
+	return tea.Tick(syncedLyricsInterval, func(t time.Time) tea.Msg {
+		d := daemon.Daemon{}
+		pos, err := d.GetPosition()
+		if err != nil {
+			return playbackPosMsg{seq: seq}
+		}
+		return playbackPosMsg{position: pos.Position, ok: true, seq: seq}
+	})
+}
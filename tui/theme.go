@@ -0,0 +1,513 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"main/config"
+)
+
+// defaultTheme is used when the config doesn't pick a theme, or picks one that doesn't exist
+const defaultTheme = "spotify"
+
+// theme is a color scheme for the whole interface, see config.ThemeConfig for what each color is for
+type theme struct {
+	Primary        lipgloss.Color
+	Accent         lipgloss.Color
+	Text           lipgloss.Color
+	Muted          lipgloss.Color
+	Background     lipgloss.Color
+	Sidebar        lipgloss.Color
+	Border         lipgloss.Color
+	Link           lipgloss.Color
+	Input          lipgloss.Color
+	Selection      lipgloss.Color
+	Playback       lipgloss.Color
+	Warning        lipgloss.Color
+	Error          lipgloss.Color
+	Overlay        lipgloss.Color
+	LyricsPast     lipgloss.Color
+	LyricsUpcoming lipgloss.Color
+	ProgressStart  lipgloss.Color
+	ProgressEnd    lipgloss.Color
+}
+
+// builtinThemeNames lists the built-in themes in the order they are cycled through
+var builtinThemeNames = []string{"spotify", "dracula", "nord", "gruvbox", "light", "high-contrast", "colorblind"}
+
+// themeNotes says what the built-in themes that aren't just a look are for, shown in the
+// theme picker
+var themeNotes = map[string]string{
+	"high-contrast": "16 colors, for terminals without true color",
+	"colorblind":    "blue and orange, no red-green pairs",
+}
+
+var builtinThemes = map[string]theme{
+	"spotify": {
+		Primary:        "#1DB954",
+		Accent:         "#1ED760",
+		Text:           "#FFFFFF",
+		Muted:          "#B3B3B3",
+		Background:     "#191414",
+		Sidebar:        "#121212",
+		Border:         "#1DB954",
+		Link:           "#4A9EFF",
+		Input:          "#2A2A2A",
+		Selection:      "#2D2D2D",
+		Playback:       "#3A3A5C",
+		Warning:        "#F2B8B5",
+		Error:          "#B3261E",
+		Overlay:        "#1A1A1A",
+		LyricsPast:     "240",
+		LyricsUpcoming: "246",
+		ProgressStart:  "#1DB954",
+		ProgressEnd:    "#4A9EFF",
+	},
+	"dracula": {
+		Primary:        "#BD93F9",
+		Accent:         "#50FA7B",
+		Text:           "#F8F8F2",
+		Muted:          "#6272A4",
+		Background:     "#282A36",
+		Sidebar:        "#21222C",
+		Border:         "#BD93F9",
+		Link:           "#8BE9FD",
+		Input:          "#44475A",
+		Selection:      "#44475A",
+		Playback:       "#44475A",
+		Warning:        "#FFB86C",
+		Error:          "#FF5555",
+		Overlay:        "#21222C",
+		LyricsPast:     "#6272A4",
+		LyricsUpcoming: "#BFBFBF",
+		ProgressStart:  "#BD93F9",
+		ProgressEnd:    "#FF79C6",
+	},
+	"nord": {
+		Primary:        "#88C0D0",
+		Accent:         "#A3BE8C",
+		Text:           "#ECEFF4",
+		Muted:          "#81A1C1",
+		Background:     "#2E3440",
+		Sidebar:        "#3B4252",
+		Border:         "#88C0D0",
+		Link:           "#8FBCBB",
+		Input:          "#434C5E",
+		Selection:      "#434C5E",
+		Playback:       "#5E81AC",
+		Warning:        "#EBCB8B",
+		Error:          "#BF616A",
+		Overlay:        "#3B4252",
+		LyricsPast:     "#4C566A",
+		LyricsUpcoming: "#D8DEE9",
+		ProgressStart:  "#88C0D0",
+		ProgressEnd:    "#B48EAD",
+	},
+	"gruvbox": {
+		Primary:        "#FABD2F",
+		Accent:         "#B8BB26",
+		Text:           "#EBDBB2",
+		Muted:          "#A89984",
+		Background:     "#282828",
+		Sidebar:        "#1D2021",
+		Border:         "#FABD2F",
+		Link:           "#83A598",
+		Input:          "#3C3836",
+		Selection:      "#504945",
+		Playback:       "#458588",
+		Warning:        "#FE8019",
+		Error:          "#CC241D",
+		Overlay:        "#32302F",
+		LyricsPast:     "#665C54",
+		LyricsUpcoming: "#928374",
+		ProgressStart:  "#FABD2F",
+		ProgressEnd:    "#FE8019",
+	},
+	"light": {
+		Primary:        "#007A3D",
+		Accent:         "#008C45",
+		Text:           "#1E1E1E",
+		Muted:          "#6B6B6B",
+		Background:     "#FAFAFA",
+		Sidebar:        "#F0F0F0",
+		Border:         "#007A3D",
+		Link:           "#0062CC",
+		Input:          "#E4E4E4",
+		Selection:      "#DADADA",
+		Playback:       "#D6D6F5",
+		Warning:        "#B3261E",
+		Error:          "#F2B8B5",
+		Overlay:        "#FFFFFF",
+		LyricsPast:     "#A0A0A0",
+		LyricsUpcoming: "#6B6B6B",
+		ProgressStart:  "#007A3D",
+		ProgressEnd:    "#0060C0",
+	},
+	// Only the 16 basic ANSI colors, which every terminal has and the user's terminal
+	// theme keeps readable
+	"high-contrast": {
+		Primary:        "11",
+		Accent:         "14",
+		Text:           "15",
+		Muted:          "7",
+		Background:     "0",
+		Sidebar:        "0",
+		Border:         "15",
+		Link:           "12",
+		Input:          "8",
+		Selection:      "4",
+		Playback:       "4",
+		Warning:        "11",
+		Error:          "9",
+		Overlay:        "0",
+		LyricsPast:     "8",
+		LyricsUpcoming: "7",
+		ProgressStart:  "11",
+		ProgressEnd:    "14",
+	},
+	// Okabe-Ito colors, told apart with any common color vision deficiency
+	"colorblind": {
+		Primary:        "#56B4E9",
+		Accent:         "#E69F00",
+		Text:           "#FFFFFF",
+		Muted:          "#A0A0A0",
+		Background:     "#1B1B1B",
+		Sidebar:        "#141414",
+		Border:         "#56B4E9",
+		Link:           "#56B4E9",
+		Input:          "#2A2A2A",
+		Selection:      "#3A3A3A",
+		Playback:       "#0072B2",
+		Warning:        "#F0E442",
+		Error:          "#D55E00",
+		Overlay:        "#1A1A1A",
+		LyricsPast:     "#6B6B6B",
+		LyricsUpcoming: "#A0A0A0",
+		ProgressStart:  "#56B4E9",
+		ProgressEnd:    "#E69F00",
+	},
+}
+
+// hexColor matches "#RGB" and "#RRGGBB"
+var hexColor = regexp.MustCompile(`^#([0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+
+// parseThemeColor checks that s is a color lipgloss understands: hex or an ANSI 256-color number
+func parseThemeColor(s string) (lipgloss.Color, error) {
+	if hexColor.MatchString(s) {
+		return lipgloss.Color(s), nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 255 {
+		return lipgloss.Color(s), nil
+	}
+	return "", fmt.Errorf("invalid color %q (use \"#RRGGBB\" or 0-255)", s)
+}
+
+// customTheme builds a theme from a [themes.<name>] entry on top of its base theme
+func customTheme(cfg config.ThemeConfig) (theme, error) {
+	base := cfg.Base
+	if base == "" {
+		base = defaultTheme
+	}
+	t, ok := builtinThemes[base]
+	if !ok {
+		return theme{}, fmt.Errorf("unknown base theme %q", base)
+	}
+
+	colors := []struct {
+		key   string
+		value string
+		dst   *lipgloss.Color
+	}{
+		{"primary", cfg.Primary, &t.Primary},
+		{"accent", cfg.Accent, &t.Accent},
+		{"text", cfg.Text, &t.Text},
+		{"muted", cfg.Muted, &t.Muted},
+		{"background", cfg.Background, &t.Background},
+		{"sidebar", cfg.Sidebar, &t.Sidebar},
+		{"border", cfg.Border, &t.Border},
+		{"link", cfg.Link, &t.Link},
+		{"input", cfg.Input, &t.Input},
+		{"selection", cfg.Selection, &t.Selection},
+		{"playback", cfg.Playback, &t.Playback},
+		{"warning", cfg.Warning, &t.Warning},
+		{"error", cfg.Error, &t.Error},
+		{"overlay", cfg.Overlay, &t.Overlay},
+		{"lyrics_past", cfg.LyricsPast, &t.LyricsPast},
+		{"lyrics_upcoming", cfg.LyricsUpcoming, &t.LyricsUpcoming},
+		{"progress_start", cfg.ProgressStart, &t.ProgressStart},
+		{"progress_end", cfg.ProgressEnd, &t.ProgressEnd},
+	}
+	for _, c := range colors {
+		if c.value == "" {
+			continue
+		}
+		color, err := parseThemeColor(c.value)
+		if err != nil {
+			return theme{}, fmt.Errorf("%s: %w", c.key, err)
+		}
+		*c.dst = color
+	}
+	return t, nil
+}
+
+// themeList holds the themes that can be cycled through at runtime
+type themeList struct {
+	names   []string
+	themes  map[string]theme
+	current int
+}
+
+// loadThemes returns the built-in themes followed by the custom ones, sorted by name.
+// Broken custom themes are left out and reported together in the error.
+func loadThemes(custom map[string]config.ThemeConfig) (themeList, error) {
+	list := themeList{
+		names:  append([]string(nil), builtinThemeNames...),
+		themes: make(map[string]theme, len(builtinThemes)+len(custom)),
+	}
+	for name, t := range builtinThemes {
+		list.themes[name] = t
+	}
+
+	names := make([]string, 0, len(custom))
+	for name := range custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		t, err := customTheme(custom[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("theme %q: %w", name, err))
+			continue
+		}
+		// A custom theme may redefine a built-in one, which keeps its place in the cycle
+		if _, builtin := builtinThemes[name]; !builtin {
+			list.names = append(list.names, name)
+		}
+		list.themes[name] = t
+	}
+	return list, errors.Join(errs...)
+}
+
+// selectTheme makes name the current theme and applies it
+func (l *themeList) selectTheme(name string) error {
+	for i, n := range l.names {
+		if n == name {
+			l.current = i
+			applyTheme(l.themes[name])
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown theme %q", name)
+}
+
+// next switches to the following theme, wrapping around, and returns its name
+func (l *themeList) next() string {
+	if len(l.names) == 0 {
+		return ""
+	}
+	l.current = (l.current + 1) % len(l.names)
+	name := l.names[l.current]
+	applyTheme(l.themes[name])
+	return name
+}
+
+func init() {
+	applyTheme(builtinThemes[defaultTheme])
+}
+
+// applyTheme rebuilds the styles used across the interface from t
+func applyTheme(t theme) {
+	// Base styles
+	baseStyle = lipgloss.NewStyle().
+		Foreground(t.Text).
+		Margin(1, 2)
+
+	// For currently selected item
+	activeItemStyle = lipgloss.NewStyle().Foreground(t.Accent).Bold(true)
+
+	// For navigated-to but not selected item
+	unfocusedSelectedItemStyle = lipgloss.NewStyle().Foreground(t.Accent).Underline(true)
+
+	// Focused and unfocused border styles
+	focusedStyle = lipgloss.NewStyle().
+		Background(t.Sidebar).
+		Foreground(t.Text).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border)
+
+	unfocusedStyle = lipgloss.NewStyle().
+		Background(t.Sidebar).
+		Foreground(t.Text).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Muted)
+
+	mainFocusedStyle = lipgloss.NewStyle().
+		Background(t.Background).
+		Foreground(t.Text).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border)
+
+	mainUnfocusedStyle = lipgloss.NewStyle().
+		Background(t.Background).
+		Foreground(t.Text).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Muted)
+
+	titleStyle = lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	selectedItemStyle = lipgloss.NewStyle().
+		Foreground(t.Accent).
+		Bold(true)
+
+	headerStyle = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Bold(true).
+		MarginBottom(1)
+
+	linkStyle = lipgloss.NewStyle().
+		Foreground(t.Link).
+		Underline(true)
+
+	searchBoxStyle = lipgloss.NewStyle().
+		Foreground(t.Text).
+		Background(t.Input).
+		Padding(0, 1).
+		MarginBottom(1)
+
+	// Song table styles. The selection is bold too, so it doesn't rest on color alone.
+	selectedSongStyle = lipgloss.NewStyle().
+		Background(t.Selection).
+		Foreground(t.Text).
+		Bold(true)
+
+	// Rows marked in visual mode
+	markedSongStyle = lipgloss.NewStyle().
+		Foreground(t.Accent).
+		Bold(true)
+
+	// Shuffle and repeat icons in the playback bar while they're off
+	playbackOffStyle = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Faint(true)
+
+	// Row of the playing track in the song list
+	playingTrackStyle = lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	// Text matching the pattern found with "/" in the song list
+	findMatchStyle = lipgloss.NewStyle().
+		Background(t.Warning).
+		Foreground(t.Background)
+
+	// Scrollbar at the right edge of the song list
+	scrollbarThumbStyle = lipgloss.NewStyle().
+		Foreground(t.Accent)
+	scrollbarTrackStyle = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Faint(true)
+
+	tableHeaderStyle = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Bold(true)
+
+	// Marker for read-only smart/genius playlists
+	smartPlaylistStyle = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Italic(true)
+
+	// Tracks that can't be played right now
+	unavailableTrackStyle = lipgloss.NewStyle().
+		Foreground(t.Muted).
+		Faint(true)
+
+	// Track count and total duration next to playlists in the sidebar
+	playlistStatsStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	// Heads-up about the upcoming track
+	upNextStyle = lipgloss.NewStyle().
+		Foreground(t.Text).
+		Background(t.Playback)
+
+	// Title of confirmations for actions that can't be undone
+	warningStyle = lipgloss.NewStyle().
+		Foreground(t.Warning).
+		Bold(true)
+
+	// Outcomes of operations over the bottom right
+	toastStyle = lipgloss.NewStyle().
+		Foreground(t.Text).
+		Background(t.Selection)
+
+	toastErrorStyle = lipgloss.NewStyle().
+		Foreground(t.Error).
+		Background(t.Selection).
+		Bold(true)
+
+	// Status line at the right of the instructions
+	statusMutedStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	statusBusyStyle = lipgloss.NewStyle().
+		Foreground(t.Accent)
+
+	statusErrorStyle = lipgloss.NewStyle().
+		Foreground(t.Error)
+
+	// Banner shown while Music.app is unreachable
+	bannerStyle = lipgloss.NewStyle().
+		Foreground(t.Text).
+		Background(t.Error).
+		Bold(true)
+
+	// Queue overlay styles
+	queueOverlayStyle = lipgloss.NewStyle().
+		Background(t.Overlay).
+		Foreground(t.Text).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border)
+
+	// Synced lyrics: the current line, the part of it already sung, and the lines around it
+	lyricsCurrentStyle = lipgloss.NewStyle().
+		Foreground(t.Text).
+		Bold(true)
+
+	lyricsSungStyle = lipgloss.NewStyle().
+		Foreground(t.Accent).
+		Bold(true)
+
+	lyricsPastStyle = lipgloss.NewStyle().
+		Foreground(t.LyricsPast)
+
+	lyricsUpcomingStyle = lipgloss.NewStyle().
+		Foreground(t.LyricsUpcoming)
+
+	// Progress bars, filled with a gradient between the progress colors
+	progressBubble = newProgressBubble(t)
+
+	// Without colors, what stands out by its background is reversed or underlined instead
+	if colorProfile == termenv.Ascii {
+		selectedSongStyle = selectedSongStyle.Reverse(true)
+		findMatchStyle = findMatchStyle.Underline(true)
+		searchBoxStyle = searchBoxStyle.Underline(true)
+		upNextStyle = upNextStyle.Reverse(true)
+		toastStyle = toastStyle.Reverse(true)
+		toastErrorStyle = toastErrorStyle.Reverse(true)
+		bannerStyle = bannerStyle.Reverse(true)
+	}
+}
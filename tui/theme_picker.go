@@ -0,0 +1,74 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/locale"
+)
+
+// themePickerModel lists the themes, previewing each one as it's selected
+type themePickerModel struct {
+	height   int
+	names    []string
+	selected int
+}
+
+// update moves the selection. It returns done when the picker should close, and picked
+// when it closed on Enter rather than Esc.
+func (m themePickerModel) update(msg tea.KeyMsg) (themePickerModel, bool, bool) {
+	switch msg.String() {
+	case "esc", "q":
+		return m, true, false
+	case "enter":
+		return m, true, true
+	case "up", "k", "ctrl+p":
+		m.selected = max(0, m.selected-1)
+	case "down", "j", "ctrl+n":
+		m.selected = min(len(m.names)-1, m.selected+1)
+	}
+	return m, false, false
+}
+
+// lines is the picker's content, drawn in a panel so the rest of the screen shows the
+// theme being previewed
+func (m themePickerModel) lines() []string {
+	lines := []string{titleStyle.Render(locale.T("theme_picker.title")), ""}
+	// Keep the selection on screen when there are more themes than rows
+	rows := max(1, min(len(m.names), m.height-6))
+	offset := max(0, m.selected-rows+1)
+	for i := offset; i < offset+rows && i < len(m.names); i++ {
+		name := m.names[i]
+		if note, ok := themeNotes[name]; ok {
+			name += playlistStatsStyle.Render(" · " + note)
+		}
+		if i == m.selected {
+			lines = append(lines, "> "+selectedItemStyle.Render(name))
+		} else {
+			lines = append(lines, "  "+name)
+		}
+	}
+	return append(lines, "", locale.T("hint.theme_picker"))
+}
+
+// openThemePicker lists the themes with the current one selected
+func (m *Model) openThemePicker() {
+	m.themePicker = themePickerModel{names: m.themes.names, selected: m.themes.current}
+	m.themePickerVisible = true
+}
+
+// updateThemePicker previews the selected theme, keeps it on Enter and goes back to the
+// theme in use before on Esc
+func (m *Model) updateThemePicker(msg tea.KeyMsg) {
+	picker, done, picked := m.themePicker.update(msg)
+	m.themePicker = picker
+	name := picker.names[picker.selected]
+	switch {
+	case picked:
+		m.themes.selectTheme(name)
+	case done:
+		m.themes.selectTheme(m.themes.names[m.themes.current])
+	default:
+		applyTheme(m.themes.themes[name])
+	}
+	m.themePickerVisible = !done
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/layout"
+)
+
+const (
+	// toastDuration is how long a toast stays up, errors twice as long so they can be read
+	toastDuration = 3 * time.Second
+	// maxToasts is how many toasts are stacked at once, the oldest go first
+	maxToasts = 4
+)
+
+// toastMsg reports the outcome of an operation in a toast, in the error color when
+// failed is set. Commands return it rather than printing, which would corrupt the screen.
+type toastMsg struct {
+	text   string
+	failed bool
+}
+
+// notify is a toast with a successful outcome
+func notify(format string, args ...any) toastMsg {
+	return toastMsg{text: fmt.Sprintf(format, args...)}
+}
+
+// notifyError is a toast with a failure
+func notifyError(format string, args ...any) toastMsg {
+	return toastMsg{text: fmt.Sprintf(format, args...), failed: true}
+}
+
+// attempt runs op as a command, reporting its error in a toast as "<failure>: <error>"
+func attempt(failure string, op func() error) tea.Cmd {
+	return func() tea.Msg {
+		if err := op(); err != nil {
+			return notifyError("%s: %v", failure, err)
+		}
+		return nil
+	}
+}
+
+// Message sent when the toast with the given id has been up long enough
+type toastExpiredMsg struct {
+	id int
+}
+
+// toast is a message shown over the bottom right of the screen for a while
+type toast struct {
+	id int
+	toastMsg
+}
+
+// toastStack is the toasts currently shown, oldest first
+type toastStack struct {
+	toasts []toast
+	nextID int
+}
+
+// push shows msg, returning the command that expires it
+func (s *toastStack) push(msg toastMsg) tea.Cmd {
+	s.nextID++
+	id := s.nextID
+	s.toasts = append(s.toasts, toast{id: id, toastMsg: msg})
+	if len(s.toasts) > maxToasts {
+		s.toasts = s.toasts[len(s.toasts)-maxToasts:]
+	}
+	duration := toastDuration
+	if msg.failed {
+		duration *= 2
+	}
+	return tea.Tick(duration, func(time.Time) tea.Msg { return toastExpiredMsg{id: id} })
+}
+
+// expire takes down the toast with the given id
+func (s *toastStack) expire(id int) {
+	for i, t := range s.toasts {
+		if t.id == id {
+			s.toasts = append(s.toasts[:i], s.toasts[i+1:]...)
+			return
+		}
+	}
+}
+
+// render draws the toasts over the bottom right of view, newest at the bottom, leaving
+// the rest of view as it is
+func (s toastStack) render(view string, width int) string {
+	if len(s.toasts) == 0 || width < 10 {
+		return view
+	}
+	room := min(max(width/2, 30), width-2)
+	boxes := make([]string, len(s.toasts))
+	for i, t := range s.toasts {
+		icon, style := "✓", toastStyle
+		if t.failed {
+			icon, style = "✗", toastErrorStyle
+		}
+		boxes[i] = style.Render(" " + layout.Truncate(icon+" "+t.text, room-2, "…") + " ")
+	}
+	// Keep clear of the last line
+	return placeRight(view, width, strings.Count(view, "\n")-len(boxes), boxes)
+}
+
+// toast shows msg, returning the command that expires it. Errors also stay in the
+// status line after the toast is gone.
+func (m *Model) toast(msg toastMsg) tea.Cmd {
+	if msg.failed {
+		m.status.lastError = msg.text
+		m.setStatus()
+	}
+	return m.toasts.push(msg)
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+)
+
+// Message sent when Music moves to another track
+type trackChangedMsg struct {
+	change daemon.TrackChange
+}
+
+// waitForTrackChange delivers the next event from the track watcher
+func waitForTrackChange(watcher *daemon.TrackWatcher) tea.Cmd {
+	if watcher == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		change, ok := <-watcher.Events()
+		if !ok {
+			return nil
+		}
+		return trackChangedMsg{change: change}
+	}
+}
+
+// handleTrackChange records the track that just started in the history, tidies the
+// queue, reloads open lyrics and the open queue, and sends notifications for it
+func (m *Model) handleTrackChange(change daemon.TrackChange) tea.Cmd {
+	current := change.Current
+	m.lastPlayingTrack = current.Id
+	if current.Name == "" {
+		return nil
+	}
+
+	cmds := []tea.Cmd{m.recordPlayed(current)}
+	d := daemon.Daemon{}
+
+	// Played tracks only pile up in the amtui Queue
+	if m.config.Queue.CleanupPlayed && change.Previous.Name != "" {
+		cmds = append(cmds, attempt("Error cleaning up queue", d.CleanupQueue))
+	}
+
+	if m.config.Notifications.NowPlaying {
+		cmds = append(cmds, attempt("Error sending notification", func() error {
+			return d.Notify("Now playing", fmt.Sprintf("%s — %s", current.Name, current.Artist))
+		}))
+	}
+
+	// Keep open lyrics in step with the music
+	if m.lyricsVisible && current.Name != m.lyricsOverlay.trackName {
+		m.lyricsOverlay.loading = true
+		m.lyricsOverlay.trackName = current.Name
+		m.lyricsOverlay.artistName = current.Artist
+		m.lyricsOverlay.lastError = nil
+		cmds = append(cmds, fetchLyrics(current.Name, current.Artist))
+	}
+
+	// And the open queue, whose now playing line just moved on
+	cmds = append(cmds, m.refreshQueue())
+	return tea.Batch(cmds...)
+}
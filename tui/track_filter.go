@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+)
+
+// rowOrder returns the playlist positions of the song list's rows, sorted by sortMode and
+// narrowed to the tracks fuzzy matching filter, or nil when every track shows in playlist
+// order
+func (m mainContentModel) rowOrder(tracks []daemon.Track) []int {
+	order := sortedTrackIndices(tracks, m.sortMode)
+	if m.filter == "" {
+		return order
+	}
+	rows := make([]int, 0)
+	for row := range tracks {
+		i := row
+		if order != nil {
+			i = order[row]
+		}
+		if _, ok := daemon.FuzzyScore(m.filter, daemon.TrackSearchText(tracks[i])); ok {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+// rowCount is how many of tracks the song list shows
+func (m mainContentModel) rowCount(tracks []daemon.Track) int {
+	if order := m.rowOrder(tracks); order != nil {
+		return len(order)
+	}
+	return len(tracks)
+}
+
+// openFilter starts typing a filter for the open playlist's songs
+func (m *Model) openFilter() {
+	if m.currentFocus != focusMain || m.selectedPlaylist == "" {
+		return
+	}
+	open := false
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		if !main.showsResults() && main.currentPlaylist != "" {
+			main.filtering = true
+			main.prompt = newLineInput(main.filter)
+			open = true
+		}
+		return main, nil
+	})
+	m.filtering = open
+}
+
+// updateFilter edits the filter as it's typed, narrowing the song list on every key.
+// Enter keeps the filter and returns to the list, Esc clears it.
+func (m *Model) updateFilter(msg tea.KeyMsg) {
+	var prompt lineInput
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		prompt = model.(mainContentModel).prompt
+		return model, nil
+	})
+
+	switch msg.String() {
+	case "esc":
+		m.filtering = false
+		m.setFilter("")
+		return
+	case "enter":
+		m.filtering = false
+		m.setFilter(strings.TrimSpace(prompt.text))
+		return
+	case "up", "ctrl+p", "ctrl+k":
+		m.updateSongSelection(-1)
+		return
+	case "down", "ctrl+n", "ctrl+j":
+		m.updateSongSelection(1)
+		return
+	}
+	prompt.update(msg)
+	m.setPrompt(prompt)
+	m.setFilter(prompt.text)
+}
+
+// setPrompt keeps the filter or pattern being typed in the song list, with its cursor
+func (m *Model) setPrompt(prompt lineInput) {
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		main.prompt = prompt
+		return main, nil
+	})
+}
+
+// setFilter narrows the song list to the tracks matching filter, keeping the selected
+// song selected while it still matches and moving to the first match otherwise
+func (m *Model) setFilter(filter string) {
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		main.filtering = m.filtering
+		if main.filter == filter {
+			return main, nil
+		}
+		selected := main.trackIndex(main.selectedSong)
+		main.filter = filter
+		main.visual = false // The marked rows are different songs now
+
+		main.selectedSong = selected
+		if main.playlistCache != nil {
+			if order := main.rowOrder((*main.playlistCache)[main.currentPlaylist].Tracks); order != nil {
+				main.selectedSong = max(slices.Index(order, selected), 0)
+			}
+		}
+		main.selectedSong = max(main.selectedSong, 0)
+		main.scrollOffset = max(main.selectedSong-main.height/2, 0)
+		return main, nil
+	})
+}
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+	"main/locale"
+)
+
+// Message carrying the full metadata of the track shown in the inspector
+type inspectedTrackMsg struct {
+	track daemon.Track
+	info  daemon.TrackInfo
+	err   error
+}
+
+// inspectTrack loads the full metadata of track for the inspector
+func inspectTrack(track daemon.Track) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		info, err := d.GetTrackInfo(track.Id)
+		return inspectedTrackMsg{track: track, info: info, err: err}
+	}
+}
+
+// trackInspectorModel is the overlay listing everything Music knows about a track
+type trackInspectorModel struct {
+	width, height int
+	track         daemon.Track // As the song list has it, until info arrives
+	info          daemon.TrackInfo
+	loading       bool
+	err           error
+}
+
+// openTrackInspector shows the details of the song selected in the main pane. The
+// playing track is known by its database ID only, which GetTrackInfo can't look up.
+func (m *Model) openTrackInspector() tea.Cmd {
+	if m.currentFocus != focusMain {
+		return nil
+	}
+	track, ok := m.highlightedTrack()
+	if !ok {
+		return nil
+	}
+	m.trackInspector = trackInspectorModel{track: track, loading: true}
+	m.trackInspectorVisible = true
+	return inspectTrack(track)
+}
+
+// fields are the label and value pairs of the inspected track, empty values left out
+func (m trackInspectorModel) fields() [][2]string {
+	info := m.info
+	bitRate := countOf(info.BitRate, 0)
+	if bitRate != "" {
+		bitRate += " kbps"
+	}
+	location := info.Location
+	if location == "" {
+		location = "none, streamed from Apple Music"
+	}
+	fields := [][2]string{
+		{"Name", info.Name},
+		{"Artist", info.Artist},
+		{"Album", info.Album},
+		{"Album artist", info.AlbumArtist},
+		{"Genre", info.Genre},
+		{"Year", countOf(info.Year, 0)},
+		{"Track", countOf(info.TrackNumber, info.TrackCount)},
+		{"Disc", countOf(info.DiscNumber, info.DiscCount)},
+		{"Duration", formatTrackDuration(info.Duration)},
+		{"Plays", strconv.Itoa(info.PlayCount)},
+		{"Rating", formatRating(info.Rating)},
+		{"Added", formatAdded(info.Added, time.Now())},
+		{"Kind", info.Kind},
+		{"Bit rate", bitRate},
+		{"Cloud status", info.CloudStatus},
+		{"File", location},
+		{"Persistent ID", info.Id},
+	}
+	shown := fields[:0]
+	for _, f := range fields {
+		if f[1] != "" {
+			shown = append(shown, f)
+		}
+	}
+	return shown
+}
+
+// countOf formats n as "7 of 12", or just "7" without a total, and 0 as nothing
+func countOf(n, total int) string {
+	switch {
+	case n <= 0:
+		return ""
+	case total > 0:
+		return fmt.Sprintf("%d of %d", n, total)
+	}
+	return strconv.Itoa(n)
+}
+
+// formatAdded formats the date a track was added to the library with its age,
+// e.g. "12 Mar 2024 (3mo ago)"
+func formatAdded(added, now time.Time) string {
+	if added.IsZero() {
+		return ""
+	}
+	return added.Format("2 Jan 2006") + " (" + formatAge(added, now) + ")"
+}
+
+func (m trackInspectorModel) lines() []string {
+	switch {
+	case m.loading:
+		return []string{"Loading details of '" + m.track.Name + "'…"}
+	case m.err != nil:
+		return []string{"Couldn't load details of '" + m.track.Name + "':", m.err.Error()}
+	}
+	fields := m.fields()
+	lines := make([]string, len(fields))
+	for i, f := range fields {
+		lines[i] = layout.Pad(f[0]+":", 15) + f[1]
+	}
+	return lines
+}
+
+func (m trackInspectorModel) View() string {
+	overlayWidth := int(float64(m.width) * 0.6)
+	if overlayWidth < 50 {
+		overlayWidth = 50
+	}
+	// Title + separator + lines + spacer + footer, plus borders
+	overlayHeight := len(m.lines()) + 4 + 2
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, m.getContentLine)
+}
+
+func (m trackInspectorModel) getContentLine(lineIndex int, maxWidth int) string {
+	lines := m.lines()
+	switch {
+	case lineIndex == 0:
+		return " Track Details"
+	case lineIndex == 1:
+		return " " + strings.Repeat("─", maxWidth-2)
+	case lineIndex-2 < len(lines):
+		return "  " + lines[lineIndex-2]
+	case lineIndex == len(lines)+3:
+		return " " + locale.T("hint.close")
+	}
+	return ""
+}
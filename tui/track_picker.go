@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/locale"
+)
+
+// Message sent after tracks were added to the queue
+type queueAddedMsg struct {
+	results daemon.QueueAddResults
+	err     error
+}
+
+// Message carrying the library versions of a track that couldn't be added unambiguously
+type trackMatchesMsg struct {
+	track   daemon.Track
+	matches []daemon.Track
+	err     error
+}
+
+// addToQueue appends tracks to the queue, matching by persistent ID where known
+func addToQueue(tracks []daemon.Track) tea.Cmd {
+	return inFlight("Adding to queue", func() tea.Msg {
+		d := daemon.Daemon{}
+		results, err := d.AddToQueue(tracks)
+		return queueAddedMsg{results: results, err: err}
+	})
+}
+
+// fetchTrackMatches looks up every library version of track
+func fetchTrackMatches(track daemon.Track) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		matches, err := d.FindTrackMatches(track)
+		return trackMatchesMsg{track: track, matches: matches, err: err}
+	}
+}
+
+// queueTrackPicks asks which version to add for each of tracks in turn, after any
+// tracks still waiting for a picker
+func (m *Model) queueTrackPicks(tracks []daemon.Track) tea.Cmd {
+	idle := len(m.trackPicks) == 0
+	m.trackPicks = append(m.trackPicks, tracks...)
+	m.trackPicker.remaining = len(m.trackPicks) - 1
+	if !idle {
+		return nil // Asked for once the picker in front of them closes
+	}
+	return fetchTrackMatches(m.trackPicks[0])
+}
+
+// nextTrackPick is done with the track at the head of the picks and looks up the
+// versions of the next one, if any
+func (m *Model) nextTrackPick() tea.Cmd {
+	if len(m.trackPicks) > 0 {
+		m.trackPicks = m.trackPicks[1:]
+	}
+	if len(m.trackPicks) == 0 {
+		return nil
+	}
+	return fetchTrackMatches(m.trackPicks[0])
+}
+
+// trackPickerModel asks which of several library versions of a track to add to the queue
+type trackPickerModel struct {
+	width, height int
+	track         daemon.Track
+	matches       []daemon.Track
+	selected      int
+	remaining     int // Ambiguous tracks still to ask about after this one
+}
+
+func (m trackPickerModel) View() string {
+	overlayWidth := int(float64(m.width) * 0.6)
+	if overlayWidth < 50 {
+		overlayWidth = 50
+	}
+	// Title + separator + matches + spacer + footer, plus borders
+	overlayHeight := len(m.matches) + 4 + 2
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, m.getContentLine)
+}
+
+func (m trackPickerModel) getContentLine(lineIndex int, maxWidth int) string {
+	switch {
+	case lineIndex == 0:
+		title := fmt.Sprintf(" Which version of '%s'?", m.track.Name)
+		if m.remaining > 0 {
+			title += fmt.Sprintf(" (%d more to pick)", m.remaining)
+		}
+		return title
+	case lineIndex == 1:
+		return " " + strings.Repeat("─", maxWidth-2)
+	case lineIndex-2 < len(m.matches):
+		i := lineIndex - 2
+		match := m.matches[i]
+		line := fmt.Sprintf("%s — %s · %s", match.Name, match.Artist, match.Album)
+		if seconds, err := strconv.ParseFloat(strings.Replace(match.Duration, ",", ".", 1), 64); err == nil {
+			line += " · " + formatDuration(int(seconds))
+		}
+		if i == m.selected {
+			return " ▶ " + selectedItemStyle.Render(line)
+		}
+		return "   " + line
+	case lineIndex == len(m.matches)+3:
+		return " " + locale.T("hint.track_picker")
+	}
+	return ""
+}
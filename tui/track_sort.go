@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"main/daemon"
+	"main/locale"
+)
+
+// trackSort is the order songs are listed in the main pane
+type trackSort int
+
+const (
+	sortPlaylistOrder trackSort = iota // As arranged in Music
+	sortDateAdded                      // Most recently added first
+)
+
+// String is the sort order's name in the song list's title, in the interface language
+func (s trackSort) String() string {
+	switch s {
+	case sortDateAdded:
+		return locale.T("sort.date_added")
+	default:
+		return locale.T("sort.playlist_order")
+	}
+}
+
+// next cycles to the following sort mode
+func (s trackSort) next() trackSort {
+	return (s + 1) % 2
+}
+
+// sortedTrackIndices returns indices into tracks in display order for mode,
+// or nil when tracks are shown in playlist order
+func sortedTrackIndices(tracks []daemon.Track, mode trackSort) []int {
+	if mode == sortPlaylistOrder {
+		return nil
+	}
+	indices := make([]int, len(tracks))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		return tracks[indices[a]].Added.After(tracks[indices[b]].Added)
+	})
+	return indices
+}
+
+// formatAge renders how long ago a track was added, e.g. "today", "3d ago", "5mo ago"
+func formatAge(added, now time.Time) string {
+	if added.IsZero() {
+		return "-"
+	}
+	days := int(now.Sub(added).Hours() / 24)
+	switch {
+	case days < 1:
+		return "today"
+	case days < 14:
+		return fmt.Sprintf("%dd ago", days)
+	case days < 60:
+		return fmt.Sprintf("%dw ago", days/7)
+	case days < 365:
+		return fmt.Sprintf("%dmo ago", days/30)
+	default:
+		return fmt.Sprintf("%dy ago", days/365)
+	}
+}
@@ -1,19 +1,27 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"math/rand"
 	"os"
+	"runtime/debug"
 	"slices"
 	"strings"
 	"time"
 
+	"main/config"
 	"main/daemon"
+	"main/layout"
+	"main/locale"
 	"main/lyrics"
 
+	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/mattn/go-runewidth"
+	"github.com/muesli/termenv"
 	"github.com/treilik/bubbleboxer"
 )
 
@@ -30,8 +38,7 @@ const (
 // Component models for bubbleboxer
 type searchHelpModel struct {
 	width, height int
-	searchText    string
-	cursorPos     int
+	input         lineInput
 	searching     bool
 }
 
@@ -39,54 +46,25 @@ func (m searchHelpModel) Init() tea.Cmd {
 	return nil
 }
 
-func (m searchHelpModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
+func (m searchHelpModel) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	defer recoverUpdate("search", m, &model, &cmd)
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 	case tea.KeyMsg:
 		if m.searching {
-			switch msg.String() {
-			case "backspace":
-				if len(m.searchText) > 0 && m.cursorPos > 0 {
-					// Remove character before cursor
-					m.searchText = m.searchText[:m.cursorPos-1] + m.searchText[m.cursorPos:]
-					m.cursorPos--
-				}
-			case "delete":
-				if m.cursorPos < len(m.searchText) {
-					// Remove character at cursor
-					m.searchText = m.searchText[:m.cursorPos] + m.searchText[m.cursorPos+1:]
-				}
-			case "left":
-				if m.cursorPos > 0 {
-					m.cursorPos--
-				}
-			case "right":
-				if m.cursorPos < len(m.searchText) {
-					m.cursorPos++
-				}
-			case "home", "ctrl+a":
-				m.cursorPos = 0
-			case "end", "ctrl+e":
-				m.cursorPos = len(m.searchText)
-			default:
-				// Insert regular characters
-				if len(msg.String()) == 1 && msg.String() != "\n" && msg.String() != "\r" {
-					if len(m.searchText) < 156 { // Character limit
-						// Insert character at cursor position
-						m.searchText = m.searchText[:m.cursorPos] + msg.String() + m.searchText[m.cursorPos:]
-						m.cursorPos++
-					}
-				}
+			before := m.input
+			if m.input.update(msg) && len([]rune(m.input.text)) > searchCharLimit {
+				m.input = before
 			}
 		}
 	}
 
 	return m, cmd
 }
-func (m searchHelpModel) View() string {
+func (m searchHelpModel) View() (view string) {
+	defer recoverView("search", m.width, m.height, &view)
 	// Ensure we have valid dimensions
 	if m.height <= 0 || m.width <= 0 {
 		return ""
@@ -96,33 +74,12 @@ func (m searchHelpModel) View() string {
 	lines = append(lines, titleStyle.Render("Search"))
 	lines = append(lines, "")
 	if m.searching {
-		// Create custom search input display
-		var searchDisplay strings.Builder
-		if len(m.searchText) == 0 {
-			// Show placeholder when empty
-			searchDisplay.WriteString("Search...")
-		} else {
-			// Show actual text with cursor
-			for i, char := range m.searchText {
-				if i == m.cursorPos && m.searching {
-					// Show cursor as underscore before character
-					searchDisplay.WriteString("_")
-				}
-				searchDisplay.WriteRune(char)
-			}
-			// Show cursor at end if needed
-			if m.cursorPos >= len(m.searchText) {
-				searchDisplay.WriteString("_")
-			}
-		}
-
 		// Wrap in simple brackets to indicate input field
-		searchLine := "[" + searchDisplay.String() + "]"
-		lines = append(lines, searchLine)
+		lines = append(lines, "["+searchInputView(m.input, max(m.width-2, 1))+"]")
 	} else {
-		lines = append(lines, "[Search box]")
+		lines = append(lines, locale.T("search.placeholder"))
 	}
-	lines = append(lines, "Help: / search • Esc cancel")
+	lines = append(lines, locale.T("search.help"))
 
 	// Limit lines to fit within height constraint
 	maxLines := m.height
@@ -143,13 +100,7 @@ func (m searchHelpModel) View() string {
 		}
 
 		// Truncate line if too long
-		if len(line) > m.width {
-			if m.width > 3 {
-				line = line[:m.width-3] + "..."
-			} else if m.width > 0 {
-				line = line[:m.width]
-			}
-		}
+		line = layout.Truncate(line, m.width, "...")
 
 		content.WriteString(line)
 		if i < maxLines-1 {
@@ -168,6 +119,71 @@ type playlistsModel struct {
 	scrollOffset  int
 	playlistItems []string
 	lastError     error
+	smartItems    map[string]bool // Smart/Genius playlists, known once the full playlist data is loaded
+	stats         map[string]string // "(42 · 2h58m)" per playlist, known once the full playlist data is loaded
+	showStats     bool
+	stations      []config.StationConfig // Listed in their own section after the playlists
+	// Library tab shown instead of the playlists, with its albums, artists or songs entry
+	tab          libraryTab
+	tabItems     []string
+	tabActive    map[libraryTab]int // activeItem of the tabs not shown
+	libraryError error
+	// Playlists the background fetch has loaded, shown as a progress bar at the bottom
+	// until it's done
+	loaded, loadTotal int
+	// The start of a playlist name typed after the jump key, see updateJump
+	jumpPrefix string
+	jumping    bool
+}
+
+// itemName is the name shown for sidebar item i
+func (m playlistsModel) itemName(i int) string {
+	if station, ok := m.station(i); ok {
+		return station.Name
+	}
+	items := m.playlistItems
+	if m.tab != tabPlaylists {
+		items = m.tabItems
+	}
+	if i < 0 || i >= len(items) {
+		return ""
+	}
+	return items[i]
+}
+
+// itemCount is the number of selectable sidebar items: playlists, then stations
+func (m playlistsModel) itemCount() int {
+	if m.tab != tabPlaylists {
+		return len(m.tabItems)
+	}
+	return len(m.playlistItems) + len(m.stations)
+}
+
+// station returns the station at sidebar index i, if i is past the playlists
+func (m playlistsModel) station(i int) (config.StationConfig, bool) {
+	if m.tab != tabPlaylists {
+		return config.StationConfig{}, false
+	}
+	i -= len(m.playlistItems)
+	if i < 0 || i >= len(m.stations) {
+		return config.StationConfig{}, false
+	}
+	return m.stations[i], true
+}
+
+// visibleItems is how many sidebar items fit below the title
+func (m playlistsModel) visibleItems() int {
+	visible := m.height - 2 // Title + empty line
+	if m.loadingPlaylists() {
+		visible-- // Progress bar
+	}
+	if m.tab == tabPlaylists && len(m.stations) > 0 {
+		visible-- // "Stations" section title
+	}
+	if m.itemCount() > visible {
+		visible-- // Make space for scrollbar
+	}
+	return max(visible, 0)
 }
 
 type playlistsMsg struct {
@@ -181,17 +197,16 @@ type allPlaylistsMsg struct {
 	err       error
 }
 
-func fetchPlaylists() tea.Msg {
+func fetchPlaylists() (msg tea.Msg) {
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Printf("PANIC in fetchPlaylists: %v\n", r)
+			msg = notifyError("Error loading playlists: %v", r)
 		}
 	}()
 
 	d := daemon.Daemon{}
 	playlists, err := d.GetAllPlaylistNames()
 	if err != nil {
-		fmt.Printf("Error in fetchPlaylists: %v\n", err)
 		return playlistsMsg{playlists: nil, err: err}
 	}
 
@@ -206,29 +221,70 @@ func fetchPlaylists() tea.Msg {
 	return playlistsMsg{playlists: playlists, err: err}
 }
 
-// fetchAllPlaylists runs in a goroutine to fetch all playlist data with tracks
+// formatPlaylistStats summarizes a playlist as "(42 · 2h58m)" for the sidebar
+func formatPlaylistStats(playlist daemon.Playlist) string {
+	var seconds float64
+	for _, track := range playlist.Tracks {
+		var duration float64
+		if _, err := fmt.Sscanf(track.Duration, "%f", &duration); err == nil {
+			seconds += duration
+		}
+	}
+
+	minutes := int(seconds) / 60
+	length := fmt.Sprintf("%dm", minutes)
+	if minutes >= 60 {
+		length = fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+	}
+	return fmt.Sprintf("(%d · %s)", len(playlist.Tracks), length)
+}
+
+// Message sent after each playlist the background fetch loads, followed by more of
+// them and finally allPlaylistsMsg on updates
+type playlistProgressMsg struct {
+	loaded, total int
+	updates       <-chan tea.Msg
+}
+
+// fetchAllPlaylists runs in a goroutine to fetch all playlist data with tracks,
+// reporting its progress along the way
 func fetchAllPlaylists() tea.Cmd {
 	return func() tea.Msg {
-		d := daemon.Daemon{}
-		playlists, err := d.GetAllPlaylists()
-		if err != nil {
-			return allPlaylistsMsg{playlists: nil, err: err}
-		}
+		updates := make(chan tea.Msg)
+		go func() {
+			d := daemon.Daemon{}
+			playlists, err := d.GetAllPlaylistsWithProgress(func(loaded, total int) {
+				updates <- playlistProgressMsg{loaded: loaded, total: total, updates: updates}
+			})
+			if err != nil {
+				updates <- allPlaylistsMsg{playlists: nil, err: err}
+				return
+			}
 
-		// Convert slice to map for quick lookup
-		playlistMap := make(map[string]daemon.Playlist)
-		for _, playlist := range playlists {
-			playlistMap[playlist.Name] = playlist
-		}
+			// Convert slice to map for quick lookup
+			playlistMap := make(map[string]daemon.Playlist)
+			for _, playlist := range playlists {
+				playlistMap[playlist.Name] = playlist
+			}
+
+			updates <- allPlaylistsMsg{playlists: playlistMap, err: nil}
+		}()
+		return <-updates
+	}
+}
 
-		return allPlaylistsMsg{playlists: playlistMap, err: nil}
+// waitForPlaylistProgress waits for the next message of a running fetchAllPlaylists
+func waitForPlaylistProgress(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
 	}
 }
 
 func (m playlistsModel) Init() tea.Cmd {
 	return fetchPlaylists
 }
-func (m playlistsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+func (m playlistsModel) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	defer recoverUpdate("playlists", m, &model, &cmd)
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -239,7 +295,8 @@ func (m playlistsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 	return m, nil
 }
-func (m playlistsModel) View() string {
+func (m playlistsModel) View() (view string) {
+	defer recoverView("playlists", m.width, m.height, &view)
 	// Ensure we have valid dimensions
 	if m.height <= 0 || m.width <= 0 {
 		return ""
@@ -247,61 +304,82 @@ func (m playlistsModel) View() string {
 
 	// Use cached playlists if available, otherwise show error
 	playlistItems := m.playlistItems
+	title := tabBar(m.tab, m.width)
+	if m.tab != tabPlaylists {
+		playlistItems = m.tabItems
+		if m.libraryError != nil {
+			return title + "\n\n" + layout.Truncate(fmt.Sprintf("Error: %v", m.libraryError), m.width, "...")
+		}
+		if playlistItems == nil {
+			return title + "\n\n" + locale.T("loading.library")
+		}
+		if len(playlistItems) == 0 {
+			return title + "\n\n" + locale.T("library.empty")
+		}
+	}
 	if m.lastError != nil {
 		// Return simple error message
-		errorMsg := fmt.Sprintf("Error: %v", m.lastError)
-		if len(errorMsg) > m.width {
-			if m.width > 3 {
-				errorMsg = errorMsg[:m.width-3] + "..."
-			} else {
-				errorMsg = errorMsg[:m.width]
-			}
-		}
-		return errorMsg
+		return layout.Truncate(fmt.Sprintf("Error: %v", m.lastError), m.width, "...")
 	}
 	if len(playlistItems) == 0 {
-		return titleStyle.Render("Playlists") + "\n\nLoading..."
+		return title + "\n\n" + locale.T("loading")
 	}
 
 	// Build all lines first
 	var allLines []string
-	allLines = append(allLines, titleStyle.Render("Playlists"))
-	allLines = append(allLines, "")
+	allLines = append(allLines, title)
+	if m.jumping {
+		allLines = append(allLines, layout.Truncate(statusMutedStyle.Render("jump to: ")+m.jumpPrefix+"_", m.width, "..."))
+	} else {
+		allLines = append(allLines, "")
+	}
 
 	// Calculate how many items can be displayed (reserve space for header + empty line)
-	headerLines := 2
-	visibleItems := m.height - headerLines
-
-	if len(m.playlistItems) > visibleItems {
-		visibleItems-- // Make space for scrollbar
-	}
-	if visibleItems < 0 {
-		visibleItems = 0
-	}
+	visibleItems := m.visibleItems()
 
 	// Calculate scroll bounds
 	startIdx := m.scrollOffset
 	endIdx := startIdx + visibleItems
-	if endIdx > len(playlistItems) {
-		endIdx = len(playlistItems)
+	if endIdx > m.itemCount() {
+		endIdx = m.itemCount()
 	}
 
-	// Add visible playlist items
+	// Add visible playlist items, followed by the stations section
 	for i := startIdx; i < endIdx; i++ {
-		item := playlistItems[i]
+		var item string
+		isStation := false
+		if station, ok := m.station(i); ok {
+			if i == len(playlistItems) || i == startIdx {
+				allLines = append(allLines, titleStyle.Render("Stations"))
+			}
+			item = station.Name
+			isStation = true
+		} else {
+			item = playlistItems[i]
+		}
 
 		// Calculate available space for the playlist name (accounting for prefix and ellipsis)
 		availableWidth := m.width - 2 // "  " or "> " prefix
+		isPlaylist := !isStation && m.tab == tabPlaylists
+		isSmart := isPlaylist && m.smartItems[item]
+		if isSmart {
+			availableWidth -= layout.Width(smartPlaylistMarker)
+		}
+		// Only show stats when they leave room for a readable name
+		stats := ""
+		if m.showStats && isPlaylist && m.stats[item] != "" {
+			stats = " " + m.stats[item]
+			if availableWidth-layout.Width(stats) < 8 {
+				stats = ""
+			}
+			availableWidth -= layout.Width(stats)
+		}
 		if availableWidth < 1 {
 			availableWidth = 1
 		}
 
 		// Truncate the item name first, before applying any styling
-		truncatedItem := item
-		if runewidth.StringWidth(item) > availableWidth {
-			// Reserve space for ellipsis
-			truncatedItem = runewidth.Truncate(item, availableWidth-3, "...")
-		}
+		truncatedItem := layout.Truncate(item, availableWidth, "...")
 
 		// Now apply styling only to the (possibly truncated) playlist name
 		var line string
@@ -314,13 +392,20 @@ func (m playlistsModel) View() string {
 		} else {
 			line = "  " + truncatedItem
 		}
+		// Label smart/genius playlists so it's clear they're read-only
+		if isSmart {
+			line += smartPlaylistStyle.Render(smartPlaylistMarker)
+		}
+		if stats != "" {
+			line += playlistStatsStyle.Render(stats)
+		}
 
 		allLines = append(allLines, line)
 	}
 
 	// Add scroll indicator if there are more items
-	if len(m.playlistItems) > visibleItems && len(allLines) < m.height {
-		scrollInfo := fmt.Sprintf("[%d/%d]", m.selectedItem+1, len(m.playlistItems))
+	if m.itemCount() > visibleItems && len(allLines) < m.height {
+		scrollInfo := fmt.Sprintf("[%d/%d]", m.selectedItem+1, m.itemCount())
 		allLines = append(allLines, scrollInfo)
 	}
 
@@ -330,6 +415,15 @@ func (m playlistsModel) View() string {
 		maxLines = len(allLines)
 	}
 
+	// Keep the progress bar at the bottom of the sidebar
+	if m.loadingPlaylists() && m.height > 3 {
+		for len(allLines) < m.height-1 {
+			allLines = append(allLines, "")
+		}
+		allLines = append(allLines[:m.height-1], m.renderLoadProgress())
+		maxLines = m.height
+	}
+
 	var content strings.Builder
 	for i := 0; i < maxLines; i++ {
 		if i < len(allLines) {
@@ -343,25 +437,92 @@ func (m playlistsModel) View() string {
 	return content.String()
 }
 
+// loadingPlaylists tells whether the background fetch of every playlist's songs is running
+func (m playlistsModel) loadingPlaylists() bool {
+	return m.tab == tabPlaylists && m.loadTotal > 0 && m.loaded < m.loadTotal
+}
+
+// renderLoadProgress draws "12/80 ████░░░░" for the background fetch to fit the sidebar
+func (m playlistsModel) renderLoadProgress() string {
+	label := fmt.Sprintf("%d/%d ", m.loaded, m.loadTotal)
+	barWidth := m.width - layout.Width(label)
+	if barWidth < 4 {
+		return layout.Truncate(label, m.width, "")
+	}
+	filled := barWidth * m.loaded / m.loadTotal
+	return playlistStatsStyle.Render(label) + activeItemStyle.Render(strings.Repeat("█", filled)) +
+		playlistStatsStyle.Render(strings.Repeat("░", barWidth-filled))
+}
+
 type mainContentModel struct {
 	width, height   int
 	focused         bool
 	currentPlaylist string
 	cachedAsciiArt  []string // Cache ASCII art to prevent reshuffling
+	artwork         *coverArt // Cover of the playing track, shown instead of the ASCII art
 	// Add references to the main model's cache and loading state
 	playlistCache    *map[string]daemon.Playlist
 	playlistsLoading *bool
 	// Song selection state
-	selectedSong int
+	selectedSong int // Row in the song list, see trackIndex for the playlist position
 	scrollOffset int
-	// Search results
-	searchResults []daemon.Track
-	searchQuery   string
-	isSearchMode  bool
+	// Columns of the song list from [[ui.columns]] or :columns, the optional "Added"
+	// column and the sort order
+	columns       []tableColumn
+	customColumns bool // Columns were picked with :columns for this playlist
+	showAdded     bool
+	sortMode      trackSort
+	// Text the song list is narrowed to, typed after "f" while filtering is set
+	filter    string
+	filtering bool
+	// Pattern highlighted in the song list, typed after "/" while finding is set, and
+	// the row selected when typing began
+	find       string
+	finding    bool
+	findOrigin int
+	// The filter or pattern being typed, with its cursor
+	prompt lineInput
+	// Persistent ID of the track Music is playing, flagged in the song list
+	playing string
+	// Rows between visualAnchor and the selected song are marked for bulk actions while
+	// visual is set
+	visual       bool
+	visualAnchor int
+	// Search results: the songs, and everything the search found by category
+	searchResults  []daemon.Track
+	searchQuery    string
+	searchHits     daemon.SearchResults
+	searchCategory searchCategory
+	// Album, artist or all songs opened from a library tab and shown in place of search
+	// results, "" for an actual search
+	collection string
+	// Tab the song list belongs to, viewSearch for search results and what was opened
+	// from them
+	view viewTab
+}
+
+// trackIndex maps a row in the song list to the track's index in the current playlist
+func (m mainContentModel) trackIndex(row int) int {
+	if m.sortMode == sortPlaylistOrder || m.playlistCache == nil {
+		return row
+	}
+	playlist, exists := (*m.playlistCache)[m.currentPlaylist]
+	if !exists {
+		return row
+	}
+	order := m.rowOrder(playlist.Tracks)
+	if order == nil {
+		return row
+	}
+	if row < 0 || row >= len(order) {
+		return -1 // Past the end of a filtered list
+	}
+	return order[row]
 }
 
 func (m mainContentModel) Init() tea.Cmd { return nil }
-func (m mainContentModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+func (m mainContentModel) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	defer recoverUpdate("main", m, &model, &cmd)
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -369,14 +530,15 @@ func (m mainContentModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 	return m, nil
 }
-func (m mainContentModel) View() string {
+func (m mainContentModel) View() (view string) {
+	defer recoverView("main", m.width, m.height, &view)
 	// Ensure we have valid dimensions
 	if m.height <= 0 || m.width <= 0 {
 		return ""
 	}
 
-	// If in search mode, show search results
-	if m.isSearchMode {
+	// Search results, or an album, artist or all songs
+	if m.showsResults() {
 		return m.renderSearchResults()
 	}
 
@@ -387,6 +549,14 @@ func (m mainContentModel) View() string {
 		if len(asciiLines) == 0 {
 			asciiLines = getRandomAsciiArt()
 		}
+		// The playing track's cover, when there is one, replaces the ASCII art
+		showingArt := false
+		if artRows := min(m.height-2, 16, (m.width-1)/2); artRows >= 4 {
+			if art := m.artwork.lines(artworkSlotMain, artRows*2, artRows); len(art) > 0 {
+				asciiLines = art
+				showingArt = true
+			}
+		}
 
 		// Build complete content with header
 		allLines := append([]string{titleStyle.Render("Apple Music TUI"), ""}, asciiLines...)
@@ -410,13 +580,10 @@ func (m mainContentModel) View() string {
 				line = " " // Empty line with padding
 			}
 
-			// Truncate line if too long for the width
-			if len(line) > m.width {
-				if m.width > 3 {
-					line = line[:m.width-3] + "..."
-				} else if m.width > 0 {
-					line = line[:m.width]
-				}
+			// Truncate line if too long for the width (artwork is sized to fit and
+			// carries escape sequences that must not be cut)
+			if !(showingArt && i >= 2) {
+				line = layout.Truncate(line, m.width, "...")
 			}
 
 			content.WriteString(line)
@@ -432,7 +599,7 @@ func (m mainContentModel) View() string {
 
 	// Check if playlists are still loading
 	if m.playlistsLoading != nil && *m.playlistsLoading {
-		return " " + titleStyle.Render(m.currentPlaylist) + "\n\n Loading songs..."
+		return " " + titleStyle.Render(m.currentPlaylist) + "\n\n " + locale.T("loading.songs")
 	}
 
 	// Get playlist data from cache
@@ -445,91 +612,57 @@ func (m mainContentModel) View() string {
 			d := &daemon.Daemon{}
 			playlist, err := d.GetPlaylist(m.currentPlaylist)
 			if err != nil {
-				return " " + titleStyle.Render(m.currentPlaylist) + "\n\n" + fmt.Sprintf("Error fetching playlist: %v", err)
+				return " " + titleStyle.Render(m.currentPlaylist) + "\n\n" + locale.T("songs.fetch_error", err)
 			}
 			tracks = playlist.Tracks
 		}
 	} else {
-		return " " + titleStyle.Render(m.currentPlaylist) + "\n\n Playlist cache not available."
+		return " " + titleStyle.Render(m.currentPlaylist) + "\n\n " + locale.T("songs.no_cache")
 	}
 
 	if len(tracks) == 0 {
-		return " " + titleStyle.Render(m.currentPlaylist) + "\n\n No tracks found in this playlist."
+		return " " + titleStyle.Render(m.currentPlaylist) + "\n\n " + locale.T("songs.empty")
 	}
 
 	// Build the table
 	var content strings.Builder
 
-	// Add title
-	content.WriteString(" " + titleStyle.Render(m.currentPlaylist) + "\n")
-
-	// Calculate column widths based on available space
-	// Reserve space for left padding (1) + separators between columns (3 spaces)
-	durationWidth := 5 // Fixed 5 chars for duration (e.g., "3:45") - very conservative
-
-	// Account for: left padding + 3 spaces between columns + duration width
-	// Subtract 8 characters for safety margin to prevent bubbleboxer errors
-	availableWidth := m.width - 1 - 3 - durationWidth - 8
-	if availableWidth < 10 {
-		availableWidth = 10 // Very conservative minimum
+	// Add title, labelling read-only smart playlists
+	title := " " + titleStyle.Render(m.currentPlaylist)
+	if playlist, exists := (*m.playlistCache)[m.currentPlaylist]; exists && playlist.Smart {
+		label := smartPlaylistMarker + " " + locale.T("songs.smart")
+		if layout.Width(m.currentPlaylist+label) < m.width-1 {
+			title += smartPlaylistStyle.Render(label)
+		}
 	}
-
-	// Column width distribution (flexible based on content)
-	nameWidth := availableWidth * 40 / 100   // 40% for name
-	artistWidth := availableWidth * 30 / 100 // 30% for artist
-	albumWidth := availableWidth * 30 / 100  // 30% for album
-
-	// Ensure minimum widths
-	minNameWidth := 8
-	minArtistWidth := 6
-	minAlbumWidth := 6
-
-	if nameWidth < minNameWidth {
-		nameWidth = minNameWidth
+	if m.sortMode != sortPlaylistOrder {
+		label := " · " + locale.T("songs.sorted_by", m.sortMode.String())
+		if layout.Width(title+label) < m.width-1 {
+			title += smartPlaylistStyle.Render(label)
+		}
 	}
-	if artistWidth < minArtistWidth {
-		artistWidth = minArtistWidth
+	order := m.rowOrder(tracks)
+	rowCount := m.rowCount(tracks)
+	if m.filtering || m.filter != "" {
+		label := " · " + locale.T("songs.filter", m.filter)
+		if m.filtering {
+			label = " · " + locale.T("songs.filter", m.prompt.View())
+		} else {
+			label += fmt.Sprintf(" (%d/%d)", rowCount, len(tracks))
+		}
+		title += " " + layout.Truncate(label, m.width-2-layout.Width(title), "...")
 	}
-	if albumWidth < minAlbumWidth {
-		albumWidth = minAlbumWidth
+	if label := m.findLabel(tracks); label != "" {
+		title += " " + layout.Truncate(label, m.width-2-layout.Width(title), "...")
 	}
-
-	// Final check: ensure total doesn't exceed available space
-	totalNeeded := 1 + nameWidth + 1 + artistWidth + 1 + albumWidth + 1 + durationWidth // padding + columns + spaces
-	if totalNeeded > m.width {
-		// Reduce all flexible columns proportionally but protect duration
-		excess := totalNeeded - m.width
-		flexibleTotal := nameWidth + artistWidth + albumWidth
-		if flexibleTotal > excess {
-			reduction := float64(excess) / float64(flexibleTotal)
-			nameWidth = nameWidth - int(float64(nameWidth)*reduction)
-			artistWidth = artistWidth - int(float64(artistWidth)*reduction)
-			albumWidth = albumWidth - int(float64(albumWidth)*reduction)
-
-			// Enforce absolute minimums
-			if nameWidth < 4 {
-				nameWidth = 4
-			}
-			if artistWidth < 4 {
-				artistWidth = 4
-			}
-			if albumWidth < 4 {
-				albumWidth = 4
-			}
-		}
+	if label := m.visualLabel(); label != "" && layout.Width(title+label) < m.width-1 {
+		title += titleStyle.Render(label)
+	}
+	content.WriteString(title + "\n")
+	if rowCount == 0 {
+		content.WriteString("\n " + locale.T("songs.no_match", m.filter))
+		return content.String()
 	}
-
-	// Table header (remove lipgloss styling to avoid width interference)
-	header := fmt.Sprintf(" %-*s %-*s %-*s %*s",
-		nameWidth, "Name",
-		artistWidth, "Artist",
-		albumWidth, "Album",
-		durationWidth, "Duration")
-	content.WriteString(header + "\n")
-
-	// Add a separator line
-	separator := strings.Repeat("─", m.width-2)
-	content.WriteString(" " + separator + "\n")
 
 	// Calculate visible tracks (reserve space for header + separator + title)
 	headerLines := 3 // title + header + separator
@@ -541,68 +674,54 @@ func (m mainContentModel) View() string {
 	// Handle scrolling
 	startIdx := m.scrollOffset
 	endIdx := startIdx + visibleTracks
-	if endIdx > len(tracks) {
-		endIdx = len(tracks)
+	if endIdx > rowCount {
+		endIdx = rowCount
 	}
-
-	// Add track rows
+	visible := make([]daemon.Track, 0, endIdx-startIdx)
 	for i := startIdx; i < endIdx; i++ {
-		track := tracks[i]
-
-		// Format duration (convert from seconds string to mm:ss)
-		durationStr := "0:00"
-		if track.Duration != "" {
-			var seconds float64
-			if n, err := fmt.Sscanf(track.Duration, "%f", &seconds); err == nil && n > 0 {
-				minutes := int(seconds) / 60
-				secs := int(seconds) % 60
-				durationStr = fmt.Sprintf("%d:%02d", minutes, secs)
-			} else {
-				durationStr = "0:00"
-			}
-		}
-
-		// Truncate fields to fit in their columns using proper Unicode width handling
-		name := track.Name
-		if runewidth.StringWidth(name) > nameWidth {
-			name = runewidth.Truncate(name, nameWidth, "...")
-		}
-
-		artist := track.Artist
-		if runewidth.StringWidth(artist) > artistWidth {
-			artist = runewidth.Truncate(artist, artistWidth, "...")
+		if order != nil {
+			visible = append(visible, tracks[order[i]])
+		} else {
+			visible = append(visible, tracks[i])
 		}
+	}
 
-		album := track.Album
-		if runewidth.StringWidth(album) > albumWidth {
-			album = runewidth.Truncate(album, albumWidth, "...")
+	// Lay out the configured columns across the available space, with a separator
+	// under the header, which stays put as the rows scroll
+	header, rows := songTable(shownColumns(m.columns, m.showAdded), m.width, visible, time.Now())
+	content.WriteString(header + "\n")
+	content.WriteString(" " + strings.Repeat("─", m.width-2) + "\n")
+
+	for n, track := range visible {
+		i := startIdx + n
+
+		// Apply selection styling if this row is selected and main content is focused,
+		// and flag rows marked in visual mode, the playing track and rows matching the
+		// pattern found with "/"
+		row := m.markRow(rows[n], i)
+		playing := m.isPlaying(track)
+		if playing {
+			row = playingRow(row)
 		}
-
-		// Format the row using Unicode-aware padding
-		row := fmt.Sprintf(" %s %s %s %s",
-			padRight(name, nameWidth),
-			padRight(artist, artistWidth),
-			padRight(album, albumWidth),
-			padLeft(durationStr, durationWidth))
-
-		// Apply selection styling if this row is selected and main content is focused
 		if i == m.selectedSong && m.focused {
 			row = selectedSongStyle.Render(row)
+		} else if m.marked(i) && m.focused {
+			row = markedSongStyle.Render(row)
+		} else if m.findMatches(track) {
+			row = highlightFind(row, m.find)
+		} else if playing {
+			row = playingTrackStyle.Render(row)
 		}
 
-		// Final safety check: ensure row doesn't exceed width
-		if len(row) > m.width {
-			row = row[:m.width-1] // Truncate with 1 char safety margin
+		// Dim tracks that can't be played (styled after layout so escapes stay intact)
+		if track.Unavailable() && !(i == m.selectedSong && m.focused) && !(m.marked(i) && m.focused) {
+			row = unavailableTrackStyle.Render(row)
 		}
 
-		content.WriteString(row + "\n")
+		rows[n] = row
 	}
-
-	// Add scroll indicator if needed (only if we have space)
-	totalLinesUsed := headerLines + (endIdx - startIdx)
-	if len(tracks) > visibleTracks && totalLinesUsed < m.height-1 {
-		scrollInfo := fmt.Sprintf(" [%d/%d songs]", m.selectedSong+1, len(tracks))
-		content.WriteString("\n" + scrollInfo)
+	for _, row := range withScrollbar(rows, m.width, rowCount, startIdx) {
+		content.WriteString(row + "\n")
 	}
 
 	// Only ensure we don't exceed the height limit
@@ -622,76 +741,32 @@ func (m mainContentModel) renderSearchResults() string {
 	// Build the table for search results
 	var content strings.Builder
 
-	// Add title
-	title := fmt.Sprintf("Search Results for: \"%s\"", m.searchQuery)
-	content.WriteString(" " + titleStyle.Render(title) + "\n")
-
-	if len(m.searchResults) == 0 {
-		content.WriteString("\n No results found.")
-		return content.String()
-	}
-
-	// Calculate column widths - same logic as playlist view
-	durationWidth := 5
-	availableWidth := m.width - 1 - 3 - durationWidth - 8
-	if availableWidth < 10 {
-		availableWidth = 10
-	}
-
-	nameWidth := availableWidth * 40 / 100
-	artistWidth := availableWidth * 30 / 100
-	albumWidth := availableWidth * 30 / 100
-
-	// Ensure minimum widths
-	minNameWidth := 8
-	minArtistWidth := 6
-	minAlbumWidth := 6
-
-	if nameWidth < minNameWidth {
-		nameWidth = minNameWidth
+	// Add title, with the result categories after the query of an actual search
+	title := locale.T("songs.results_for", m.searchQuery)
+	if m.collection != "" {
+		title = m.collection
+		if m.searchQuery != "" {
+			title += " · " + m.searchQuery
+		}
 	}
-	if artistWidth < minArtistWidth {
-		artistWidth = minArtistWidth
+	title = " " + titleStyle.Render(title)
+	if m.collection == "" {
+		query := titleStyle.Render(layout.Truncate(fmt.Sprintf("%q", m.searchQuery), max(m.width/3, 8), "..."))
+		title = " " + query + " " + m.searchCategoryBar(m.width-3-layout.Width(query))
+		if m.showsSearchHits() {
+			return m.renderSearchHits(title)
+		}
 	}
-	if albumWidth < minAlbumWidth {
-		albumWidth = minAlbumWidth
+	if label := m.visualLabel(); label != "" && layout.Width(title+label) < m.width-1 {
+		title += titleStyle.Render(label)
 	}
+	content.WriteString(title + "\n")
 
-	// Final check: ensure total doesn't exceed available space
-	totalNeeded := 1 + nameWidth + 1 + artistWidth + 1 + albumWidth + 1 + durationWidth
-	if totalNeeded > m.width {
-		excess := totalNeeded - m.width
-		flexibleTotal := nameWidth + artistWidth + albumWidth
-		if flexibleTotal > excess {
-			reduction := float64(excess) / float64(flexibleTotal)
-			nameWidth = nameWidth - int(float64(nameWidth)*reduction)
-			artistWidth = artistWidth - int(float64(artistWidth)*reduction)
-			albumWidth = albumWidth - int(float64(albumWidth)*reduction)
-
-			if nameWidth < 4 {
-				nameWidth = 4
-			}
-			if artistWidth < 4 {
-				artistWidth = 4
-			}
-			if albumWidth < 4 {
-				albumWidth = 4
-			}
-		}
+	if len(m.searchResults) == 0 {
+		content.WriteString("\n " + locale.T("songs.no_results"))
+		return content.String()
 	}
 
-	// Table header
-	header := fmt.Sprintf(" %-*s %-*s %-*s %*s",
-		nameWidth, "Name",
-		artistWidth, "Artist",
-		albumWidth, "Album",
-		durationWidth, "Duration")
-	content.WriteString(header + "\n")
-
-	// Add a separator line
-	separator := strings.Repeat("─", m.width-2)
-	content.WriteString(" " + separator + "\n")
-
 	// Calculate visible tracks
 	headerLines := 3 // title + header + separator
 	visibleTracks := m.height - headerLines
@@ -705,65 +780,35 @@ func (m mainContentModel) renderSearchResults() string {
 	if endIdx > len(m.searchResults) {
 		endIdx = len(m.searchResults)
 	}
-
-	// Add track rows
-	for i := startIdx; i < endIdx; i++ {
-		track := m.searchResults[i]
-
-		// Format duration
-		durationStr := "0:00"
-		if track.Duration != "" {
-			var seconds float64
-			if n, err := fmt.Sscanf(track.Duration, "%f", &seconds); err == nil && n > 0 {
-				minutes := int(seconds) / 60
-				secs := int(seconds) % 60
-				durationStr = fmt.Sprintf("%d:%02d", minutes, secs)
-			} else {
-				durationStr = "0:00"
-			}
-		}
-
-		// Truncate fields to fit in their columns
-		name := track.Name
-		if runewidth.StringWidth(name) > nameWidth {
-			name = runewidth.Truncate(name, nameWidth, "...")
-		}
-
-		artist := track.Artist
-		if runewidth.StringWidth(artist) > artistWidth {
-			artist = runewidth.Truncate(artist, artistWidth, "...")
+	visible := make([]daemon.Track, 0, endIdx-startIdx)
+	for _, track := range m.searchResults[startIdx:endIdx] {
+		if !loaded(track) {
+			track.Name = locale.T("loading") // Its page of the library is still on the way
 		}
+		visible = append(visible, track)
+	}
 
-		album := track.Album
-		if runewidth.StringWidth(album) > albumWidth {
-			album = runewidth.Truncate(album, albumWidth, "...")
-		}
+	// Lay out columns the same as the playlist view
+	header, rows := songTable(shownColumns(m.columns, m.showAdded), m.width, visible, time.Now())
+	content.WriteString(header + "\n")
+	content.WriteString(" " + strings.Repeat("─", m.width-2) + "\n")
 
-		// Format the row
-		row := fmt.Sprintf(" %s %s %s %s",
-			padRight(name, nameWidth),
-			padRight(artist, artistWidth),
-			padRight(album, albumWidth),
-			padLeft(durationStr, durationWidth))
+	for n := range rows {
+		i := startIdx + n
 
-		// Apply selection styling if this row is selected and main content is focused
+		// Apply selection styling if this row is selected and main content is focused,
+		// and flag rows marked in visual mode
+		row := m.markRow(rows[n], i)
 		if i == m.selectedSong && m.focused {
 			row = selectedSongStyle.Render(row)
+		} else if m.marked(i) && m.focused {
+			row = markedSongStyle.Render(row)
 		}
 
-		// Final safety check
-		if len(row) > m.width {
-			row = row[:m.width-1]
-		}
-
-		content.WriteString(row + "\n")
+		rows[n] = row
 	}
-
-	// Add scroll indicator if needed
-	totalLinesUsed := headerLines + (endIdx - startIdx)
-	if len(m.searchResults) > visibleTracks && totalLinesUsed < m.height-1 {
-		scrollInfo := fmt.Sprintf(" [%d/%d results]", m.selectedSong+1, len(m.searchResults))
-		content.WriteString("\n" + scrollInfo)
+	for _, row := range withScrollbar(rows, m.width, len(m.searchResults), startIdx) {
+		content.WriteString(row + "\n")
 	}
 
 	// Ensure we don't exceed height limit
@@ -780,13 +825,45 @@ func (m mainContentModel) renderSearchResults() string {
 type playbackModel struct {
 	width, height int
 	status        daemon.PlaybackStatus
-	lastUpdate    time.Time
+	lastUpdate    time.Time // When status.Position was read, see position
+	lastFull      time.Time // When the full status was last fetched, see pollPlaybackStatus
+	// When shuffle, repeat or volume was last changed from amtui, see reconcileControls
+	controlsChanged time.Time
+	artwork         *coverArt     // Cover of the playing track, nil when it has none
+	pollInterval    time.Duration // From [playback] poll_interval
+	remaining       bool          // Show the time left instead of elapsed/total, toggled with "t"
+	framing         bool          // Redraws of the progress bar are coming, see startFrames
+	// Polling slows down while idle, see nextPollInterval. pollSeq numbers the chain of
+	// polls so one brought forward replaces the one waited for.
+	pollSeq         int
+	pollDelay       time.Duration // Wait before the poll scheduled last
+	polling         bool          // A poll is on its way
+	backingOff      bool          // Waiting out the daemon's circuit breaker
+	blurred         bool          // The terminal is in the background
+	lastInteraction time.Time     // Last key or click
+	steady          bool          // Poll at pollInterval even while idle, as in safe mode
 }
 
 // Message type for playback status updates
 type playbackStatusMsg struct {
 	status daemon.PlaybackStatus
-	err    error
+	full   bool // Everything was refreshed, not just the position
+	// A one-off refresh after a change made from amtui; the poll keeps its own schedule
+	refresh bool
+	err     error
+}
+
+// Message sent after the shuffle mode was changed
+type shuffleModeMsg struct {
+	mode daemon.ShuffleMode
+	err  error
+}
+
+// cycleShuffleMode switches Music to the next shuffle mode
+func cycleShuffleMode() tea.Msg {
+	d := daemon.Daemon{}
+	mode, err := d.CycleShuffleMode()
+	return shuffleModeMsg{mode: mode, err: err}
 }
 
 // Message type for periodic size checks
@@ -818,7 +895,23 @@ func fetchPlaybackStatus() tea.Cmd {
 	return func() tea.Msg {
 		d := daemon.Daemon{}
 		status, err := d.GetPlaybackStatus()
-		return playbackStatusMsg{status: status, err: err}
+		return playbackStatusMsg{status: status, full: true, err: err}
+	}
+}
+
+// pollPlaybackStatus checks the position only, falling back to the full status
+// when the track changed, playback was paused or resumed, or it's been a while
+func pollPlaybackStatus(last daemon.PlaybackStatus, lastFull time.Time) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		pos, err := d.GetPosition()
+		if err != nil {
+			return playbackStatusMsg{err: err}
+		}
+		if daemon.NeedsFullStatus(last, pos, time.Since(lastFull)) {
+			return fetchPlaybackStatus()()
+		}
+		return playbackStatusMsg{status: last.WithPosition(pos)}
 	}
 }
 
@@ -826,25 +919,76 @@ func (m playbackModel) Init() tea.Cmd {
 	return fetchPlaybackStatus()
 }
 
-func (m playbackModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+func (m playbackModel) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	defer recoverUpdate("playback", m, &model, &cmd)
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 	case playbackStatusMsg:
 		if msg.err == nil {
-			m.status = msg.status
+			m.status = m.reconcileControls(msg.status)
 			m.lastUpdate = time.Now()
+			if msg.full {
+				m.lastFull = m.lastUpdate
+			}
 		}
-		// Return a command to fetch status again after 1 second
-		return m, tea.Tick(time.Second, func(time.Time) tea.Msg {
-			return fetchPlaybackStatus()()
-		})
+		frameCmd := m.startFrames()
+		if msg.refresh {
+			return m, frameCmd
+		}
+		// Return a command to fetch status again after the poll interval, slower while
+		// idle and backing off to the probe interval while the daemon's circuit breaker
+		// is open
+		m.polling = false
+		interval := m.nextPollInterval()
+		m.backingOff = errors.Is(msg.err, daemon.ErrCircuitOpen)
+		if m.backingOff {
+			interval = daemon.CircuitProbeInterval
+		}
+		return m, tea.Batch(frameCmd, m.schedulePoll(interval))
+	case pollTickMsg:
+		// Polls waited for before an interaction brought the next one forward are dropped
+		if msg.seq != m.pollSeq {
+			return m, nil
+		}
+		m.polling = true
+		return m, pollPlaybackStatus(m.status, m.lastFull)
+	case progressFrameMsg:
+		// Frames stop with the music and start again with the next status that plays
+		if m.status.PlayerState != "playing" {
+			m.framing = false
+			return m, nil
+		}
+		return m, nextFrame()
 	}
 	return m, nil
 }
 
-func (m playbackModel) View() string {
+func (m playbackModel) View() (view string) {
+	defer recoverView("playback", m.width, m.height, &view)
+	// Cover art goes on the left, as tall as the bar, with the status beside it
+	artRows := m.height
+	artCols := artRows * 2
+	art := m.artwork.lines(artworkSlotPlayback, artCols, artRows)
+	if len(art) == 0 || m.width < artCols+30 {
+		return m.renderStatus()
+	}
+	status := m
+	status.width = m.width - artCols - 1
+	statusLines := strings.Split(status.renderStatus(), "\n")
+	lines := make([]string, len(art))
+	for i := range art {
+		lines[i] = art[i] + " "
+		if i < len(statusLines) {
+			lines[i] += statusLines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderStatus renders the track, progress and playback settings
+func (m playbackModel) renderStatus() string {
 	// Ensure we have valid dimensions
 	if m.height <= 0 || m.width <= 0 {
 		return ""
@@ -852,17 +996,8 @@ func (m playbackModel) View() string {
 
 	// Check if we have any status data
 	if m.status.Track.Name == "" {
-		// No playback info available
-		info := "♪ No track playing"
-		if len(info) > m.width {
-			info = info[:m.width]
-		}
-		// Center the "no track playing" message
-		padding := (m.width - len(info)) / 2
-		if padding > 0 {
-			info = strings.Repeat(" ", padding) + info
-		}
-		return info
+		// No playback info available, centered
+		return layout.Center("♪ No track playing", m.width)
 	}
 
 	// Build the playback status display
@@ -870,161 +1005,20 @@ func (m playbackModel) View() string {
 
 	// Line 1: Track name and artist (centered)
 	trackInfo := fmt.Sprintf("♪ %s - %s", m.status.Track.Name, m.status.Track.Artist)
-	if len(trackInfo) > m.width {
-		if m.width > 10 {
-			trackInfo = trackInfo[:m.width-3] + "..."
-		} else {
-			trackInfo = trackInfo[:m.width]
-		}
-	}
-	// Center the track info
-	trackPadding := (m.width - len(trackInfo)) / 2
-	if trackPadding > 0 {
-		trackInfo = strings.Repeat(" ", trackPadding) + trackInfo
-	}
-	content.WriteString(trackInfo)
+	content.WriteString(layout.Center(trackInfo, m.width))
 
 	// Line 2: Large progress bar (if we have height for it)
 	if m.height > 1 {
 		content.WriteString("\n")
 
-		// Calculate progress percentage
-		progressPercent := 0.0
-		if m.status.Duration > 0 {
-			progressPercent = float64(m.status.Position) / float64(m.status.Duration)
-			if progressPercent > 1.0 {
-				progressPercent = 1.0
-			}
-		}
-
-		// Format time strings
-		positionStr := formatDuration(int(m.status.Position))
-		durationStr := formatDuration(int(m.status.Duration))
-		timeInfo := fmt.Sprintf("%s/%s", positionStr, durationStr)
-
-		// Make progress bar much larger - use most of the available width
-		// Only reserve minimal space for time info
-		timeInfoLen := len(timeInfo)
-		// Use 80% of width for progress bar, leave 20% for time and padding
-		progressBarWidth := int(float64(m.width) * 0.8)
-		// Ensure we have at least some space for time
-		if progressBarWidth > m.width-timeInfoLen-2 {
-			progressBarWidth = m.width - timeInfoLen - 2
-		}
-		// Set minimum progress bar width
-		if progressBarWidth < 20 {
-			progressBarWidth = 20
-		}
-
-		// Calculate filled portion of progress bar
-		filledWidth := 0
-		if progressPercent > 0.0 && progressBarWidth > 0 {
-			filledWidth = int(progressPercent*float64(progressBarWidth) + 0.5) // Round to nearest
-			if filledWidth > progressBarWidth {
-				filledWidth = progressBarWidth
-			}
-			if filledWidth < 0 {
-				filledWidth = 0
-			}
-		}
-
-		// Build larger progress bar with ASCII characters
-		var progressBar strings.Builder
-		for i := 0; i < progressBarWidth; i++ {
-			if i < filledWidth {
-				progressBar.WriteString("█") // Use block character for filled portion
-			} else {
-				progressBar.WriteString("░") // Use light shade for empty portion
-			}
-		}
-
-		// Construct the line with progress bar and time
-		progressLine := progressBar.String() + " " + timeInfo
-
-		// Final safety check - ensure it doesn't exceed width
-		if len(progressLine) > m.width {
-			// Fallback to ASCII characters if Unicode causes issues
-			progressBar.Reset()
-			for i := 0; i < progressBarWidth; i++ {
-				if i < filledWidth {
-					progressBar.WriteString("=")
-				} else {
-					progressBar.WriteString("-")
-				}
-			}
-			progressLine = progressBar.String() + " " + timeInfo
-
-			// If still too long, truncate the progress bar
-			if len(progressLine) > m.width {
-				maxBarWidth := m.width - timeInfoLen - 1
-				if maxBarWidth > 0 && maxBarWidth < len(progressBar.String()) {
-					progressLine = progressBar.String()[:maxBarWidth] + " " + timeInfo
-				} else {
-					progressLine = timeInfo // Just show time if no space
-				}
-			}
-		}
-
-		// Center the progress line
-		progressPadding := (m.width - len(progressLine)) / 2
-		if progressPadding > 0 {
-			progressLine = strings.Repeat(" ", progressPadding) + progressLine
-		}
+		progressLine, _, _ := m.progressLine()
 		content.WriteString(progressLine)
 	}
 
-	// Line 3: Additional info (shuffle, repeat, volume) if we have height
+	// Line 3: Additional info (state, shuffle, repeat, volume) if we have height
 	if m.height > 2 {
 		content.WriteString("\n")
-
-		var infoItems []string
-
-		// Add player state
-		switch m.status.PlayerState {
-		case "playing":
-			infoItems = append(infoItems, "Playing")
-		case "paused":
-			infoItems = append(infoItems, "Paused")
-		case "stopped":
-			infoItems = append(infoItems, "Stopped")
-		}
-
-		// Add shuffle state
-		if m.status.Shuffle {
-			infoItems = append(infoItems, "Shuffle: On")
-		} else {
-			infoItems = append(infoItems, "Shuffle: Off")
-		}
-
-		// Add repeat state
-		switch m.status.RepeatMode {
-		case "one":
-			infoItems = append(infoItems, "Repeat: One")
-		case "all":
-			infoItems = append(infoItems, "Repeat: All")
-		case "off":
-			infoItems = append(infoItems, "Repeat: Off")
-		default:
-			if m.status.RepeatMode == "" {
-				infoItems = append(infoItems, "Repeat: Off")
-			} else {
-				infoItems = append(infoItems, fmt.Sprintf("Repeat: %s", m.status.RepeatMode))
-			}
-		}
-
-		// Add volume
-		infoItems = append(infoItems, fmt.Sprintf("Volume: %d%%", m.status.Volume))
-
-		statusInfo := strings.Join(infoItems, " • ")
-		if len(statusInfo) > m.width {
-			statusInfo = statusInfo[:m.width]
-		}
-		// Center the status info
-		statusPadding := (m.width - len(statusInfo)) / 2
-		if statusPadding > 0 {
-			statusInfo = strings.Repeat(" ", statusPadding) + statusInfo
-		}
-		content.WriteString(statusInfo)
+		content.WriteString(m.infoLine())
 	}
 
 	return content.String()
@@ -1040,69 +1034,111 @@ func formatDuration(seconds int) string {
 	return fmt.Sprintf("%d:%02d", minutes, secs)
 }
 
-// padRight pads a string to the right with spaces to reach the target width (Unicode-aware)
-func padRight(s string, width int) string {
-	currentWidth := runewidth.StringWidth(s)
-	if currentWidth >= width {
-		return s
-	}
-	padding := width - currentWidth
-	return s + strings.Repeat(" ", padding)
-}
-
-// padLeft pads a string to the left with spaces to reach the target width (Unicode-aware)
-func padLeft(s string, width int) string {
-	currentWidth := runewidth.StringWidth(s)
-	if currentWidth >= width {
-		return s
-	}
-	padding := width - currentWidth
-	return strings.Repeat(" ", padding) + s
-}
-
 type instructionsModel struct {
 	width        int
 	currentFocus focusArea
+	keys         keyMap              // Effective keymap, whose keys the instructions name
+	circuit      daemon.CircuitState // Daemon health, shown as a banner while the breaker is open
+	upNext       string              // "Up next" notice, shown as a banner shortly before a track ends
+	startup      string              // Startup status such as "Starting Music.app…"
+	commandLine  string              // ":" prompt or the outcome of the last command, replacing the instructions
+	status       statusLine          // Operations in flight, last error and connection, at the right
 }
 
 func (m instructionsModel) Init() tea.Cmd { return nil }
-func (m instructionsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+func (m instructionsModel) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	defer recoverUpdate("instructions", m, &model, &cmd)
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 	}
 	return m, nil
 }
-func (m instructionsModel) View() string {
+func (m instructionsModel) View() (view string) {
+	defer recoverView("instructions", m.width, 1, &view)
 	focusName := map[focusArea]string{
-		focusSearch:    "Search",
-		focusPlaylists: "Playlists",
-		focusMain:      "Main",
+		focusSearch:    locale.T("focus.search"),
+		focusPlaylists: locale.T("focus.playlists"),
+		focusMain:      locale.T("focus.main"),
 	}
 
-	// Build the instruction text based on current focus
-	var instructions string
-	if m.currentFocus == focusMain {
-		instructions = fmt.Sprintf("Focus: %s | 'q' quit • Tab cycle • Ctrl+W+hjkl vim nav • ↑↓ navigate • Enter play song • Space play/pause • s shuffle • r repeat • +/- volume", focusName[m.currentFocus])
-	} else if m.currentFocus == focusSearch {
-		instructions = fmt.Sprintf("Focus: %s | 'q' quit • Tab cycle • Ctrl+W+hjkl vim nav • ↑↓ navigate • Enter select • / search • Space play/pause • s shuffle • r repeat • +/- volume", focusName[m.currentFocus])
-	} else {
-		instructions = fmt.Sprintf("Focus: %s | 'q' quit • Tab cycle • Ctrl+W+hjkl vim nav • ↑↓ navigate • Enter select • Space play/pause • s shuffle • r repeat • +/- volume", focusName[m.currentFocus])
+	// Build the instruction text based on current focus, with the keys as bound
+	instructions := locale.T("focus", focusName[m.currentFocus]) + keyInstructions(m.keys, m.currentFocus)
+
+	if m.commandLine != "" {
+		instructions = m.commandLine
+	}
+
+	// The status line keeps the right end, the instructions get what's left
+	status := ""
+	if m.width > 40 {
+		status = m.status.render(m.width - 20)
+	}
+	room := m.width
+	if status != "" {
+		room -= layout.Width(status) + 1
 	}
 
 	// Truncate if the instructions are too long for the available width
-	if len(instructions) > m.width {
-		if m.width > 3 {
-			instructions = instructions[:m.width-3] + "..."
-		} else {
-			instructions = instructions[:m.width]
-		}
+	instructions = layout.Truncate(instructions, room, "...")
+	if status != "" {
+		instructions = layout.Pad(instructions, room+1) + status
+	}
+
+	// The banner gets a row of its own above the instructions, see syncBannerRow
+	if banner := m.banner(); banner != "" {
+		return banner + "\n" + instructions
 	}
 
 	return instructions
 }
 
-// getRandomAsciiArt returns a random ASCII art from the available collection
+// banner renders the notice shown above the instructions, if any: a persistent one
+// while Music.app is unreachable, then startup progress, then "Up next"
+func (m instructionsModel) banner() string {
+	if m.width <= 0 {
+		return ""
+	}
+	switch {
+	case m.circuit.Open:
+		return m.renderCircuitBanner()
+	case m.startup != "":
+		return m.renderStartupBanner()
+	case m.upNext != "":
+		return m.renderUpNextBanner()
+	}
+	return ""
+}
+
+// syncBannerRow lays the panes out again when a banner shows up above the instructions
+// or goes away, so it gets a row of its own instead of pushing the screen down
+func (m *Model) syncBannerRow() {
+	shown := false
+	m.boxer.EditLeaf("instructions", func(model tea.Model) (tea.Model, error) {
+		shown = model.(instructionsModel).banner() != ""
+		return model, nil
+	})
+	if shown == *m.bannerRow {
+		return
+	}
+	*m.bannerRow = shown
+	if m.lastWidth > 0 && m.lastHeight > 0 {
+		m.boxer.UpdateSize(tea.WindowSizeMsg{Width: m.lastWidth, Height: m.lastHeight})
+	}
+}
+
+// renderCircuitBanner renders the diagnosis shown while the daemon's circuit breaker is open
+func (m instructionsModel) renderCircuitBanner() string {
+	banner := fmt.Sprintf("⚠ %s", m.circuit.Diagnosis)
+	if wait := time.Until(m.circuit.NextProbe).Round(time.Second); wait > 0 {
+		banner += fmt.Sprintf(" • retrying in %s", wait)
+	} else {
+		banner += " • retrying..."
+	}
+	return bannerStyle.Render(layout.Fit(banner, m.width, "..."))
+}
+
+// getRandomAsciiArt returns a random ASCII art from the available collection
 func getRandomAsciiArt() []string {
 	asciiArts := [][]string{
 		// Original ASCII Art (your provided one)
@@ -1185,11 +1221,12 @@ func getRandomAsciiArt() []string {
 type queueModel struct {
 	width, height int
 	queueInfo     *daemon.QueueInfo
-	selectedItem  int
-	scrollOffset  int
+	list          list.Model // Upcoming tracks, see setQueue
 	visible       bool
 	loading       bool
+	refreshing    bool // Refetching in the background, see refreshQueue
 	lastError     error
+	keys          keyMap // Effective keymap, whose keys the hint line names
 }
 
 // Message for queue info
@@ -1198,11 +1235,19 @@ type queueInfoMsg struct {
 	err  error
 }
 
+// Message sent after the amtui Queue was edited from the overlay
+type queueEditedMsg struct {
+	selected int // Queue index (0-based) to select once the queue is refetched
+	err      error
+}
+
 // Message for search results
 type searchResultsMsg struct {
-	tracks []daemon.Track
-	query  string
-	err    error
+	seq     int // See Model.searchSeq
+	results daemon.SearchResults
+	query   string
+	live    bool // Searched while typing, see searchAsYouType
+	err     error
 }
 
 // LyricsModel represents the lyrics overlay
@@ -1221,12 +1266,7 @@ type lyricsModel struct {
 	loading         bool
 	lastError       error
 	autoScroll      bool // Whether to auto-scroll to current line
-}
-
-// lrcLine represents a single line of time-synced lyrics
-type lrcLine struct {
-	timestamp float64 // Timestamp in seconds
-	text      string
+	syncSeq         int  // Bumped for every lyrics load so position polls for older lyrics stop
 }
 
 // Message for lyrics
@@ -1239,72 +1279,6 @@ type lyricsMsg struct {
 	err          error
 }
 
-// Message for playback position updates (for synced lyrics)
-type playbackPosMsg struct {
-	position float64
-}
-
-// Parse LRC format lyrics into structured lines
-func parseLRC(lrc string) []lrcLine {
-	if lrc == "" {
-		return nil
-	}
-
-	var lines []lrcLine
-	for _, line := range strings.Split(lrc, "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		// LRC format: [mm:ss.xx] text
-		if !strings.HasPrefix(line, "[") {
-			continue
-		}
-
-		endBracket := strings.Index(line, "]")
-		if endBracket == -1 {
-			continue
-		}
-
-		timeStr := line[1:endBracket]
-		text := strings.TrimSpace(line[endBracket+1:])
-
-		// Parse time format mm:ss.xx or mm:ss
-		parts := strings.Split(timeStr, ":")
-		if len(parts) != 2 {
-			continue
-		}
-
-		minutes := 0
-		fmt.Sscanf(parts[0], "%d", &minutes)
-
-		seconds := 0.0
-		fmt.Sscanf(parts[1], "%f", &seconds)
-
-		timestamp := float64(minutes)*60.0 + seconds
-
-		lines = append(lines, lrcLine{
-			timestamp: timestamp,
-			text:      text,
-		})
-	}
-
-	return lines
-}
-
-// Tick command for updating playback position
-func tickPlaybackPosition() tea.Cmd {
-	return tea.Tick(time.Millisecond*500, func(t time.Time) tea.Msg {
-		d := daemon.Daemon{}
-		status, err := d.GetPlaybackStatus()
-		if err != nil {
-			return playbackPosMsg{position: 0}
-		}
-		return playbackPosMsg{position: status.Position}
-	})
-}
-
 // fetchQueueInfo gets the current queue information
 func fetchQueueInfo() tea.Cmd {
 	return func() tea.Msg {
@@ -1314,15 +1288,6 @@ func fetchQueueInfo() tea.Cmd {
 	}
 }
 
-// fetchSearchResults searches for tracks by query
-func fetchSearchResults(query string) tea.Cmd {
-	return func() tea.Msg {
-		d := daemon.Daemon{}
-		tracks, err := d.SearchTracks(query)
-		return searchResultsMsg{tracks: tracks, query: query, err: err}
-	}
-}
-
 // fetchLyrics gets lyrics for the current track
 func fetchLyrics(trackName, artistName string) tea.Cmd {
 	return func() tea.Msg {
@@ -1349,12 +1314,11 @@ func (m queueModel) Init() tea.Cmd {
 func (m queueModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
+		m.resize(msg.Width, msg.Height)
 	case queueInfoMsg:
-		m.queueInfo = msg.info
 		m.lastError = msg.err
 		m.loading = false
+		return m, m.setQueue(msg.info)
 	}
 	return m, nil
 }
@@ -1364,103 +1328,25 @@ func (m queueModel) View() string {
 		return ""
 	}
 
-	// Calculate overlay dimensions (80% of screen)
-	overlayWidth := int(float64(m.width) * 0.8)
-	overlayHeight := int(float64(m.height) * 0.8)
-	if overlayWidth < 40 {
-		overlayWidth = 40
-	}
-	if overlayHeight < 10 {
-		overlayHeight = 10
-	}
-
-	// Ensure overlay doesn't exceed terminal bounds
-	if overlayWidth > m.width {
-		overlayWidth = m.width
-	}
-	if overlayHeight > m.height {
-		overlayHeight = m.height
-	}
-
-	// Center the overlay
-	leftPadding := (m.width - overlayWidth) / 2
-	topPadding := (m.height - overlayHeight) / 2
-
-	// Create the full screen overlay with transparent background
-	var content strings.Builder
-
-	// Render each line of the full terminal
-	for row := 0; row < m.height; row++ {
-		if row > 0 {
-			content.WriteString("\n")
-		}
-
-		// Check if this row is within the overlay area
-		if row >= topPadding && row < topPadding+overlayHeight {
-			// This row contains overlay content
-			overlayRow := row - topPadding
-
-			// Add left transparent padding
-			for col := 0; col < leftPadding; col++ {
-				content.WriteString(" ")
-			}
-
-			// Add overlay content
-			if overlayRow == 0 {
-				// Top border
-				content.WriteString("┌" + strings.Repeat("─", overlayWidth-2) + "┐")
-			} else if overlayRow == overlayHeight-1 {
-				// Bottom border
-				content.WriteString("└" + strings.Repeat("─", overlayWidth-2) + "┘")
-			} else {
-				// Content area
-				content.WriteString("│")
-
-				// Add content based on line
-				contentLine := m.getContentLine(overlayRow-1, overlayWidth-2)
-
-				// Use Unicode-aware width calculation for proper padding
-				contentWidth := runewidth.StringWidth(contentLine)
-				availableContentWidth := overlayWidth - 2 // Account for left and right borders
-
-				// Truncate content if it's too wide
-				if contentWidth > availableContentWidth {
-					contentLine = runewidth.Truncate(contentLine, availableContentWidth, "")
-					contentWidth = availableContentWidth
-				}
-
-				// Add the content
-				content.WriteString(contentLine)
-
-				// Add padding to fill remaining space
-				padding := availableContentWidth - contentWidth
-				if padding > 0 {
-					content.WriteString(strings.Repeat(" ", padding))
-				}
-
-				content.WriteString("│")
-			}
-
-			// Add right transparent padding
-			rightPadding := m.width - leftPadding - overlayWidth
-			for col := 0; col < rightPadding; col++ {
-				content.WriteString(" ")
-			}
-		} else {
-			// This row is outside the overlay - make it transparent
-			for col := 0; col < m.width; col++ {
-				content.WriteString(" ")
+	overlayWidth, overlayHeight := queueOverlaySize(m.width, m.height)
+	showTracks := !m.loading && m.lastError == nil && m.queueInfo != nil
+	tracks := strings.Split(m.list.View(), "\n")
+	return renderOverlay(m.width, m.height, overlayWidth, overlayHeight, func(lineIndex, maxWidth int) string {
+		// The track list fills the overlay below the header
+		if showTracks && lineIndex >= queueHeaderLines {
+			if row := lineIndex - queueHeaderLines; row < len(tracks) {
+				return tracks[row]
 			}
+			return ""
 		}
-	}
-
-	return content.String()
+		return m.getContentLine(lineIndex, maxWidth)
+	})
 }
 
 func (m queueModel) getContentLine(lineIndex int, maxWidth int) string {
 	if m.loading {
 		if lineIndex == 1 {
-			return " Loading queue information..."
+			return " " + locale.T("loading.queue")
 		}
 		return ""
 	}
@@ -1485,9 +1371,8 @@ func (m queueModel) getContentLine(lineIndex int, maxWidth int) string {
 
 	// Header lines
 	if lineIndex == 0 {
-		queueTitle := "amtui Queue"
-		if m.queueInfo.QueueName == "amtui Queue" {
-			return fmt.Sprintf(" 🎵 %s (%d tracks)", queueTitle, m.queueInfo.TotalTracks)
+		if m.queueInfo.Editable() {
+			return fmt.Sprintf(" 🎵 %s (%d tracks)", m.queueInfo.QueueName, m.queueInfo.TotalTracks)
 		} else {
 			return fmt.Sprintf(" 🎵 Current Playlist: %s (%d tracks)", m.queueInfo.QueueName, m.queueInfo.TotalTracks)
 		}
@@ -1501,10 +1386,7 @@ func (m queueModel) getContentLine(lineIndex int, maxWidth int) string {
 		if m.queueInfo.CurrentTrack != nil {
 			currentInfo := fmt.Sprintf(" ♪ Now Playing: %s - %s (Track %d)",
 				m.queueInfo.CurrentTrack.Name, m.queueInfo.CurrentTrack.Artist, m.queueInfo.CurrentPosition)
-			if len(currentInfo) > maxWidth {
-				currentInfo = currentInfo[:maxWidth-3] + "..."
-			}
-			return currentInfo
+			return layout.Truncate(currentInfo, maxWidth, "...")
 		} else {
 			return " ♪ No track currently playing"
 		}
@@ -1517,7 +1399,7 @@ func (m queueModel) getContentLine(lineIndex int, maxWidth int) string {
 
 	// Instructions
 	if lineIndex == 4 {
-		return " Navigation: ↑↓ select • Enter skip to track • Esc close • u refresh"
+		return " " + layout.Truncate(queueKeyHints(m.keys), maxWidth-1, "...")
 	}
 
 	// Empty line for spacing
@@ -1530,53 +1412,6 @@ func (m queueModel) getContentLine(lineIndex int, maxWidth int) string {
 		return " Upcoming Tracks in Queue:"
 	}
 
-	if lineIndex >= 7 {
-		// Show only upcoming tracks (excluding currently playing song)
-		if m.queueInfo.CurrentPosition <= 0 {
-			// If no current position, show all tracks
-			trackIndex := lineIndex - 7 + m.scrollOffset
-			if trackIndex < len(m.queueInfo.Tracks) {
-				track := m.queueInfo.Tracks[trackIndex]
-				prefix := "   "
-
-				// Highlight selected item
-				if trackIndex == m.selectedItem {
-					prefix = " > "
-				}
-
-				// Show track info with position number
-				trackInfo := fmt.Sprintf("%s%d. %s - %s", prefix, trackIndex+1, track.Name, track.Artist)
-				if len(trackInfo) > maxWidth {
-					trackInfo = trackInfo[:maxWidth-3] + "..."
-				}
-				return trackInfo
-			}
-		} else {
-			// Show tracks starting AFTER the current position (exclude currently playing)
-			currentPosIndex := m.queueInfo.CurrentPosition - 1 // Convert to 0-based
-			upcomingTrackIndex := lineIndex - 7 + m.scrollOffset
-			actualTrackIndex := currentPosIndex + 1 + upcomingTrackIndex // +1 to skip current track
-
-			if actualTrackIndex < len(m.queueInfo.Tracks) {
-				track := m.queueInfo.Tracks[actualTrackIndex]
-				prefix := "   "
-
-				// Adjust selected item to work with upcoming tracks display (exclude current)
-				adjustedSelectedItem := m.selectedItem - currentPosIndex - 1 // -1 to account for skipped current track
-				if upcomingTrackIndex == adjustedSelectedItem {
-					prefix = " > "
-				}
-
-				// Show track info with original position number
-				trackInfo := fmt.Sprintf("%s%d. %s - %s", prefix, actualTrackIndex+1, track.Name, track.Artist)
-				if len(trackInfo) > maxWidth {
-					trackInfo = trackInfo[:maxWidth-3] + "..."
-				}
-				return trackInfo
-			}
-		}
-	}
-
 	return ""
 }
 
@@ -1584,36 +1419,6 @@ func (m lyricsModel) Init() tea.Cmd {
 	return nil
 }
 
-// stripANSI removes ANSI escape codes from a string for accurate width calculation
-func stripANSI(s string) string {
-	// Remove ANSI color codes (format: \033[...m)
-	var result strings.Builder
-	inEscape := false
-	
-	for i := 0; i < len(s); {
-		if i+1 < len(s) && s[i] == '\033' && s[i+1] == '[' {
-			// Start of ANSI escape sequence
-			inEscape = true
-			i += 2
-			continue
-		}
-		
-		if inEscape {
-			if s[i] == 'm' {
-				// End of ANSI escape sequence
-				inEscape = false
-			}
-			i++
-			continue
-		}
-		
-		result.WriteByte(s[i])
-		i++
-	}
-	
-	return result.String()
-}
-
 func (m lyricsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -1630,23 +1435,21 @@ func (m lyricsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.scrollOffset = 0
 		m.autoScroll = true
 		// Parse synced lyrics if available
-		if msg.syncedLyrics != "" {
-			m.parsedLyrics = parseLRC(msg.syncedLyrics)
-		}
+		m.parsedLyrics = parseLRC(msg.syncedLyrics)
+		m.currentLineIdx = -1
 	case playbackPosMsg:
 		// Update playback position and find current line
+		if msg.seq != m.syncSeq || !m.visible {
+			return m, nil
+		}
 		if len(m.parsedLyrics) > 0 {
-			m.playbackPos = msg.position
-			// Find the current line based on playback position
-			currentIdx := -1
-			for i, line := range m.parsedLyrics {
-				if line.timestamp <= msg.position {
-					currentIdx = i
-				} else {
-					break
-				}
+			if !msg.ok {
+				return m, tickPlaybackPosition(m.syncSeq)
 			}
-			if currentIdx != -1 && currentIdx != m.currentLineIdx {
+			m.playbackPos = msg.position
+			// Find the current line based on playback position, which also moves back on seeks
+			currentIdx := currentLyricLine(m.parsedLyrics, msg.position)
+			if currentIdx != m.currentLineIdx {
 				m.currentLineIdx = currentIdx
 				// Auto-scroll to keep current line visible
 				if m.autoScroll {
@@ -1662,7 +1465,7 @@ func (m lyricsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.scrollOffset = targetScroll
 				}
 			}
-			return m, tickPlaybackPosition()
+			return m, tickPlaybackPosition(m.syncSeq)
 		}
 	}
 	return m, nil
@@ -1714,22 +1517,7 @@ func (m lyricsModel) View() string {
 				content.WriteString("│")
 
 				contentLine := m.getContentLine(overlayRow-1, overlayWidth-2)
-				// Strip ANSI codes for accurate width calculation
-				plainContent := stripANSI(contentLine)
-				contentWidth := runewidth.StringWidth(plainContent)
-				availableContentWidth := overlayWidth - 2
-
-				if contentWidth > availableContentWidth {
-					contentLine = runewidth.Truncate(contentLine, availableContentWidth, "")
-					contentWidth = runewidth.StringWidth(stripANSI(contentLine))
-				}
-
-				content.WriteString(contentLine)
-
-				padding := availableContentWidth - contentWidth
-				if padding > 0 {
-					content.WriteString(strings.Repeat(" ", padding))
-				}
+				content.WriteString(layout.Fit(contentLine, overlayWidth-2, ""))
 
 				content.WriteString("│")
 			}
@@ -1750,28 +1538,16 @@ func (m lyricsModel) View() string {
 
 func (m lyricsModel) getContentLine(lineIndex int, maxWidth int) string {
 	if lineIndex == 0 {
-		title := " 🎵 LYRICS"
-		if len(title) > maxWidth {
-			title = title[:maxWidth]
-		}
-		return title
+		return layout.Truncate(" 🎵 LYRICS", maxWidth, "")
 	}
 
 	if lineIndex == 1 {
-		trackInfo := fmt.Sprintf(" %s - %s", m.trackName, m.artistName)
-		if len(trackInfo) > maxWidth {
-			trackInfo = runewidth.Truncate(trackInfo, maxWidth, "...")
-		}
-		return trackInfo
+		return layout.Truncate(fmt.Sprintf(" %s - %s", m.trackName, m.artistName), maxWidth, "...")
 	}
 
 	if lineIndex == 2 {
 		if m.source != "" {
-			sourceInfo := fmt.Sprintf(" Source: %s", m.source)
-			if len(sourceInfo) > maxWidth {
-				sourceInfo = runewidth.Truncate(sourceInfo, maxWidth, "...")
-			}
-			return sourceInfo
+			return layout.Truncate(fmt.Sprintf(" Source: %s", m.source), maxWidth, "...")
 		}
 		return ""
 	}
@@ -1782,7 +1558,7 @@ func (m lyricsModel) getContentLine(lineIndex int, maxWidth int) string {
 
 	if m.loading {
 		if lineIndex == 4 {
-			return " Loading lyrics..."
+			return " " + locale.T("loading.lyrics")
 		}
 		return ""
 	}
@@ -1792,11 +1568,7 @@ func (m lyricsModel) getContentLine(lineIndex int, maxWidth int) string {
 			return " ❌ Lyrics not found"
 		}
 		if lineIndex == 5 {
-			errMsg := fmt.Sprintf(" %s", m.lastError.Error())
-			if len(errMsg) > maxWidth {
-				errMsg = runewidth.Truncate(errMsg, maxWidth, "...")
-			}
-			return errMsg
+			return layout.Truncate(" "+m.lastError.Error(), maxWidth, "...")
 		}
 		if lineIndex == 7 {
 			return " This song may not be in the lyrics database."
@@ -1832,44 +1604,21 @@ func (m lyricsModel) getContentLine(lineIndex int, maxWidth int) string {
 				isCurrent := lyricsLineIndex == m.currentLineIdx
 				isPast := lyricsLineIndex < m.currentLineIdx
 
-				// Apple Music-style rendering with ANSI color codes
-				// Current line: bright white/green
-				// Future lines: dimmed gray
-				// Past lines: medium gray
+				// Apple Music-style rendering: the current line stands out, the
+				// others are dimmed in the theme's lyrics colors
 
-				var line string
 				if isCurrent {
-					// Current line: bright green/white with play indicator
-					// Using ANSI escape codes for brightness
-					line = fmt.Sprintf("\033[1;97m  ▶ %s\033[0m", lrcLine.text)
-				} else if isPast {
-					// Past line: dimmed gray
-					line = fmt.Sprintf("\033[38;5;240m    %s\033[0m", lrcLine.text)
-				} else {
-					// Future line: lighter gray
-					line = fmt.Sprintf("\033[38;5;246m    %s\033[0m", lrcLine.text)
+					// Current line: the part already sung in the accent color, karaoke style
+					progress := lyricProgress(m.parsedLyrics, lyricsLineIndex, m.playbackPos)
+					return karaokeLine(lrcLine.text, progress, maxWidth)
 				}
 
-				// Note: runewidth.StringWidth doesn't account for ANSI codes
-				// so we need to calculate width without color codes
-				plainText := lrcLine.text
-				prefix := "    "
-				if isCurrent {
-					prefix = "  ▶ "
-				}
-				totalWidth := runewidth.StringWidth(prefix + plainText)
-				
-				if totalWidth > maxWidth {
-					truncated := runewidth.Truncate(plainText, maxWidth-len(prefix), "...")
-					if isCurrent {
-						line = fmt.Sprintf("\033[1;97m  ▶ %s\033[0m", truncated)
-					} else if isPast {
-						line = fmt.Sprintf("\033[38;5;240m    %s\033[0m", truncated)
-					} else {
-						line = fmt.Sprintf("\033[38;5;246m    %s\033[0m", truncated)
-					}
+				// Past lines are dimmed more than the ones still to come
+				style := lyricsUpcomingStyle
+				if isPast {
+					style = lyricsPastStyle
 				}
-				return line
+				return style.Render(layout.Truncate("    "+lrcLine.text, maxWidth, "..."))
 			}
 		} else {
 			// Fallback to plain lyrics
@@ -1884,11 +1633,7 @@ func (m lyricsModel) getContentLine(lineIndex int, maxWidth int) string {
 			lyricsLineIndex := lineIndex - 6 + m.scrollOffset
 
 			if lyricsLineIndex >= 0 && lyricsLineIndex < len(lyricsLines) {
-				line := "  " + lyricsLines[lyricsLineIndex]
-				if len(line) > maxWidth {
-					line = runewidth.Truncate(line, maxWidth, "...")
-				}
-				return line
+				return layout.Truncate("  "+lyricsLines[lyricsLineIndex], maxWidth, "...")
 			}
 		}
 	}
@@ -1901,7 +1646,17 @@ type contextMenuOption int
 
 const (
 	contextPlay contextMenuOption = iota
+	contextPlayNext
 	contextAddToQueue
+	contextAddToPlaylist
+	contextAddAlbumToQueue
+	contextAddPlaylistToQueue
+	contextPlayWithStrategy
+	contextRevealInFinder
+	contextCopyFilePath
+	contextEditMetadata
+	contextRemoveFromPlaylist
+	contextDeleteFromLibrary
 )
 
 // Context menu model
@@ -1913,6 +1668,70 @@ type contextMenuModel struct {
 	targetSong      daemon.Track
 	targetPlaylist  string
 	targetSongIndex int
+	strategyIndex   int // Index into daemon.QueueStrategyNames() for "Play As"
+	// Entries shown, selectedOption indexes them
+	entries []contextMenuOption
+}
+
+// playlistOnlyOptions are the entries that play from, queue or edit the playlist the
+// song is in, left out for songs of search results and library collections
+var playlistOnlyOptions = map[contextMenuOption]bool{
+	contextPlay:               true,
+	contextPlayWithStrategy:   true,
+	contextAddPlaylistToQueue: true,
+	contextRemoveFromPlaylist: true,
+}
+
+// contextMenuEntries lists the entries of the menu for a song, of a playlist when
+// inPlaylist is set
+func contextMenuEntries(inPlaylist bool) []contextMenuOption {
+	var entries []contextMenuOption
+	for option := contextPlay; option <= contextDeleteFromLibrary; option++ {
+		if inPlaylist || !playlistOnlyOptions[option] {
+			entries = append(entries, option)
+		}
+	}
+	return entries
+}
+
+// option is the selected entry, -1 when there's none
+func (m contextMenuModel) option() contextMenuOption {
+	if m.selectedOption < 0 || m.selectedOption >= len(m.entries) {
+		return -1
+	}
+	return m.entries[m.selectedOption]
+}
+
+// options returns the labels of the entries shown
+func (m contextMenuModel) options() []string {
+	options := make([]string, len(m.entries))
+	for i, option := range m.entries {
+		options[i] = m.label(option)
+	}
+	return options
+}
+
+// label is the text of option
+func (m contextMenuModel) label(option contextMenuOption) string {
+	strategies := daemon.QueueStrategyNames()
+	labels := []string{
+		locale.T("menu.play"),
+		locale.T("menu.play_next"),
+		locale.T("menu.add_to_queue"),
+		locale.T("menu.add_to_playlist"),
+		locale.T("menu.add_album_to_queue"),
+		locale.T("menu.add_playlist_to_queue"),
+		locale.T("menu.play_as", strategies[m.strategyIndex%len(strategies)]),
+		locale.T("menu.reveal_in_finder"),
+		locale.T("menu.copy_file_path"),
+		locale.T("menu.edit_metadata"),
+		locale.T("menu.remove_from_playlist"),
+		locale.T("menu.delete_from_library"),
+	}
+	if option < 0 || int(option) >= len(labels) {
+		return ""
+	}
+	return labels[option]
 }
 
 func (m contextMenuModel) Init() tea.Cmd { return nil }
@@ -1940,8 +1759,8 @@ func (m contextMenuModel) View() string {
 		overlayWidth = 60 // Max width
 	}
 
-	// Calculate content height: song info (3 lines) + separator (1) + options (3) + borders (2) + spacing
-	overlayHeight := 10 // Fixed height for context menu
+	// Calculate content height: song info (3 lines) + separator (1) + spacing (1) + options + bottom spacing (1) + borders (2)
+	overlayHeight := 3 + 1 + 1 + len(m.options()) + 1 + 2
 
 	// Ensure overlay doesn't exceed terminal bounds
 	if overlayWidth > m.width {
@@ -1988,24 +1807,8 @@ func (m contextMenuModel) View() string {
 				// Add content based on line
 				contentLine := m.getContentLine(overlayRow-1, overlayWidth-2)
 
-				// Use Unicode-aware width calculation for proper padding
-				contentWidth := runewidth.StringWidth(contentLine)
-				availableContentWidth := overlayWidth - 2 // Account for left and right borders
-
-				// Truncate content if it's too wide
-				if contentWidth > availableContentWidth {
-					contentLine = runewidth.Truncate(contentLine, availableContentWidth, "...")
-					contentWidth = runewidth.StringWidth(contentLine)
-				}
-
-				// Add the content
-				content.WriteString(contentLine)
-
-				// Add padding to fill remaining space
-				padding := availableContentWidth - contentWidth
-				if padding > 0 {
-					content.WriteString(strings.Repeat(" ", padding))
-				}
+				// Truncate or pad the content to the inside of the borders
+				content.WriteString(layout.Fit(contentLine, overlayWidth-2, "..."))
 
 				content.WriteString("│")
 			}
@@ -2048,102 +1851,194 @@ type Model struct {
 	contextMenu    contextMenuModel
 	contextVisible bool
 	// Track change detection for automatic queue cleanup
-	lastPlayingTrack string // Track ID of the last playing track to detect changes
+	lastPlayingTrack string               // Track ID of the last playing track to detect changes
+	trackWatcher     *daemon.TrackWatcher // Publishes track changes, nil in safe mode
+	// User configuration
+	config        config.Config
+	queueStrategy daemon.QueueStrategy // Strategy from the config file, nil means "auto"
+	keys          keyMap               // Effective keymap (defaults + [keys] overrides)
+	// Keybinding problems found while loading the config, shown at startup
+	keyWarnings        keyWarningsModel
+	keyWarningsVisible bool
+	// Automation that pauses Music while apps from [hooks] are running
+	pauseHook daemon.PauseWhileRunningHook
+	// Offer to resume the queue saved when amtui last quit
+	queueRestore        queueRestoreModel
+	queueRestoreVisible bool
+	// Heads-up about the next track shortly before the current one ends
+	upNext upNextNotice
+	// Picks between library versions of a track that couldn't be queued unambiguously
+	trackPicker        trackPickerModel
+	trackPickerVisible bool
+	trackPicks         []daemon.Track // Ambiguous tracks to pick versions of, the first being asked about
+	// Asks which playlist to add songs to
+	playlistPicker        playlistPickerModel
+	playlistPickerVisible bool
+	// Prompt for adding tracks from the queue overlay
+	queueSearch        queueSearchModel
+	queueSearchVisible bool
+	// Sort order, columns and scroll position each playlist was last shown with
+	views viewSettings
+	// Where the last run was left, restored once the playlist names arrive
+	session *sessionState
+	// Volume step set with :volstep, 0 to use the configured one
+	volumeStep int
+	// Numbers the openings of the queue overlay, see startQueueRefresh
+	queueRefreshSeq int
+	// Width of the sidebar, shared with the layout, and the sidebar's node to put back
+	// in the layout after hiding it
+	split       *sidebarSplit
+	bannerRow   *bool // The instructions have a banner row, see syncBannerRow
+	sidebarNode bubbleboxer.Node
+	// "Edit Metadata" form
+	metadataForm        metadataFormModel
+	metadataFormVisible bool
+	// Full details of a song, opened with "i"
+	trackInspector        trackInspectorModel
+	trackInspectorVisible bool
+	// Confirmation for destructive actions
+	confirm        confirmModel
+	confirmVisible bool
+	// Startup checklist, shown until amtui can talk to Music
+	preflight        preflightModel
+	preflightVisible bool
+	// Placeholder shown instead of the panes while Music.app is closed
+	musicDown        musicDownModel
+	musicDownVisible bool
+	// Walkthrough for granting Automation permission when macOS refuses it
+	permission        permissionModel
+	permissionVisible bool
+	// Track the cover art shown in the playback bar was fetched for
+	artworkTrack string
+	// kitty image ID of the cover sent to the terminal, 0 when none is, and the last ID
+	// used, see setArtwork
+	artworkImage, artworkImages int
+	// Sequences for the terminal written ahead of the frames until escapeSeq's
+	// escapesSentMsg, see sendEscape
+	escapes   string
+	escapeSeq int
+	// Title last set on the terminal, with [ui] terminal_title
+	windowTitle string
+	// Diagnostics screen ("amtui doctor")
+	doctor        doctorModel
+	doctorVisible bool
+	// Track, album and artist counts for the whole library
+	libraryStats        libraryStatsModel
+	libraryStatsVisible bool
+	// History view, the tracks played lately
+	history        historyModel
+	historyVisible bool
+	// Lists the themes to preview and pick one
+	themePicker        themePickerModel
+	themePickerVisible bool
+	// Every keybinding, grouped by where it applies
+	help        helpModel
+	helpVisible bool
+	// Ctrl+P command palette for running any action by name
+	palette        paletteModel
+	paletteVisible bool
+	// Vim-style ":" command line
+	commandLine        commandLineModel
+	commandLineVisible bool
+	// Typing a filter for the playlist's songs, see openFilter
+	filtering bool
+	// Typing a pattern to find in the song list, see openFind
+	finding bool
+	// Past searches, recalled with Up and Down in the search box
+	searchHistory searchHistory
+	// Playlist set with :alarm to start playing at a given time, nil when there's none
+	alarm *alarm
+	// Count and "g" typed so far for a vim motion in a list
+	motion motionState
+	// Outcomes of operations, shown for a few seconds over the bottom right
+	toasts toastStack
+	// Operations in flight, the last error and the connection with Music.app
+	status statusLine
+	// searchSeq numbers searches so results of superseded ones are dropped, and
+	// searchCancel cancels the one running
+	searchSeq    int
+	searchCancel context.CancelFunc
+	// Color themes cycled through with cycle_theme
+	themes themeList
+	// Volume before muting, restored by the next mute
+	mutedVolume int
+	// Sidebar tab, and the selection each tab had when last left
+	tab          libraryTab
+	tabSelection [libraryTabCount]int
+	// Whole library for the Albums, Artists and Songs tabs, loaded when first needed
+	library        *libraryIndex
+	libraryLoading bool
+	libraryError   error
+	// The Songs tab's paged view of the library
+	songs songPager
+	// Last click in the song list, to recognize double-clicks
+	lastClick mouseClick
+	// Started with --safe-mode: default config and nothing read from or written to the state directory
+	safeMode bool
+	// F12 panel with message, script and frame timings
+	debug        *debugStats
+	debugVisible bool
+	// Full-screen view of the playing track, with the visualizer drawn as visualizerMode;
+	// visualizerFraming is set while its frames are coming
+	nowPlayingVisible bool
+	visualizerMode    visualizerMode
+	visualizerFraming bool
+	// Action on a song repeated on the selected one by ".", nil before the first
+	lastAction *repeatable
+	// Keys recorded with record_macro, played back with play_macro
+	macro macroState
 }
 
-// Styles
+// Styles, built from the current theme by applyTheme
 var (
-	// Colors
-	primaryColor    = lipgloss.Color("#1DB954") // Spotify green
-	backgroundColor = lipgloss.Color("#191414")
-	sidebarColor    = lipgloss.Color("#121212")
-	textColor       = lipgloss.Color("#FFFFFF")
-	mutedColor      = lipgloss.Color("#B3B3B3")
-	accentColor     = lipgloss.Color("#1ED760")
-	focusedBorder   = lipgloss.Color("#1DB954")
-
-	// Base styles
-	baseStyle = lipgloss.NewStyle().
-			Foreground(textColor).
-			Margin(1, 2)
-
-	// For currently selected item
-	activeItemStyle = lipgloss.NewStyle().Foreground(accentColor).Bold(true)
-
-	// For navigated-to but not selected item
-	unfocusedSelectedItemStyle = lipgloss.NewStyle().Foreground(accentColor)
-
-	// Focused and unfocused border styles
-	focusedStyle = lipgloss.NewStyle().
-			Background(sidebarColor).
-			Foreground(textColor).
-			Padding(0, 1).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(focusedBorder)
-
-	unfocusedStyle = lipgloss.NewStyle().
-			Background(sidebarColor).
-			Foreground(textColor).
-			Padding(0, 1).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(mutedColor)
-
-	mainFocusedStyle = lipgloss.NewStyle().
-				Background(backgroundColor).
-				Foreground(textColor).
-				Padding(0, 1).
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(focusedBorder)
-
-	mainUnfocusedStyle = lipgloss.NewStyle().
-				Background(backgroundColor).
-				Foreground(textColor).
-				Padding(0, 1).
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(mutedColor)
-
-	titleStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true)
-
-	selectedItemStyle = lipgloss.NewStyle().
-				Foreground(accentColor).
-				Bold(true)
-
-	headerStyle = lipgloss.NewStyle().
-			Foreground(mutedColor).
-			Bold(true).
-			MarginBottom(1)
-
-	linkStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#4A9EFF")).
-			Underline(true)
-
-	searchBoxStyle = lipgloss.NewStyle().
-			Foreground(textColor).
-			Background(lipgloss.Color("#2A2A2A")).
-			Padding(0, 1).
-			MarginBottom(1)
-
-	// Song table styles
-	selectedSongStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("#2D2D2D")).
-				Foreground(textColor)
-
-	tableHeaderStyle = lipgloss.NewStyle().
-				Foreground(mutedColor).
-				Bold(true)
-
-	// Queue overlay styles
-	queueOverlayStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("#1A1A1A")).
-				Foreground(textColor).
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(focusedBorder)
+	baseStyle                  lipgloss.Style
+	activeItemStyle            lipgloss.Style
+	unfocusedSelectedItemStyle lipgloss.Style
+	focusedStyle               lipgloss.Style
+	unfocusedStyle             lipgloss.Style
+	mainFocusedStyle           lipgloss.Style
+	mainUnfocusedStyle         lipgloss.Style
+	titleStyle                 lipgloss.Style
+	selectedItemStyle          lipgloss.Style
+	headerStyle                lipgloss.Style
+	linkStyle                  lipgloss.Style
+	searchBoxStyle             lipgloss.Style
+	selectedSongStyle          lipgloss.Style
+	markedSongStyle            lipgloss.Style
+	playbackOffStyle           lipgloss.Style
+	playingTrackStyle          lipgloss.Style
+	scrollbarThumbStyle        lipgloss.Style
+	scrollbarTrackStyle        lipgloss.Style
+	findMatchStyle             lipgloss.Style
+	toastStyle                 lipgloss.Style
+	toastErrorStyle            lipgloss.Style
+	statusMutedStyle           lipgloss.Style
+	statusBusyStyle            lipgloss.Style
+	statusErrorStyle           lipgloss.Style
+	tableHeaderStyle           lipgloss.Style
+	smartPlaylistStyle         lipgloss.Style
+	unavailableTrackStyle      lipgloss.Style
+	playlistStatsStyle         lipgloss.Style
+	upNextStyle                lipgloss.Style
+	warningStyle               lipgloss.Style
+	bannerStyle                lipgloss.Style
+	queueOverlayStyle          lipgloss.Style
+	lyricsCurrentStyle         lipgloss.Style
+	lyricsSungStyle            lipgloss.Style
+	lyricsPastStyle            lipgloss.Style
+	lyricsUpcomingStyle        lipgloss.Style
 )
 
+// smartPlaylistMarker is appended to smart/genius playlists in the sidebar
+const smartPlaylistMarker = " ⚙"
+
+// unavailableTrackMarker is prefixed to the names of tracks that can't be played
+const unavailableTrackMarker = "⚠ "
+
 // NewModel creates and returns a new TUI model
-func NewModel() Model {
+// NewModel builds the TUI from cfg. In safe mode nothing saved by an earlier run is
+// loaded and polling keeps to the configured interval.
+func NewModel(cfg config.Config, safeMode bool) Model {
 	boxer := bubbleboxer.Boxer{
 		ModelMap: make(map[string]tea.Model),
 	}
@@ -2155,12 +2050,26 @@ func NewModel() Model {
 	playlistCache := make(map[string]daemon.Playlist)
 	playlistsLoading := true
 
+	// Run reports invalid columns, fall back to the defaults for them here
+	columns, err := tableColumns(cfg.UI.Columns)
+	if err != nil {
+		columns, _ = tableColumns(nil)
+	}
+	// Listing the "added" column turns it on, like added_column
+	if hasColumn(columns, "added") {
+		cfg.UI.AddedColumn = true
+	}
+
+	// Apply user keybindings and collect conflicts for the startup warning overlay
+	keys, keyConflicts := newKeyMap(cfg.Keys)
+
 	// Create leaf nodes
-	searchHelpLeaf, _ := boxer.CreateLeaf("searchHelp", searchHelpModel{width: 30, height: 4, searchText: "", cursorPos: 0, searching: false})
-	playlistsLeaf, _ := boxer.CreateLeaf("playlists", playlistsModel{width: 30, height: 12, selectedItem: 0, activeItem: -1, focused: true})
-	mainLeaf, _ := boxer.CreateLeaf("main", mainContentModel{width: 50, height: 24, currentPlaylist: "", focused: false, cachedAsciiArt: cachedAscii, playlistCache: &playlistCache, playlistsLoading: &playlistsLoading})
-	playbackLeaf, _ := boxer.CreateLeaf("playback", playbackModel{width: 80, height: 3})
-	instructionsLeaf, _ := boxer.CreateLeaf("instructions", instructionsModel{width: 80, currentFocus: focusPlaylists})
+	searchHelpLeaf, _ := boxer.CreateLeaf("searchHelp", searchHelpModel{width: 30, height: 4, searching: false})
+	playlistsLeaf, _ := boxer.CreateLeaf("playlists", playlistsModel{width: 30, height: 12, selectedItem: 0, activeItem: -1, focused: true, showStats: cfg.UI.SidebarStats, stations: cfg.Stations})
+	mainLeaf, _ := boxer.CreateLeaf("main", mainContentModel{width: 50, height: 24, currentPlaylist: "", focused: false, cachedAsciiArt: cachedAscii, playlistCache: &playlistCache, playlistsLoading: &playlistsLoading, columns: columns, showAdded: cfg.UI.AddedColumn})
+	playbackLeaf, _ := boxer.CreateLeaf("playback", playbackModel{width: 80, height: 3, pollInterval: cfg.Playback.PollInterval, polling: true, steady: safeMode})
+	instructionsLeaf, _ := boxer.CreateLeaf("instructions", instructionsModel{width: 80, currentFocus: focusPlaylists, keys: keys})
+	tabsLeaf, _ := boxer.CreateLeaf("tabs", viewTabsModel{width: 80})
 
 	// Create the layout tree structure
 	// Sidebar (vertical layout)
@@ -2177,51 +2086,79 @@ func NewModel() Model {
 		},
 	}
 
+	// A banner above the instructions takes a row from the main content
+	bannerRow := new(bool)
+
+	// Pick up where the last run left off, including how the sidebar was resized
+	var session *sessionState
+	if !safeMode {
+		session = loadSessionState()
+	}
+	split := &sidebarSplit{}
+	if session != nil {
+		split.adjust, split.hidden = session.SidebarAdjust, session.SidebarHidden
+	}
+
 	// Main content area (horizontal layout)
 	mainContent := bubbleboxer.Node{
 		Children:        []bubbleboxer.Node{sidebar, mainLeaf},
 		VerticalStacked: false,
 		SizeFunc: func(node bubbleboxer.Node, widthOrHeight int) []int {
-			// Responsive sidebar sizing based on terminal width
-			var sidebarWidth int
-			if widthOrHeight <= 80 {
-				// Small screens: sidebar gets 1/3 but minimum 25
-				sidebarWidth = widthOrHeight / 3
-				if sidebarWidth < 25 {
-					sidebarWidth = 25
-				}
-			} else if widthOrHeight <= 120 {
-				// Medium screens: fixed sidebar width
-				sidebarWidth = 35
-			} else if widthOrHeight <= 160 {
-				// Large screens: slightly larger sidebar
-				sidebarWidth = 40
-			} else {
-				// Very large screens: cap sidebar but allow more space
-				sidebarWidth = 45
+			// Only the song list is left while the sidebar is hidden
+			if len(node.Children) == 1 {
+				return []int{widthOrHeight}
 			}
-
+			// Responsive sidebar sizing based on terminal width, moved with Ctrl+W < and >
+			sidebarWidth := split.width(widthOrHeight)
 			mainWidth := widthOrHeight - sidebarWidth
 			return []int{sidebarWidth, mainWidth}
 		},
 	}
+	if split.hidden {
+		mainContent.Children = []bubbleboxer.Node{mainLeaf}
+	}
 
 	// Root layout (vertical) - now includes playback viewer
 	root := bubbleboxer.Node{
-		Children:        []bubbleboxer.Node{mainContent, playbackLeaf, instructionsLeaf},
+		Children:        []bubbleboxer.Node{mainContent, playbackLeaf, instructionsLeaf, tabsLeaf},
 		VerticalStacked: true,
 		SizeFunc: func(node bubbleboxer.Node, widthOrHeight int) []int {
 			// Main content gets most space, playback gets 3 lines, instructions get 2 lines
-			mainHeight := widthOrHeight - 3 - 2
+			// (3 with a banner) and the view tabs 1
+			instructionsHeight := 2
+			if *bannerRow {
+				instructionsHeight = 3
+			}
+			mainHeight := widthOrHeight - 3 - instructionsHeight - 1
 			if mainHeight < 10 {
 				mainHeight = 10
 			}
-			return []int{mainHeight, 3, 2}
+			return []int{mainHeight, 3, instructionsHeight, 1}
 		},
 	}
 
 	boxer.LayoutTree = root
 
+	// An invalid strategy is reported by Run, fall back to "auto" here
+	queueStrategy, _ := daemon.LookupQueueStrategy(cfg.Queue.Strategy)
+	visualizerMode, _ := parseVisualizerMode(cfg.UI.Visualizer)
+
+	var alarm *alarm
+	var views viewSettings
+	var searches searchHistory
+	var played []playedTrack
+	if !safeMode {
+		alarm = loadAlarm()
+		views = loadViewSettings()
+		searches = loadSearchHistory()
+		played = loadPlayHistory()
+	}
+
+	volumeStep := 0
+	if session != nil && session.VolumeStep >= 1 && session.VolumeStep <= 100 {
+		volumeStep = session.VolumeStep
+	}
+
 	return Model{
 		boxer:                boxer,
 		currentFocus:         focusPlaylists,
@@ -2230,23 +2167,131 @@ func NewModel() Model {
 		selectedPlaylist:     "",
 		playlistCache:        make(map[string]daemon.Playlist),
 		playlistsLoading:     true,
-		queueOverlay:         queueModel{visible: false, loading: false},
+		queueOverlay:         queueModel{visible: false, loading: false, list: newQueueList(), keys: keys},
 		queueVisible:         false,
 		lyricsOverlay:        lyricsModel{visible: false, loading: false, autoScroll: true},
 		lyricsVisible:        false,
+		config:               cfg,
+		queueStrategy:        queueStrategy,
+		visualizerMode:       visualizerMode,
+		keys:                 keys,
+		keyWarnings:          keyWarningsModel{conflicts: keyConflicts},
+		keyWarningsVisible:   len(keyConflicts) > 0,
+		pauseHook:            daemon.PauseWhileRunningHook{Apps: cfg.Hooks.PauseWhenRunning},
+		views:                views,
+		searchHistory:        searches,
+		alarm:                alarm,
+		status:               statusLine{alarm: alarm},
+		session:              session,
+		volumeStep:           volumeStep,
+		split:                split,
+		bannerRow:            bannerRow,
+		sidebarNode:          sidebar,
+		debug:                &debugStats{},
+		history:              historyModel{played: played},
+		preflight:            newPreflightModel(),
+		preflightVisible:     true,
+		safeMode:             safeMode,
 	}
 }
 
 func (m Model) Init() tea.Cmd {
+	var queueCmd tea.Cmd
+	if m.queueVisible {
+		// Opened by [ui] default_view
+		queueCmd = tea.Batch(fetchQueueInfo(), scheduleQueueRefresh(m.queueRefreshSeq))
+	}
 	return tea.Batch(
-		fetchPlaylists,        // Fetch playlist names quickly for UI
-		fetchAllPlaylists(),   // Start background fetch of all playlist data
+		m.preflight.start(),   // Launch Music.app if needed and check access, then load the library
 		fetchPlaybackStatus(), // Start fetching playback status
 		checkTerminalSize(),   // Start periodic size checking for yabai compatibility
+		checkPauseHook(m.pauseHook.Apps),
+		m.loadState(),
+		waitForTrackChange(m.trackWatcher),
+		m.scheduleAlarm(),
+		queueCmd,
 	)
 }
 
+// minPollInterval keeps [playback] poll_interval from flooding Music with scripts
+const minPollInterval = 250 * time.Millisecond
+
+// applyDefaultView opens the view chosen with [ui] default_view
+func (m *Model) applyDefaultView(view string) error {
+	switch view {
+	case "", "playlists":
+		// The sidebar was hidden last time, so start in the song list
+		if m.split.hidden {
+			m.currentFocus = focusMain
+			m.updateFocus()
+		}
+	case "search":
+		m.currentFocus = focusSearch
+		m.updateFocus()
+	case "queue":
+		// Init fetches the queue
+		m.queueVisible = true
+		m.queueOverlay.visible = true
+		m.queueOverlay.loading = true
+	default:
+		return fmt.Errorf("unknown view %q (use playlists, search or queue)", view)
+	}
+	return nil
+}
+
+// loadState offers to resume the previous queue, unless in safe mode
+func (m Model) loadState() tea.Cmd {
+	if m.safeMode {
+		return nil
+	}
+	return loadQueueSnapshot
+}
+
+// Message carrying the result of a [hooks] check
+type pauseHookMsg struct {
+	appRunning bool
+	playing    bool
+	err        error
+}
+
+// checkPauseHook reports whether any app from pause_when_running is open and Music is playing
+func checkPauseHook(apps []string) tea.Cmd {
+	if len(apps) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		running, err := daemon.RunningApps(apps)
+		if err != nil {
+			return pauseHookMsg{err: err}
+		}
+		d := daemon.Daemon{}
+		playing, err := d.IsPlaying()
+		return pauseHookMsg{appRunning: len(running) > 0, playing: playing, err: err}
+	}
+}
+
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Any key or click brings status polling back to full rate
+	var wakeCmd tea.Cmd
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		wakeCmd = m.wakePolling()
+	}
+	start := time.Now()
+	updated, cmd := m.update(msg)
+	m.debug.recordUpdate(msg, time.Since(start))
+	cmd = tea.Batch(cmd, wakeCmd)
+	if model, ok := updated.(Model); ok {
+		// Watch for track changes as often as the status is polled
+		model.syncTrackWatcher()
+		model.syncBannerRow()
+		// Whatever moved the song list, fetch the library pages it now shows
+		return model, tea.Batch(cmd, model.requestSongPages())
+	}
+	return updated, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Update the boxer first
 	var cmd tea.Cmd
 	updatedBoxer, boxerCmd := m.boxer.Update(msg)
@@ -2265,16 +2310,220 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			pl.lastError = msg.err
 			return pl, nil
 		})
-	case allPlaylistsMsg:
-		// Cache the full playlist data
+		m.checkPermission(msg.err)
+		if msg.err == nil && len(msg.playlists) > 0 {
+			return m, tea.Batch(cmd, m.restoreSession())
+		}
+	case trackChangedMsg:
+		return m, tea.Batch(cmd, m.handleTrackChange(msg.change), waitForTrackChange(m.trackWatcher))
+	case musicProbeMsg:
+		return m, tea.Batch(cmd, m.handleMusicProbe(msg))
+	case preflightResultMsg:
+		return m, tea.Batch(cmd, m.handlePreflightResult(msg))
+	case musicReadyMsg:
+		if m.musicDownVisible {
+			return m, tea.Batch(cmd, m.recoverMusic(msg.err))
+		}
+		m.checkPermission(msg.err)
 		if msg.err != nil {
-			// Handle error - could show a notification or log it
-			fmt.Printf("Error loading playlists: %v\n", msg.err)
+			m.setStartupNotice("⚠ " + msg.err.Error())
 		} else {
-			m.playlistCache = msg.playlists
+			m.setStartupNotice("")
 		}
+		return m, tea.Batch(cmd,
+			fetchPlaylists,      // Fetch playlist names quickly for UI
+			fetchAllPlaylists(), // Start background fetch of all playlist data
+		)
+	case queueAddedMsg:
+		if msg.err != nil {
+			return m, tea.Batch(cmd, m.toast(notifyError("Error adding song to queue: %v", msg.err)))
+		}
+		cmd = tea.Batch(cmd, m.toast(notify("%s", msg.results.Summary())))
+		if m.queueVisible && !m.queueOverlay.loading {
+			// Show the new tracks in the open queue overlay
+			m.queueOverlay.loading = true
+			cmd = tea.Batch(cmd, fetchQueueInfo())
+		}
+		// Ask which version to add for tracks that matched several library tracks
+		if ambiguous := msg.results.Ambiguous(); len(ambiguous) > 0 {
+			tracks := make([]daemon.Track, len(ambiguous))
+			for i, result := range ambiguous {
+				tracks[i] = result.Track
+			}
+			return m, tea.Batch(cmd, m.queueTrackPicks(tracks))
+		}
+	case queueSearchResultsMsg:
+		m.queueSearch.setResults(msg)
+	case libraryMsg:
+		m.checkPermission(msg.err)
+		return m, tea.Batch(cmd, m.setLibrary(msg))
+	case songPageMsg:
+		m.checkPermission(msg.err)
+		m.setSongPage(msg)
+	case trackMatchesMsg:
+		switch {
+		case msg.err != nil:
+			return m, tea.Batch(cmd, m.toast(notifyError("Error looking up '%s': %v", msg.track.Name, msg.err)), m.nextTrackPick())
+		case len(msg.matches) == 1:
+			return m, tea.Batch(cmd, addToQueue(msg.matches), m.nextTrackPick())
+		default:
+			m.trackPicker = trackPickerModel{track: msg.track, matches: msg.matches, remaining: len(m.trackPicks) - 1}
+			m.trackPickerVisible = true
+		}
+	case upNextMsg:
+		return m, tea.Batch(cmd, m.showUpNext(msg))
+	case toastMsg:
+		return m, tea.Batch(cmd, m.toast(msg))
+	case clipboardMsg:
+		return m, tea.Batch(cmd, m.copyToClipboard(msg))
+	case escapesSentMsg:
+		if msg.seq == m.escapeSeq {
+			m.escapes = ""
+		}
+	case toastExpiredMsg:
+		m.toasts.expire(msg.id)
+	case motionTimeoutMsg:
+		resolved, motionCmd := m.resolveMotionTimeout(msg)
+		return resolved, tea.Batch(cmd, motionCmd)
+	case opStartedMsg:
+		m.status.start(msg.op)
+		m.setStatus()
+	case opDoneMsg:
+		// A failed operation puts its error back when its result is handled
+		m.status.finish(msg.op)
+		m.status.lastError = ""
+		m.setStatus()
+		if msg.msg == nil {
+			return m, cmd
+		}
+		updated, resultCmd := m.update(msg.msg)
+		return updated, tea.Batch(cmd, resultCmd)
+	case artworkMsg:
+		if msg.trackID != m.artworkTrack {
+			break // The track changed again while this was loading
+		}
+		var art *coverArt
+		if msg.err == nil {
+			art = newCoverArt(msg.image)
+		} else if !artworkMissing(msg.err) {
+			cmd = tea.Batch(cmd, m.toast(notifyError("Error loading artwork: %v", msg.err)))
+		}
+		cmd = tea.Batch(cmd, m.setArtwork(art))
+	case shuffleModeMsg:
+		if msg.err != nil {
+			return m, tea.Batch(cmd, m.toast(notifyError("Error changing shuffle mode: %v", msg.err)))
+		}
+		// Show the new mode right away rather than at the next full status poll
+		m.changeControls(func(s *daemon.PlaybackStatus) { s.ShuffleMode = msg.mode })
+	case libraryStatsMsg:
+		m.libraryStats.stats = msg.stats
+		m.libraryStats.err = msg.err
+		m.libraryStats.loading = false
+	case diagnosticsMsg:
+		m.doctor.diag = msg.diag
+		m.doctor.running = false
+	case trackInfoMsg:
+		if msg.err != nil {
+			return m, tea.Batch(cmd, m.toast(notifyError("Error loading '%s': %v", msg.track.Name, msg.err)))
+		}
+		m.metadataForm = newMetadataForm(msg.info)
+		m.metadataFormVisible = true
+	case inspectedTrackMsg:
+		// Details of a song the inspector no longer shows are dropped
+		if m.trackInspectorVisible && m.trackInspector.track.Id == msg.track.Id {
+			m.trackInspector.info = msg.info
+			m.trackInspector.err = msg.err
+			m.trackInspector.loading = false
+		}
+	case metadataSavedMsg:
+		if msg.err != nil {
+			return m, tea.Batch(cmd, m.toast(notifyError("Error saving metadata: %v", msg.err)))
+		}
+		applyMetadata(m.playlistCache, msg.id, msg.meta)
+	case trackDeletedMsg:
+		if msg.err != nil {
+			return m, tea.Batch(cmd, m.toast(notifyError("Error deleting '%s': %v", msg.track.Name, msg.err)))
+		}
+		cmd = tea.Batch(cmd, m.toast(notify("Deleted '%s' from library", msg.track.Name)))
+		forgetTrack(m.playlistCache, msg.track.Id)
+	case tracksEditedMsg:
+		if msg.err != nil {
+			return m, tea.Batch(cmd, m.toast(notifyError("Error editing songs: %v", msg.err)))
+		}
+		cmd = tea.Batch(cmd, m.toast(notify("%s", msg.summary())))
+		applyTrackEdit(m.playlistCache, msg)
+		if msg.removed {
+			m.updateSongSelection(0) // Keep the selection within the shorter list
+		}
+	case alarmMsg:
+		cmd = tea.Batch(cmd, m.checkAlarm(msg))
+	case visualizerFrameMsg:
+		cmd = tea.Batch(cmd, m.handleVisualizerFrame())
+	case queueSnapshotMsg:
+		if msg.err != nil {
+			cmd = tea.Batch(cmd, m.toast(notifyError("Error loading saved queue: %v", msg.err)))
+		} else if msg.ok {
+			m.queueRestore.snapshot = msg.snapshot
+			m.queueRestoreVisible = true
+		}
+	case queueRestoredMsg:
+		if msg.err != nil {
+			cmd = tea.Batch(cmd, m.toast(notifyError("Error restoring queue: %v", msg.err)))
+		}
+	case pauseHookMsg:
+		// Pause for meetings and resume afterwards, then poll again
+		if msg.err == nil {
+			d := daemon.Daemon{}
+			switch m.pauseHook.Step(msg.appRunning, msg.playing) {
+			case daemon.HookPause:
+				cmd = tea.Batch(cmd, attempt("Error pausing for hook", d.Pause))
+			case daemon.HookResume:
+				cmd = tea.Batch(cmd, attempt("Error resuming after hook", d.Play))
+			}
+		}
+		apps := m.pauseHook.Apps
+		interval := max(m.config.Hooks.PollInterval, time.Second)
+		return m, tea.Batch(cmd, tea.Tick(interval, func(time.Time) tea.Msg {
+			return checkPauseHook(apps)()
+		}))
+	case playlistProgressMsg:
+		m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+			pl := model.(playlistsModel)
+			pl.loaded, pl.loadTotal = msg.loaded, msg.total
+			return pl, nil
+		})
+		return m, tea.Batch(cmd, waitForPlaylistProgress(msg.updates))
+	case allPlaylistsMsg:
+		// Cache the full playlist data
+		m.checkPermission(msg.err)
+		if msg.err != nil {
+			cmd = tea.Batch(cmd, m.toast(notifyError("Error loading playlists: %v", msg.err)))
+		} else {
+			m.playlistCache = msg.playlists
+			// Let the sidebar label smart playlists and show counts/durations
+			smartItems := make(map[string]bool)
+			stats := make(map[string]string)
+			for name, playlist := range msg.playlists {
+				if playlist.Smart {
+					smartItems[name] = true
+				}
+				stats[name] = formatPlaylistStats(playlist)
+			}
+			m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+				pl := model.(playlistsModel)
+				pl.smartItems = smartItems
+				pl.stats = stats
+				return pl, nil
+			})
+		}
+		m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+			pl := model.(playlistsModel)
+			pl.loaded, pl.loadTotal = 0, 0
+			return pl, nil
+		})
 		m.playlistsLoading = false
 	case playbackStatusMsg:
+		m.checkPermission(msg.err)
 		// Forward playback status messages to the playback model
 		var playbackCmd tea.Cmd
 		m.boxer.EditLeaf("playback", func(model tea.Model) (tea.Model, error) {
@@ -2283,6 +2532,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			playbackCmd = pbCmd // Capture the command for scheduling next update
 			return updatedPb, nil
 		})
+		if msg.err == nil {
+			m.setPlaying(msg.status.PersistentId)
+			cmd = tea.Batch(cmd, m.updateWindowTitle(msg.status.Track))
+		}
+		// Keep the circuit breaker banner and the connection state in sync with the daemon's health
+		m.status.connection = connectionOf(msg.err)
+		cmd = tea.Batch(cmd, m.checkMusicDown(msg.err))
+		m.boxer.EditLeaf("instructions", func(model tea.Model) (tea.Model, error) {
+			instr := model.(instructionsModel)
+			instr.circuit = daemon.CircuitStatus()
+			instr.status = m.status
+			return instr, nil
+		})
+		if msg.err == nil {
+			if upNextCmd := m.updateUpNext(msg.status); upNextCmd != nil {
+				cmd = tea.Batch(cmd, upNextCmd)
+			}
+			if artworkCmd := m.updateArtwork(msg.status); artworkCmd != nil {
+				cmd = tea.Batch(cmd, artworkCmd)
+			}
+			cmd = tea.Batch(cmd, m.startVisualizerFrames())
+			// The native queue backend starts each track itself
+			status := msg.status
+			d := daemon.Daemon{}
+			cmd = tea.Batch(cmd, attempt("Error advancing queue", func() error { return d.AdvanceNativeQueue(status) }))
+		}
 		// Combine any existing command with the playback command
 		if playbackCmd != nil {
 			if cmd != nil {
@@ -2293,12 +2568,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case queueInfoMsg:
 		// Update the queue overlay with the new information
-		m.queueOverlay.queueInfo = msg.info
 		m.queueOverlay.lastError = msg.err
 		m.queueOverlay.loading = false
+		m.queueOverlay.refreshing = false
 		// Update dimensions based on current terminal size
-		m.queueOverlay.width = m.lastWidth
-		m.queueOverlay.height = m.lastHeight
+		m.queueOverlay.resize(m.lastWidth, m.lastHeight-1)
+		cmd = tea.Batch(cmd, m.queueOverlay.setQueue(msg.info))
+	case queueRefreshMsg:
+		return m, tea.Batch(cmd, m.handleQueueRefresh(msg))
+	case list.FilterMatchesMsg:
+		return m, tea.Batch(cmd, m.queueOverlay.updateList(msg))
+	case progressFrameMsg, pollTickMsg:
+		var playbackCmd tea.Cmd
+		m.boxer.EditLeaf("playback", func(model tea.Model) (tea.Model, error) {
+			updated, next := model.(playbackModel).Update(msg)
+			playbackCmd = next
+			return updated, nil
+		})
+		return m, tea.Batch(cmd, playbackCmd)
+	case tea.FocusMsg:
+		return m, tea.Batch(cmd, m.setTerminalFocused(true))
+	case tea.BlurMsg:
+		return m, tea.Batch(cmd, m.setTerminalFocused(false))
+	case queueEditedMsg:
+		// Refetch the queue after an edit so the overlay reflects Music's state
+		if msg.err != nil {
+			m.queueOverlay.lastError = msg.err
+			return m, nil
+		}
+		// The refetched queue keeps the selection on this position
+		m.queueOverlay.selectIndex(msg.selected)
+		m.queueOverlay.loading = true
+		return m, fetchQueueInfo()
 	case lyricsMsg:
 		// Update the lyrics overlay with the new information
 		m.lyricsOverlay.lyrics = msg.lyrics
@@ -2312,12 +2613,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.lyricsOverlay.autoScroll = true
 		// Update dimensions based on current terminal size
 		m.lyricsOverlay.width = m.lastWidth
-		m.lyricsOverlay.height = m.lastHeight
+		m.lyricsOverlay.height = m.lastHeight - 1
 		// Parse synced lyrics if available
-		if msg.syncedLyrics != "" {
-			m.lyricsOverlay.parsedLyrics = parseLRC(msg.syncedLyrics)
+		m.lyricsOverlay.parsedLyrics = parseLRC(msg.syncedLyrics)
+		m.lyricsOverlay.currentLineIdx = -1
+		m.lyricsOverlay.syncSeq++
+		if len(m.lyricsOverlay.parsedLyrics) > 0 {
 			// Start playback position ticker for synced lyrics
-			return m, tickPlaybackPosition()
+			return m, tickPlaybackPosition(m.lyricsOverlay.syncSeq)
 		}
 	case playbackPosMsg:
 		// Forward to lyrics overlay when it's visible
@@ -2326,30 +2629,40 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.lyricsOverlay = updatedOverlay.(lyricsModel)
 			return m, overlayCmd
 		}
+	case commandResultMsg:
+		if msg.err != nil {
+			m.commandLine.message = "E: " + msg.err.Error()
+			m.syncCommandLine()
+		}
+	case searchDebounceMsg:
+		// Typing paused; search unless another key came since
+		if msg.seq == m.searchSeq {
+			return m, m.startSearch(msg.query, true)
+		}
 	case searchResultsMsg:
-		// Handle search results
+		// Results of a search the query has moved on from are dropped
+		if m.staleSearch(msg) {
+			return m, nil
+		}
+		m.searchCancel = nil
 		m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
 			main := model.(mainContentModel)
 			if msg.err != nil {
-				// Error occurred during search - show empty results with error message
-				main.searchResults = []daemon.Track{}
-				main.searchQuery = fmt.Sprintf("Error: %v", msg.err)
-				main.isSearchMode = true // Still show search mode to display the error
-				main.selectedSong = 0
-				main.scrollOffset = 0
+				// Error occurred during search - show empty results with error message,
+				// still in search mode to display the error
+				main.setSearchResults(fmt.Sprintf("Error: %v", msg.err), daemon.SearchResults{})
 			} else {
-				// Update search results
-				main.searchResults = msg.tracks
-				main.searchQuery = msg.query
-				main.isSearchMode = true
-				main.selectedSong = 0 // Reset selection to first result
-				main.scrollOffset = 0 // Reset scroll position
+				// Show the results from the top of the first category that has any
+				main.setSearchResults(msg.query, msg.results)
 			}
 			return main, nil
 		})
-		// Switch focus to main content to show search results or error
-		m.currentFocus = focusMain
-		m.updateFocus()
+		// Switch focus to main content to show search results or error, unless
+		// they came in while typing
+		if !msg.live {
+			m.currentFocus = focusMain
+			m.updateFocus()
+		}
 	case sizeCheckMsg:
 		// Aggressive size check for yabai compatibility
 		// Force immediate refresh to catch size changes
@@ -2361,9 +2674,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		)
 	case tea.WindowSizeMsg:
 		// Always force an update for yabai compatibility, even if size appears the same
-		prevWidth, prevHeight := m.lastWidth, m.lastHeight
 		m.lastWidth = msg.Width
 		m.lastHeight = msg.Height
+		m.queueOverlay.resize(msg.Width, msg.Height-1)
+		m.help.resize(msg.Width, msg.Height)
 
 		// Force boxer update - let bubbleboxer handle sizing properly
 		// This is critical for yabai resize detection
@@ -2373,109 +2687,368 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// This helps with yabai compatibility
 		m.boxer.Update(msg)
 
-		// Log size changes for debugging
-		if prevWidth != msg.Width || prevHeight != msg.Height {
-			fmt.Printf("\rTerminal size changed: %dx%d -> %dx%d\n", prevWidth, prevHeight, msg.Width, msg.Height)
-		}
+	case tea.MouseMsg:
+		return m, m.updateMouse(msg)
+	case macroKeyMsg:
+		m.macro.replaying = true
+		model, keyCmd := m.update(msg.key)
+		replayed := model.(Model)
+		replayed.macro.replaying = false
+		return replayed, tea.Batch(cmd, keyCmd)
+	case repeatableMsg:
+		m.lastAction = &msg.action
 	case tea.KeyMsg:
-		// Handle context menu navigation first
-		if m.contextVisible {
+		if handled, macroCmd := m.handleMacroKey(msg); handled {
+			return m, tea.Batch(cmd, macroCmd)
+		}
+
+		// Nothing else works until the startup checks pass or are skipped
+		if m.preflightVisible {
+			return m, m.updatePreflight(msg)
+		}
+
+		// While Music.app is closed there's nothing to do but launch it
+		if m.musicDownVisible {
+			return m, m.updateMusicDown(msg)
+		}
+
+		// The keybinding warnings shown at startup must be dismissed first
+		if m.keyWarningsVisible {
+			switch msg.String() {
+			case "enter", "esc":
+				m.keyWarningsVisible = false
+			case "ctrl+c":
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		// Then the offer to resume the previous queue
+		if m.queueRestoreVisible {
+			switch msg.String() {
+			case "y", "enter":
+				m.queueRestoreVisible = false
+				return m, restoreQueue(m.queueRestore.snapshot)
+			case "n", "esc":
+				m.queueRestoreVisible = false
+				if err := discardQueueSnapshot(); err != nil {
+					return m, m.toast(notifyError("Error discarding saved queue: %v", err))
+				}
+			case "ctrl+c":
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		// Nothing works without Automation permission, so explain that first
+		if m.permissionVisible {
+			return m, m.updatePermission(msg)
+		}
+
+		if m.doctorVisible {
+			return m, m.updateDoctor(msg)
+		}
+
+		if m.libraryStatsVisible {
+			if viewCmd, ok := m.runViewAction(m.keys.action(scopeGlobal, msg.String())); ok {
+				return m, viewCmd
+			}
 			switch msg.String() {
 			case "esc", "q":
+				m.libraryStatsVisible = false
+			case "ctrl+c":
+				return m, tea.Quit
+			default:
+				// The key that opened the overlay closes it too
+				if m.keys.action(scopeGlobal, msg.String()) == actionLibraryStats {
+					m.libraryStatsVisible = false
+				}
+			}
+			return m, nil
+		}
+
+		if m.trackInspectorVisible {
+			switch msg.String() {
+			case "esc", "q":
+				m.trackInspectorVisible = false
+			case "ctrl+c":
+				return m, tea.Quit
+			default:
+				// The key that opened the overlay closes it too
+				if m.keys.action(scopeGlobal, msg.String()) == actionInspectTrack {
+					m.trackInspectorVisible = false
+				}
+			}
+			return m, nil
+		}
+
+		if m.themePickerVisible {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			m.updateThemePicker(msg)
+			return m, nil
+		}
+
+		if m.helpVisible {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			var done bool
+			m.help, done = m.help.update(msg, m.keys)
+			m.helpVisible = !done
+			return m, nil
+		}
+
+		if m.paletteVisible {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			palette, entry, done := m.palette.update(msg)
+			m.palette = palette
+			m.paletteVisible = !done
+			switch {
+			case entry == nil:
+				return m, nil
+			case entry.item >= 0:
+				return m, m.openPlaylistItem(entry.item)
+			default:
+				return m.runAction(entry.action, nil)
+			}
+		}
+
+		if m.commandLineVisible {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			commandCmd := m.updateCommandLine(msg)
+			m.syncCommandLine()
+			return m, commandCmd
+		}
+
+		// Typing a filter captures all keys until Enter or Esc
+		if m.filtering {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			m.updateFilter(msg)
+			return m, nil
+		}
+
+		// So does typing a pattern to find in the song list
+		if m.finding {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			m.updateFind(msg)
+			return m, nil
+		}
+		// The outcome of the last command stays until the next key
+		if m.commandLine.message != "" {
+			m.commandLine.message = ""
+			m.syncCommandLine()
+		}
+
+		// Editing a track's tags captures all typing
+		if m.metadataFormVisible {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			form, saveCmd, done := m.metadataForm.update(msg)
+			m.metadataForm = form
+			m.metadataFormVisible = !done
+			return m, saveCmd
+		}
+
+		// Picking a playlist to add songs to captures all typing
+		if m.playlistPickerVisible {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			picker, pickCmd, done := m.playlistPicker.update(msg)
+			m.playlistPicker = picker
+			m.playlistPickerVisible = !done
+			return m, pickCmd
+		}
+
+		// Confirming a destructive action
+		if m.confirmVisible {
+			switch msg.String() {
+			case "y":
+				m.confirmVisible = false
+				return m, m.confirm.onConfirm
+			case "n", "esc":
+				m.confirmVisible = false
+			case "ctrl+c":
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		// Picking a version of an ambiguous track
+		if m.trackPickerVisible {
+			switch m.keys.action(scopeMenu, msg.String()) {
+			case actionMenuClose:
+				m.trackPickerVisible = false
+				return m, m.nextTrackPick()
+			case actionMenuUp:
+				if m.trackPicker.selected > 0 {
+					m.trackPicker.selected--
+				}
+			case actionMenuDown:
+				if m.trackPicker.selected < len(m.trackPicker.matches)-1 {
+					m.trackPicker.selected++
+				}
+			case actionMenuRun:
+				m.trackPickerVisible = false
+				return m, tea.Batch(addToQueue([]daemon.Track{m.trackPicker.matches[m.trackPicker.selected]}), m.nextTrackPick())
+			}
+			return m, nil
+		}
+
+		// Searching for tracks to add from the queue overlay
+		if m.queueSearchVisible {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			var searchCmd tea.Cmd
+			var done bool
+			m.queueSearch, searchCmd, done = m.queueSearch.update(msg)
+			if done {
+				m.queueSearchVisible = false
+			}
+			return m, searchCmd
+		}
+
+		// Handle context menu navigation first
+		if m.contextVisible {
+			switch m.keys.action(scopeMenu, msg.String()) {
+			case actionMenuClose:
 				// Close context menu
 				m.contextVisible = false
 				m.contextMenu.visible = false
 				return m, nil
-			case "up", "k":
+			case actionMenuUp:
 				// Navigate up in context menu
 				if m.contextMenu.selectedOption > 0 {
 					m.contextMenu.selectedOption--
 				}
 				return m, nil
-			case "down", "j":
+			case actionMenuDown:
 				// Navigate down in context menu
-				if m.contextMenu.selectedOption < 2 { // 3 options total (0-2)
+				if m.contextMenu.selectedOption < len(m.contextMenu.options())-1 {
 					m.contextMenu.selectedOption++
 				}
 				return m, nil
-			case "enter":
-				// Execute selected context menu option
-				return m, m.executeContextMenuAction()
+			case actionMenuLeft, actionMenuRight:
+				// Cycle the queue strategy used by "Play As"
+				if m.contextMenu.option() == contextPlayWithStrategy {
+					count := len(daemon.QueueStrategyNames())
+					if m.keys.action(scopeMenu, msg.String()) == actionMenuLeft {
+						m.contextMenu.strategyIndex = (m.contextMenu.strategyIndex + count - 1) % count
+					} else {
+						m.contextMenu.strategyIndex = (m.contextMenu.strategyIndex + 1) % count
+					}
+				}
+				return m, nil
+			case actionMenuRun:
+				// Execute selected context menu option, which may open another overlay on m
+				menuCmd := m.executeContextMenuAction()
+				return m, menuCmd
 			default:
 				// Ignore other keys when context menu is visible
 				return m, nil
 			}
 		}
 
+		// A queue filter being typed takes every key
+		if m.queueVisible && m.queueOverlay.list.SettingFilter() {
+			return m, tea.Batch(cmd, m.queueOverlay.updateList(msg))
+		}
+
+		// The view tabs switch from any view
+		if viewCmd, ok := m.runViewAction(m.keys.action(scopeGlobal, msg.String())); ok {
+			return m, tea.Batch(cmd, viewCmd)
+		}
+
+		if m.historyVisible {
+			return m, tea.Batch(cmd, m.updateHistory(msg))
+		}
+
 		// Handle queue overlay navigation
 		if m.queueVisible {
-			switch msg.String() {
-			case "q", "esc":
-				// Close queue overlay
-				m.queueVisible = false
-				m.queueOverlay.visible = false
+			moved, motionCmd, handled := m.handleMotion(msg, scopeQueue)
+			if handled {
+				return moved, tea.Batch(cmd, motionCmd)
+			}
+			m, cmd = moved, tea.Batch(cmd, motionCmd)
+			switch m.keys.action(scopeQueue, msg.String()) {
+			case actionQueueClose:
+				// Drop the filter first, then close queue overlay
+				if m.queueOverlay.list.IsFiltered() {
+					m.queueOverlay.list.ResetFilter()
+					return m, nil
+				}
+				m.closeQueue()
 				return m, nil
-			case "u":
+			case actionQueueFilter:
+				if len(m.queueOverlay.list.Items()) > 0 {
+					m.queueOverlay.list.SetFilterState(list.Filtering)
+				}
+				return m, nil
+			case actionQueueRefresh:
 				// Refresh queue info
 				m.queueOverlay.loading = true
 				return m, fetchQueueInfo()
-			case "up", "k":
-				// Navigate up in queue (upcoming tracks only - excluding current)
-				if m.queueOverlay.queueInfo != nil && len(m.queueOverlay.queueInfo.Tracks) > 0 {
-					// Calculate minimum position for upcoming tracks (after current track)
-					minPosition := 0
-					if m.queueOverlay.queueInfo.CurrentPosition > 0 {
-						minPosition = m.queueOverlay.queueInfo.CurrentPosition // First upcoming track (0-based)
-					}
-
-					if m.queueOverlay.selectedItem > minPosition {
-						m.queueOverlay.selectedItem--
-						// Update scroll offset if needed
-						if m.queueOverlay.selectedItem < m.queueOverlay.scrollOffset {
-							m.queueOverlay.scrollOffset = m.queueOverlay.selectedItem
-						}
-					}
+			case actionQueueUp:
+				m.moveQueueSelection(-1)
+				return m, nil
+			case actionQueueDown:
+				m.moveQueueSelection(1)
+				return m, nil
+			case actionQueueMoveUp:
+				return m, m.moveSelectedQueueTrack(-1)
+			case actionQueueMoveDn:
+				return m, m.moveSelectedQueueTrack(1)
+			case actionQueueRemove:
+				return m, m.removeSelectedQueueTrack()
+			case actionQueueClear:
+				if info := m.queueOverlay.queueInfo; info != nil && info.Editable() && !m.queueOverlay.loading {
+					m.confirm = confirmClearQueue(len(info.Tracks)-info.CurrentPosition, max(0, info.CurrentPosition-1))
+					m.confirmVisible = true
 				}
 				return m, nil
-			case "down", "j":
-				// Navigate down in queue (upcoming tracks only)
-				if m.queueOverlay.queueInfo != nil && len(m.queueOverlay.queueInfo.Tracks) > 0 {
-					if m.queueOverlay.selectedItem < len(m.queueOverlay.queueInfo.Tracks)-1 {
-						m.queueOverlay.selectedItem++
-						// Update scroll offset if needed
-						visibleTracks := 15 // Approximate visible tracks in overlay (accounting for header)
-						if m.queueOverlay.selectedItem >= m.queueOverlay.scrollOffset+visibleTracks {
-							m.queueOverlay.scrollOffset = m.queueOverlay.selectedItem - visibleTracks + 1
-						}
-					}
+			case actionQueueCurrent:
+				m.selectQueueCurrent()
+				return m, nil
+			case actionQueueAdd:
+				if info := m.queueOverlay.queueInfo; info != nil && info.Editable() {
+					m.queueSearch = queueSearchModel{}
+					m.queueSearchVisible = true
 				}
 				return m, nil
-			case "enter":
+			case actionQueuePlay:
 				// Skip to selected song in queue
 				if m.queueOverlay.queueInfo != nil && len(m.queueOverlay.queueInfo.Tracks) > 0 {
-					// Use the selected item directly as the track index (0-based)
-					if m.queueOverlay.selectedItem >= 0 && m.queueOverlay.selectedItem < len(m.queueOverlay.queueInfo.Tracks) {
+					// The selected track's queue index (0-based)
+					if selected := m.queueOverlay.selected(); selected >= 0 && selected < len(m.queueOverlay.queueInfo.Tracks) {
 						// Skip to the selected track using daemon (1-based indexing)
 						// When playing from queue, we want to disable shuffle to maintain queue order
 						d := daemon.Daemon{}
-						go func() {
+						position := selected + 1 // Convert to 1-based
+						// Close overlay after action
+						m.closeQueue()
+						return m, attempt("Error skipping to track", func() error {
 							// Temporarily disable shuffle for queue playback
 							currentShuffle, shuffleErr := d.GetShuffle()
 							if shuffleErr == nil && currentShuffle {
 								d.SetShuffle(false)
 							}
 
-							err := d.SkipToQueuePosition(m.queueOverlay.selectedItem + 1) // Convert to 1-based
-							if err != nil {
-								fmt.Printf("Error skipping to track: %v\n", err)
-							}
-
 							// Keep shuffle disabled for queue playback
 							// Don't restore it since we want the queue to play in order
-						}()
-						// Close overlay after action
-						m.queueVisible = false
-						m.queueOverlay.visible = false
+							return d.SkipToQueuePosition(position)
+						})
 					}
 				}
 				return m, nil
@@ -2485,22 +3058,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if m.nowPlayingVisible {
+			return m.updateNowPlaying(msg)
+		}
+
 		// Handle lyrics overlay navigation
 		if m.lyricsVisible {
-			switch msg.String() {
-			case "q", "esc", "l":
+			switch m.keys.action(scopeLyrics, msg.String()) {
+			case actionLyricsClose:
 				// Close lyrics overlay
 				m.lyricsVisible = false
 				m.lyricsOverlay.visible = false
 				return m, nil
-			case "up", "k":
+			case actionLyricsUp:
 				// Scroll up in lyrics (disable auto-scroll when user manually scrolls)
 				m.lyricsOverlay.autoScroll = false
 				if m.lyricsOverlay.scrollOffset > 0 {
 					m.lyricsOverlay.scrollOffset--
 				}
 				return m, nil
-			case "down", "j":
+			case actionLyricsDown:
 				// Scroll down in lyrics (disable auto-scroll when user manually scrolls)
 				m.lyricsOverlay.autoScroll = false
 				if len(m.lyricsOverlay.parsedLyrics) > 0 {
@@ -2522,7 +3099,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 				return m, nil
-			case "a":
+			case actionLyricsAutoScroll:
 				// Toggle auto-scroll
 				m.lyricsOverlay.autoScroll = !m.lyricsOverlay.autoScroll
 				return m, nil
@@ -2535,47 +3112,65 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle Ctrl+W combinations
 		if m.ctrlWPressed {
 			m.ctrlWPressed = false
-			switch msg.String() {
-			case "h":
-				if m.currentFocus == focusMain {
-					m.currentFocus = focusPlaylists
-				}
-			case "l":
-				if m.currentFocus == focusPlaylists {
-					m.currentFocus = focusMain
-				}
+			switch action := m.keys.action(scopePane, msg.String()); action {
+			case actionPaneLeft, actionPaneDown, actionPaneUp, actionPaneRight:
+				m.moveFocus(paneDirections[action])
+			case actionPaneWider:
+				m.resizeSidebar(sidebarStep)
+			case actionPaneNarrower:
+				m.resizeSidebar(-sidebarStep)
+			case actionPaneResetWidth:
+				m.split.adjust, m.split.hidden = 0, false
+				m.applySidebarLayout()
+			case actionPaneToggleSidebar:
+				m.setSidebarHidden(!m.split.hidden)
 			}
 			m.updateFocus()
 			return m, nil
 		}
 
 		if m.currentFocus == focusSearch {
+			// Ctrl+W deletes a word of the query, and leaves the empty search box for
+			// another pane
+			if m.keys.action(scopeGlobal, msg.String()) == actionPanePrefix && (msg.String() != "ctrl+w" || m.searchText() == "") {
+				m.ctrlWPressed = true
+				return m, nil
+			}
 			switch msg.String() {
 			case "enter":
 				// Get search text and perform search
 				var searchQuery string
 				m.boxer.EditLeaf("searchHelp", func(model tea.Model) (tea.Model, error) {
 					sh := model.(searchHelpModel)
-					searchQuery = strings.TrimSpace(sh.searchText)
+					searchQuery = strings.TrimSpace(sh.input.text)
 					return sh, nil
 				})
 
 				// Only perform search if there's a query
 				if searchQuery != "" {
 					// Trigger search
-					return m, fetchSearchResults(searchQuery)
+					return m, m.search(searchQuery)
 				} else {
 					// Empty search - exit search mode
 					m.currentFocus = focusPlaylists
 					m.updateFocus()
 					return m, nil
 				}
+			case "up", "down":
+				// Recall earlier searches
+				if msg.String() == "up" {
+					m.recallSearch(-1)
+				} else {
+					m.recallSearch(1)
+				}
+				return m, m.searchAsYouType(m.searchText())
 			case "esc":
 				// Clear search and return to playlists
+				m.searchHistory.reset()
+				m.cancelSearch()
 				m.boxer.EditLeaf("searchHelp", func(model tea.Model) (tea.Model, error) {
 					sh := model.(searchHelpModel)
-					sh.searchText = ""
-					sh.cursorPos = 0
+					sh.input = lineInput{}
 					return sh, nil
 				})
 				m.currentFocus = focusPlaylists
@@ -2584,389 +3179,482 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case " ":
 				// Space key: toggle play/pause (even in search mode)
 				d := daemon.Daemon{}
-				go func() {
-					err := d.TogglePlayPause()
-					if err != nil {
-						// Could add error handling here, maybe show in UI
-						fmt.Printf("Error toggling play/pause: %v\n", err)
-					}
-				}()
-				return m, nil
+				return m, attempt("Error toggling play/pause", d.TogglePlayPause)
 			default:
-				// Forward all other key events to the search input for custom handling
-				m.boxer.EditLeaf("searchHelp", func(model tea.Model) (tea.Model, error) {
-					sh := model.(searchHelpModel)
-					// The custom input handling is already done in the searchHelpModel.Update method
-					// This will be handled by our custom search input logic
-					updatedSh, inputCmd := sh.Update(msg)
-					sh = updatedSh.(searchHelpModel)
-					if inputCmd != nil {
-						cmd = inputCmd
-					}
-					return sh, nil
-				})
+				// Forward all other key events, pastes included, to the search input
+				return m, tea.Batch(cmd, m.updateSearchInput(msg))
+			}
+		}
+
+		// Bulk actions on the songs marked in visual mode
+		if m.currentFocus == focusMain && m.inVisual() {
+			if visualCmd, handled := m.updateVisual(msg); handled {
+				return m, tea.Batch(cmd, visualCmd)
+			}
+		}
+
+		// n and N move between the matches of the pattern found in the song list
+		if m.currentFocus == focusMain && (msg.String() == "n" || msg.String() == "N") {
+			direction := 1
+			if msg.String() == "N" {
+				direction = -1
+			}
+			if m.findNext(direction) {
 				return m, cmd
 			}
 		}
 
-		switch msg.String() {
-		case "ctrl+c", "q":
-			return m, tea.Quit
+		// Let the "Up next" notice veto the upcoming track
+		if m.upNext.visible && m.currentFocus != focusSearch {
+			if action := m.keys.action(scopeUpNext, msg.String()); action != "" {
+				return m, m.vetoUpNext(action)
+			}
+		}
 
-		case "/":
-			m.currentFocus = focusSearch
-			m.updateFocus()
-			return m, nil
+		// Type the start of a playlist name after the jump key to jump to it
+		if m.currentFocus == focusPlaylists {
+			if _, jumping := m.jumpPrefix(); jumping && m.updateJump(msg) {
+				return m, cmd
+			}
+		}
 
-		case "ctrl+w":
-			m.ctrlWPressed = true
+		// Vim motions in the sidebar and song list
+		if m.currentFocus == focusPlaylists || m.currentFocus == focusMain {
+			moved, motionCmd, handled := m.handleMotion(msg, scopeGlobal)
+			if handled {
+				return moved, tea.Batch(cmd, motionCmd)
+			}
+			m, cmd = moved, tea.Batch(cmd, motionCmd)
+		}
 
-		case "Q":
-			// Toggle queue overlay with capital Q
-			if m.queueVisible {
-				m.queueVisible = false
-				m.queueOverlay.visible = false
-			} else {
-				m.queueVisible = true
-				m.queueOverlay.visible = true
-				// Update overlay dimensions
-				m.queueOverlay.width = m.lastWidth
-				m.queueOverlay.height = m.lastHeight
-				// Start loading queue info
-				m.queueOverlay.loading = true
-				return m, fetchQueueInfo()
+		// Type a letter to jump through the playlists starting with it
+		if m.currentFocus == focusPlaylists {
+			if letter, ok := m.jumpKey(msg); ok {
+				m.jumpToLetter(letter)
+				return m, cmd
 			}
+		}
+
+		return m.runAction(m.keys.action(scopeGlobal, msg.String()), cmd)
+	}
+
+	return m, cmd
+}
+
+// runAction performs a global action, whether triggered by its key or picked from the
+// command palette. cmd is returned along with the action's own commands.
+func (m Model) runAction(action keyAction, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	switch action {
+	case actionQuit:
+		if m.safeMode {
+			return m, tea.Quit
+		}
+		// Remember the queue so the next run can offer to resume it
+		if err := saveQueueSnapshot(); err != nil {
+			slog.Error("saving queue", "err", err)
+		}
+		// Keep how the open playlist is arranged for next time
+		m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+			main := model.(mainContentModel)
+			m.views.remember(main)
+			return main, nil
+		})
+		if err := m.views.save(); err != nil {
+			slog.Error("saving view settings", "err", err)
+		}
+		// And where everything else was, to reopen there
+		if err := m.captureSession().save(); err != nil {
+			slog.Error("saving session", "err", err)
+		}
+		return m, tea.Quit
+
+	case actionSearch:
+		// From the song list, "/" finds in the open playlist instead
+		if m.openFind() {
 			return m, nil
+		}
+		m.currentFocus = focusSearch
+		m.updateFocus()
+		return m, nil
 
-		case "l":
-			// Toggle lyrics overlay with 'l'
-			if m.lyricsVisible {
-				m.lyricsVisible = false
-				m.lyricsOverlay.visible = false
-			} else {
-				// Get current track info
-				d := daemon.Daemon{}
-				currentTrack, err := d.GetCurrentTrack()
-				if err != nil {
-					// Can't get current track, show error
-					m.lyricsVisible = true
-					m.lyricsOverlay.visible = true
-					m.lyricsOverlay.width = m.lastWidth
-					m.lyricsOverlay.height = m.lastHeight
-					m.lyricsOverlay.lastError = fmt.Errorf("no track currently playing")
-					m.lyricsOverlay.loading = false
-					return m, nil
-				}
+	case actionPanePrefix:
+		m.ctrlWPressed = true
 
-				// Open lyrics overlay and start loading
-				m.lyricsVisible = true
-				m.lyricsOverlay.visible = true
-				m.lyricsOverlay.width = m.lastWidth
-				m.lyricsOverlay.height = m.lastHeight
-				m.lyricsOverlay.loading = true
-				m.lyricsOverlay.trackName = currentTrack.Name
-				m.lyricsOverlay.artistName = currentTrack.Artist
-				m.lyricsOverlay.lastError = nil
-				return m, fetchLyrics(currentTrack.Name, currentTrack.Artist)
-			}
+	case actionToggleQueue:
+		if m.queueVisible {
+			m.closeQueue()
 			return m, nil
+		}
+		return m, m.openQueue()
 
-		case "shift+k", "K":
-			// Show context menu for currently selected song (only in main focus)
-			if m.currentFocus == focusMain && m.selectedPlaylist != "" {
-				// Get the currently selected song info and calculate position
-				var selectedSong daemon.Track
-				var selectedSongIndex int
-				var menuX, menuY int
-
-				m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
-					main := model.(mainContentModel)
-					selectedSongIndex = main.selectedSong
-
-					// Calculate the position of the selected song row
-					// Main content area position calculation
-					// Get sidebar width from the boxer layout
-					var sidebarWidth int
-					if m.lastWidth <= 80 {
-						sidebarWidth = m.lastWidth / 3
-						if sidebarWidth < 25 {
-							sidebarWidth = 25
-						}
-					} else if m.lastWidth <= 120 {
-						sidebarWidth = 35
-					} else if m.lastWidth <= 160 {
-						sidebarWidth = 40
-					} else {
-						sidebarWidth = 45
+	case actionToggleLyrics:
+		if m.lyricsVisible {
+			m.closeLyrics()
+			return m, nil
+		}
+		return m, m.openLyrics()
+
+	case actionContextMenu:
+		// Show context menu for currently selected song (only in main focus)
+		// Show context menu for the selected song, of a playlist or of search results
+		if target, ok := m.selectedTarget(); ok {
+			// Calculate the position of the selected song
+			var selectedSongIndex int
+			var menuX, menuY int
+
+			m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+				main := model.(mainContentModel)
+				selectedSongIndex = main.selectedSong
+
+				// Calculate the position of the selected song row
+				// Main content area position calculation
+				// Get sidebar width from the boxer layout
+				sidebarWidth := m.sidebarWidth()
+
+				// Calculate the Y position of the selected song
+				headerLines := 3 // title + header + separator
+				visibleSongRow := selectedSongIndex - main.scrollOffset
+				songRowY := headerLines + visibleSongRow
+
+				// Improved positioning logic
+				// First, calculate preferred position (to the right of song name)
+				preferredMenuX := sidebarWidth + 30 // Place further right, after song name column
+				preferredMenuY := songRowY + 1      // +1 for base style margin
+
+				// Menu dimensions (estimated)
+				menuWidth := 16 // Width needed for "Add To Queue" + borders
+				menuHeight := 5 // 3 options + 2 borders
+
+				// Check boundaries and adjust if needed
+				// X position: ensure menu doesn't go off right edge
+				if preferredMenuX+menuWidth > m.lastWidth {
+					// Try placing to the left of the song name instead
+					preferredMenuX = sidebarWidth - menuWidth - 2
+					// If that's still off-screen, place it at a safe position
+					if preferredMenuX < 0 {
+						preferredMenuX = 2 // Minimum padding from left edge
 					}
+				}
 
-					// Calculate the Y position of the selected song
-					headerLines := 3 // title + header + separator
-					visibleSongRow := selectedSongIndex - main.scrollOffset
-					songRowY := headerLines + visibleSongRow
-
-					// Improved positioning logic
-					// First, calculate preferred position (to the right of song name)
-					preferredMenuX := sidebarWidth + 30 // Place further right, after song name column
-					preferredMenuY := songRowY + 1      // +1 for base style margin
-
-					// Menu dimensions (estimated)
-					menuWidth := 16 // Width needed for "Add To Queue" + borders
-					menuHeight := 5 // 3 options + 2 borders
-
-					// Check boundaries and adjust if needed
-					// X position: ensure menu doesn't go off right edge
-					if preferredMenuX+menuWidth > m.lastWidth {
-						// Try placing to the left of the song name instead
-						preferredMenuX = sidebarWidth - menuWidth - 2
-						// If that's still off-screen, place it at a safe position
-						if preferredMenuX < 0 {
-							preferredMenuX = 2 // Minimum padding from left edge
-						}
+				// Y position: ensure menu doesn't go off bottom edge
+				if preferredMenuY+menuHeight > m.lastHeight {
+					// Move menu up so it fits
+					preferredMenuY = m.lastHeight - menuHeight - 1
+					// Ensure it doesn't go above the top either
+					if preferredMenuY < 1 {
+						preferredMenuY = 1
 					}
+				}
 
-					// Y position: ensure menu doesn't go off bottom edge
-					if preferredMenuY+menuHeight > m.lastHeight {
-						// Move menu up so it fits
-						preferredMenuY = m.lastHeight - menuHeight - 1
-						// Ensure it doesn't go above the top either
-						if preferredMenuY < 1 {
-							preferredMenuY = 1
-						}
-					}
+				menuX = preferredMenuX
+				menuY = preferredMenuY
+
+				return main, nil
+			})
+
+			// Set up context menu, without the entries that need a playlist for search
+			// results and library collections
+			m.contextMenu.targetSong = target.track
+			m.contextMenu.targetPlaylist = target.playlist
+			m.contextMenu.targetSongIndex = target.index
+			m.contextMenu.entries = contextMenuEntries(target.playlist != "")
+			m.contextMenu.selectedOption = 0 // Reset to first option
+			// Start "Play As" on the configured strategy
+			m.contextMenu.strategyIndex = max(slices.Index(daemon.QueueStrategyNames(), m.config.Queue.Strategy), 0)
+			m.contextMenu.visible = true
+			m.contextMenu.width = m.lastWidth
+			m.contextMenu.height = m.lastHeight
+
+			// Position menu next to the selected song
+			m.contextMenu.x = menuX
+			m.contextMenu.y = menuY
+
+			m.contextVisible = true
+		}
+		return m, nil
+
+	case actionQueueAlbum:
+		// Add the selected song's album to the queue (only in main focus)
+		if m.currentFocus == focusMain {
+			if track, ok := m.highlightedTrack(); ok {
+				m.lastAction = &repeatable{label: "add album to queue", run: func(m *Model, target songTarget) tea.Cmd {
+					return enqueueAlbum(target.track)
+				}}
+				return m, enqueueAlbum(track)
+			}
+		}
+		return m, nil
 
-					menuX = preferredMenuX
-					menuY = preferredMenuY
+	case actionQueueList:
+		// Add the whole selected playlist to the queue
+		if (m.currentFocus == focusMain || m.currentFocus == focusPlaylists) && m.selectedPlaylist != "" {
+			return m, enqueuePlaylist(m.selectedPlaylist)
+		}
+		return m, nil
 
-					return main, nil
-				})
+	case actionFilter:
+		m.openFilter()
+		return m, nil
 
-				// Get the song from the playlist cache
-				if playlist, exists := m.playlistCache[m.selectedPlaylist]; exists {
-					if selectedSongIndex >= 0 && selectedSongIndex < len(playlist.Tracks) {
-						selectedSong = playlist.Tracks[selectedSongIndex]
-
-						// Set up context menu
-						m.contextMenu.targetSong = selectedSong
-						m.contextMenu.targetPlaylist = m.selectedPlaylist
-						m.contextMenu.targetSongIndex = selectedSongIndex
-						m.contextMenu.selectedOption = 0 // Reset to first option
-						m.contextMenu.visible = true
-						m.contextMenu.width = m.lastWidth
-						m.contextMenu.height = m.lastHeight
-
-						// Position menu next to the selected song
-						m.contextMenu.x = menuX
-						m.contextMenu.y = menuY
-
-						m.contextVisible = true
-					}
-				}
-			}
+	case actionCenterPlaying:
+		return m, m.centerOnPlaying()
+
+	case actionJumpToName:
+		m.startJump()
+		return m, nil
+
+	case actionTimeLeft:
+		m.toggleRemainingTime()
+		return m, nil
+
+	case actionVisual:
+		m.toggleVisual()
+		return m, nil
+
+	case actionRevealInMusic:
+		return m, m.revealInMusic()
+
+	case actionOpenWebPage:
+		return m, m.openWebPage()
+
+	case actionYank, actionYankLink:
+		// Copy the selected song's title and artist, or its catalog link
+		if m.currentFocus != focusMain {
 			return m, nil
+		}
+		track, ok := m.highlightedTrack()
+		if !ok {
+			return m, nil
+		}
+		if action == actionYankLink {
+			return m, yankTrackLink(track)
+		}
+		return m, yankTrack(track)
 
-		case " ":
-			// Space key: toggle play/pause (works in any focus area except search)
-			if m.currentFocus != focusSearch {
-				d := daemon.Daemon{}
-				go func() {
-					err := d.TogglePlayPause()
-					if err != nil {
-						// Could add error handling here, maybe show in UI
-						fmt.Printf("Error toggling play/pause: %v\n", err)
-					}
-				}()
-				return m, nil
-			}
+	case actionNextCategory:
+		m.cycleSearchCategory(1)
+		return m, nil
 
-		case "s":
-			// S key: toggle shuffle (works in any focus area except search)
-			if m.currentFocus != focusSearch {
-				d := daemon.Daemon{}
-				go func() {
-					err := d.ToggleShuffle()
-					if err != nil {
-						// Could add error handling here, maybe show in UI
-						fmt.Printf("Error toggling shuffle: %v\n", err)
-					}
-				}()
-				return m, nil
-			}
+	case actionPrevCategory:
+		m.cycleSearchCategory(-1)
+		return m, nil
 
-		case "r":
-			// R key: cycle repeat mode (works in any focus area except search)
-			if m.currentFocus != focusSearch {
-				d := daemon.Daemon{}
-				go func() {
-					err := d.CycleRepeatMode()
-					if err != nil {
-						// Could add error handling here, maybe show in UI
-						fmt.Printf("Error cycling repeat mode: %v\n", err)
-					}
-				}()
-				return m, nil
+	case actionToggleAdded:
+		m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+			main := model.(mainContentModel)
+			main.showAdded = !main.showAdded
+			return main, nil
+		})
+		return m, nil
+
+	case actionCycleSort:
+		// Cycle playlist order / newest first, keeping the selected song selected
+		m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+			main := model.(mainContentModel)
+			if main.showsResults() {
+				return main, nil
+			}
+			selected := main.trackIndex(main.selectedSong)
+			main.sortMode = main.sortMode.next()
+			main.selectedSong = selected
+			if order := main.rowOrder((*main.playlistCache)[main.currentPlaylist].Tracks); order != nil {
+				main.selectedSong = max(slices.Index(order, selected), 0)
 			}
+			main.scrollOffset = max(main.selectedSong-main.height/2, 0)
+			return main, nil
+		})
+		return m, nil
 
-		case "+", "=":
-			// + key: volume up (works in any focus area except search)
-			if m.currentFocus != focusSearch {
-				d := daemon.Daemon{}
-				go func() {
-					// Get current volume first
-					currentVol, err := d.GetVolume()
-					if err != nil {
-						fmt.Printf("Error getting volume: %v\n", err)
-						return
-					}
+	case actionDebugOverlay:
+		m.debugVisible = !m.debugVisible
+		return m, nil
 
-					// Increase by 10%, max at 100
-					newVol := currentVol + 10
-					if newVol > 100 {
-						newVol = 100
-					}
+	case actionLibraryStats:
+		return m, m.openLibraryStats()
 
-					err = d.SetVolume(newVol)
-					if err != nil {
-						fmt.Printf("Error setting volume: %v\n", err)
-					}
-				}()
-				return m, nil
-			}
+	case actionInspectTrack:
+		return m, m.openTrackInspector()
 
-		case "-":
-			// - key: volume down (works in any focus area except search)
-			if m.currentFocus != focusSearch {
-				d := daemon.Daemon{}
-				go func() {
-					// Get current volume first
-					currentVol, err := d.GetVolume()
-					if err != nil {
-						fmt.Printf("Error getting volume: %v\n", err)
-						return
-					}
+	case actionViewPlaylists, actionViewSearch, actionViewQueue, actionViewLyrics, actionViewHistory, actionViewStats, actionNextView, actionPrevView:
+		viewCmd, _ := m.runViewAction(action)
+		return m, viewCmd
 
-					// Decrease by 10%, min at 0
-					newVol := currentVol - 10
-					if newVol < 0 {
-						newVol = 0
-					}
+	case actionCommandLine:
+		m.openCommandLine()
+		return m, nil
+
+	case actionPalette:
+		var pl playlistsModel
+		m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+			pl = model.(playlistsModel)
+			return pl, nil
+		})
+		m.palette = newPalette(paletteEntries(m.keys, pl))
+		m.paletteVisible = true
+		return m, nil
+
+	case actionHelp:
+		// Built from the effective keymap so remapped keys show up
+		m.help = helpModel{lines: helpLines(m.keys)}
+		m.help.resize(m.lastWidth, m.lastHeight)
+		m.helpVisible = true
+		return m, nil
+
+	case actionCycleTheme:
+		// Switch to the next built-in or custom theme; styles are read on every render
+		m.themes.next()
+		return m, nil
+
+	case actionThemePicker:
+		m.openThemePicker()
+		return m, nil
+
+	case actionNowPlaying:
+		return m, tea.Batch(cmd, m.openNowPlaying())
+
+	case actionRepeatLast:
+		return m, tea.Batch(cmd, m.repeatLastAction())
+
+	case actionRecordMacro:
+		return m, tea.Batch(cmd, m.toggleMacroRecording())
+
+	case actionPlayMacro:
+		m.macro.awaiting = macroAwaitPlay
+		return m, cmd
+
+	case actionDoctor:
+		// Check the connection to Music
+		m.doctorVisible = true
+		m.doctor.running = true
+		return m, runDiagnostics
+
+	case actionStartStation:
+		// Start an Apple Music station from the playing track
+		d := daemon.Daemon{}
+		return m, inFlight("Starting station", attempt("Error starting station", d.StartStationFromCurrentTrack))
+
+	case actionToggleStats:
+		// Show or hide track counts and durations next to playlists
+		m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+			pl := model.(playlistsModel)
+			pl.showStats = !pl.showStats
+			return pl, nil
+		})
+		return m, nil
+
+	case actionPlayPause:
+		// Space key: toggle play/pause (works in any focus area except search)
+		if m.currentFocus != focusSearch {
+			d := daemon.Daemon{}
+			return m, attempt("Error toggling play/pause", d.TogglePlayPause)
+		}
+
+	case actionShuffle:
+		// S key: toggle shuffle (works in any focus area except search)
+		if m.currentFocus != focusSearch {
+			// Flip the last known state instead of asking Music for it first
+			status, known := m.changeControls(func(s *daemon.PlaybackStatus) { s.Shuffle = !s.Shuffle })
+			d := daemon.Daemon{}
+			return m, attempt("Error toggling shuffle", func() error {
+				if known {
+					return d.SetShuffle(status.Shuffle)
+				}
+				return d.ToggleShuffle()
+			})
+		}
 
-					err = d.SetVolume(newVol)
-					if err != nil {
-						fmt.Printf("Error setting volume: %v\n", err)
-					}
-				}()
-				return m, nil
+	case actionShuffleMode:
+		// Shift+S: cycle what gets shuffled (songs, albums, groupings)
+		if m.currentFocus != focusSearch {
+			if status, known := m.changeControls(func(s *daemon.PlaybackStatus) { s.ShuffleMode = s.ShuffleMode.Next() }); known {
+				return m, setShuffleMode(status.ShuffleMode)
 			}
+			return m, cycleShuffleMode
+		}
 
-		case "enter":
-			if m.currentFocus == focusPlaylists {
-				// Get the selected playlist name
-				m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
-					pl := model.(playlistsModel)
-					if m.selectedPlaylistItem >= 0 && m.selectedPlaylistItem < len(pl.playlistItems) {
-						m.selectedPlaylist = pl.playlistItems[m.selectedPlaylistItem]
-						pl.activeItem = m.selectedPlaylistItem
-					}
-					return pl, nil
-				})
-				// Update the main content view and reset song selection
-				m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
-					main := model.(mainContentModel)
-					main.currentPlaylist = m.selectedPlaylist
-					main.selectedSong = 0 // Reset to first song
-					main.scrollOffset = 0 // Reset scroll position
-					main.isSearchMode = false // Exit search mode when viewing playlist
-					return main, nil
-				})
-				// Automatically switch focus to main content for better UX
-				m.currentFocus = focusMain
-				m.updateFocus()
-			} else if m.currentFocus == focusMain {
-				// Check if we're in search mode or playlist mode
-				var isSearchMode bool
-				var selectedTrack daemon.Track
-				var selectedSongIndex int
-				
-				m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
-					main := model.(mainContentModel)
-					isSearchMode = main.isSearchMode
-					selectedSongIndex = main.selectedSong
-					
-					if isSearchMode && len(main.searchResults) > 0 {
-						// Play selected search result
-						if selectedSongIndex >= 0 && selectedSongIndex < len(main.searchResults) {
-							selectedTrack = main.searchResults[selectedSongIndex]
-						}
-					}
-					return main, nil
-				})
-				
-				if isSearchMode {
-					// Play the selected search result directly
-					if selectedTrack.Name != "" {
-						d := daemon.Daemon{}
-						go func() {
-							// Use PlaySongById if we have an ID, otherwise try by name/artist
-							if selectedTrack.Id != "" {
-								err := d.PlaySongById(selectedTrack.Id)
-								if err != nil {
-									fmt.Printf("Error playing song by ID: %v\n", err)
-								}
-							} else {
-								// Fallback: try to find and play by name/artist
-								fmt.Printf("Playing search result: %s by %s\n", selectedTrack.Name, selectedTrack.Artist)
-								// Could implement additional logic here if needed
-							}
-						}()
-					}
-				} else if m.selectedPlaylist != "" {
-					// Play song from playlist (original logic)
-					d := daemon.Daemon{}
-					go func() {
-						err := d.PlaySongAtPosition(m.selectedPlaylist, selectedSongIndex+1)
-						if err != nil {
-							// Could add error handling here, maybe show in UI
-							fmt.Printf("Error playing song: %v\n", err)
-						}
-					}()
+	case actionRepeat:
+		// R key: cycle repeat mode (works in any focus area except search)
+		if m.currentFocus != focusSearch {
+			status, known := m.changeControls(func(s *daemon.PlaybackStatus) { s.RepeatMode = daemon.NextRepeatMode(s.RepeatMode) })
+			d := daemon.Daemon{}
+			return m, attempt("Error cycling repeat mode", func() error {
+				if known {
+					return d.SetRepeat(status.RepeatMode)
 				}
-			}
+				return d.CycleRepeatMode()
+			})
+		}
 
-		case "tab":
-			if m.currentFocus == focusPlaylists {
-				m.currentFocus = focusMain
-			} else {
-				m.currentFocus = focusPlaylists
+	case actionVolumeUp:
+		return m, m.changeVolume(m.volumeStepSize())
+	case actionVolumeDown:
+		return m, m.changeVolume(-m.volumeStepSize())
+	case actionVolumeUpFine:
+		return m, m.changeVolume(m.config.Playback.FineVolumeStep)
+	case actionVolumeDownFine:
+		return m, m.changeVolume(-m.config.Playback.FineVolumeStep)
+	case actionMute:
+		return m, m.toggleMute()
+	case actionTabPlaylists:
+		return m, m.switchTab(tabPlaylists)
+	case actionTabAlbums:
+		return m, m.switchTab(tabAlbums)
+	case actionTabArtists:
+		return m, m.switchTab(tabArtists)
+	case actionTabSongs:
+		return m, m.switchTab(tabSongs)
+	case actionNextTrack:
+		return m, skipTrack(true)
+	case actionPrevTrack:
+		return m, skipTrack(false)
+
+	case actionSeekBack:
+		return m, m.seek(-seekStep.Seconds(), false)
+	case actionSeekForward:
+		return m, m.seek(seekStep.Seconds(), false)
+	case actionJumpBack:
+		return m, m.seek(-seekJump.Seconds(), false)
+	case actionJumpForward:
+		return m, m.seek(seekJump.Seconds(), false)
+
+	case actionSelect:
+		if selectCmd := m.activateSelection(); selectCmd != nil {
+			return m, selectCmd
+		}
+
+	case actionCycleFocus:
+		if m.currentFocus == focusPlaylists {
+			m.currentFocus = focusMain
+		} else {
+			m.currentFocus = focusPlaylists
+		}
+		m.updateFocus()
+
+	case actionUp:
+		if m.currentFocus == focusPlaylists {
+			if m.selectedPlaylistItem > 0 {
+				m.selectedPlaylistItem--
+				m.updatePlaylistSelection()
 			}
-			m.updateFocus()
+		} else if m.currentFocus == focusMain {
+			m.updateSongSelection(-1)
+		}
 
-		case "up", "k":
-			if m.currentFocus == focusPlaylists {
-				if m.selectedPlaylistItem > 0 {
-					m.selectedPlaylistItem--
-					m.updatePlaylistSelection()
-				}
-			} else if m.currentFocus == focusMain {
-				m.updateSongSelection(-1)
-			}
-
-		case "down", "j":
-			if m.currentFocus == focusPlaylists {
-				// Get playlist count from the cached model
-				var playlistCount int
-				m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
-					pl := model.(playlistsModel)
-					playlistCount = len(pl.playlistItems)
-					return pl, nil
-				})
-				if m.selectedPlaylistItem < playlistCount-1 {
-					m.selectedPlaylistItem++
-					m.updatePlaylistSelection()
-				}
-			} else if m.currentFocus == focusMain {
-				m.updateSongSelection(1)
+	case actionDown:
+		if m.currentFocus == focusPlaylists {
+			// Get playlist count from the cached model
+			var playlistCount int
+			m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+				pl := model.(playlistsModel)
+				playlistCount = pl.itemCount()
+				return pl, nil
+			})
+			if m.selectedPlaylistItem < playlistCount-1 {
+				m.selectedPlaylistItem++
+				m.updatePlaylistSelection()
 			}
+		} else if m.currentFocus == focusMain {
+			m.updateSongSelection(1)
 		}
 	}
 
@@ -2975,11 +3663,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // Helper methods to update focus and selections
 func (m *Model) updateFocus() {
+	// The sidebar comes back when it's focused, for the playlists or the search box in it
+	if m.currentFocus != focusMain {
+		m.setSidebarHidden(false)
+	}
+
 	// Update search focus
 	m.boxer.EditLeaf("searchHelp", func(model tea.Model) (tea.Model, error) {
 		sh := model.(searchHelpModel)
 		sh.searching = (m.currentFocus == focusSearch)
-		// No need to focus/blur since we're using custom input handling
 		return sh, nil
 	})
 
@@ -2987,6 +3679,9 @@ func (m *Model) updateFocus() {
 	m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
 		pl := model.(playlistsModel)
 		pl.focused = (m.currentFocus == focusPlaylists)
+		if !pl.focused {
+			pl.jumping, pl.jumpPrefix = false, ""
+		}
 		pl.selectedItem = m.selectedPlaylistItem
 		return pl, nil
 	})
@@ -3015,13 +3710,7 @@ func (m *Model) updatePlaylistSelection() {
 		pl.selectedItem = m.selectedPlaylistItem
 
 		// Update scroll offset using same logic as View()
-		visibleItems := pl.height - 2
-		if len(pl.playlistItems) > visibleItems {
-			visibleItems-- // Make space for scrollbar
-		}
-		if visibleItems < 0 {
-			visibleItems = 0
-		}
+		visibleItems := pl.visibleItems()
 
 		// If selected item is above visible area, scroll up
 		if m.selectedPlaylistItem < pl.scrollOffset {
@@ -3036,20 +3725,143 @@ func (m *Model) updatePlaylistSelection() {
 	})
 }
 
+// activateSelection opens the selected playlist or station, or plays the selected song
+func (m *Model) activateSelection() tea.Cmd {
+	if m.currentFocus == focusPlaylists && m.tab != tabPlaylists {
+		return m.openCollection(m.selectedPlaylistItem)
+	}
+	if m.currentFocus == focusPlaylists {
+		// Stations play right away and leave the song list alone
+		var station config.StationConfig
+		isStation := false
+		m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+			pl := model.(playlistsModel)
+			if station, isStation = pl.station(m.selectedPlaylistItem); isStation {
+				pl.activeItem = m.selectedPlaylistItem
+			}
+			return pl, nil
+		})
+		if isStation {
+			return playStation(station)
+		}
+
+		// Get the selected playlist name
+		m.boxer.EditLeaf("playlists", func(model tea.Model) (tea.Model, error) {
+			pl := model.(playlistsModel)
+			if m.selectedPlaylistItem >= 0 && m.selectedPlaylistItem < len(pl.playlistItems) {
+				m.selectedPlaylist = pl.playlistItems[m.selectedPlaylistItem]
+				pl.activeItem = m.selectedPlaylistItem
+			}
+			return pl, nil
+		})
+		// Update the main content view and reset song selection
+		m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+			main := model.(mainContentModel)
+			// Remember how the previous playlist was arranged and restore the new one's
+			m.views.remember(main)
+			m.views.apply(&main, m.selectedPlaylist, m.config.UI)
+			// Leave search results for the playlist
+			main.view, main.collection = viewPlaylists, ""
+			return main, nil
+		})
+		// Automatically switch focus to main content for better UX
+		m.currentFocus = focusMain
+		m.updateFocus()
+	} else if m.currentFocus == focusMain {
+		// Albums, artists and playlists found by a search have their own actions
+		showsHits := false
+		m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+			showsHits = model.(mainContentModel).showsSearchHits()
+			return model, nil
+		})
+		if showsHits {
+			return m.activateSearchHit()
+		}
+
+		// Check if we're in search mode or playlist mode
+		var showsResults bool
+		var selectedTrack daemon.Track
+		var selectedSongIndex int
+		var collection string
+		var collectionTracks []daemon.Track
+		
+		m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+			main := model.(mainContentModel)
+			showsResults = main.showsResults()
+			selectedSongIndex = main.selectedSong
+			collection, collectionTracks = main.collection, main.searchResults
+			
+			if showsResults && len(main.searchResults) > 0 {
+				// Play selected search result
+				if selectedSongIndex >= 0 && selectedSongIndex < len(main.searchResults) {
+					selectedTrack = main.searchResults[selectedSongIndex]
+				}
+			} else {
+				// Map the row through the sort order to the playlist position
+				selectedSongIndex = main.trackIndex(selectedSongIndex)
+			}
+			return main, nil
+		})
+		
+		if showsResults && collection != "" {
+			// Albums, artists and songs play on through the rest of the list
+			if collection == allSongsTitle {
+				collectionTracks, selectedSongIndex = loadedRun(collectionTracks, selectedSongIndex)
+			}
+			if selectedSongIndex >= 0 && selectedSongIndex < len(collectionTracks) {
+				return m.playCollection(collection, collectionTracks, selectedSongIndex)
+			}
+		} else if showsResults {
+			// Play the selected search result directly
+			if selectedTrack.Name != "" {
+				if selectedTrack.Id == "" {
+					return func() tea.Msg {
+						return notifyError("Can't play '%s' by %s, Music gave no ID for it", selectedTrack.Name, selectedTrack.Artist)
+					}
+				}
+				d := daemon.Daemon{}
+				return attempt("Error playing song by ID", func() error { return d.PlaySongById(selectedTrack.Id) })
+			}
+		} else if m.selectedPlaylist != "" && selectedSongIndex >= 0 {
+			// Play song from playlist using the configured queue strategy
+			d := daemon.Daemon{}
+			playlist, strategy := m.selectedPlaylist, m.queueStrategy
+			return attempt("Error playing song", func() error {
+				return d.PlaySongAtPositionWithStrategy(playlist, selectedSongIndex+1, strategy)
+			})
+		}
+	}
+	return nil
+}
+
+// openPlaylistItem switches to the Playlists tab and opens its playlist or station at index i
+func (m *Model) openPlaylistItem(i int) tea.Cmd {
+	return tea.Batch(m.switchTab(tabPlaylists), m.openSidebarItem(i))
+}
+
+// openSidebarItem selects the playlist or station at sidebar index i and opens it, like enter
+func (m *Model) openSidebarItem(i int) tea.Cmd {
+	m.currentFocus = focusPlaylists
+	m.updateFocus()
+	m.selectedPlaylistItem = i
+	m.updatePlaylistSelection()
+	return m.activateSelection()
+}
+
 func (m *Model) updateSongSelection(direction int) {
 	// Get the current main content model to check if we're in search mode
-	var isSearchMode bool
+	var showsResults bool
 	var searchResultCount int
 	var playlistSongCount int
 	
 	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
 		main := model.(mainContentModel)
-		isSearchMode = main.isSearchMode
-		searchResultCount = len(main.searchResults)
+		showsResults = main.showsResults()
+		searchResultCount = main.searchRowCount()
 		return main, nil
 	})
 
-	if isSearchMode {
+	if showsResults {
 		// Handle navigation in search results
 		if searchResultCount == 0 {
 			return // No search results to navigate
@@ -3095,7 +3907,11 @@ func (m *Model) updateSongSelection(direction int) {
 
 	// Get the current song count from cache
 	if playlist, exists := m.playlistCache[m.selectedPlaylist]; exists {
-		playlistSongCount = len(playlist.Tracks)
+		m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+			main := model.(mainContentModel)
+			playlistSongCount = main.rowCount(playlist.Tracks)
+			return main, nil
+		})
 	} else {
 		return // No tracks available
 	}
@@ -3136,64 +3952,399 @@ func (m *Model) updateSongSelection(direction int) {
 	})
 }
 
+// moveQueueSelection moves the queue overlay selection one upcoming track up (-1) or down (1)
+func (m *Model) moveQueueSelection(direction int) {
+	if direction < 0 {
+		m.queueOverlay.list.CursorUp()
+	} else {
+		m.queueOverlay.list.CursorDown()
+	}
+}
+
+// moveSelectedQueueTrack moves the selected upcoming track one position up (-1) or down (1)
+// in the amtui Queue. The currently playing track and anything before it can't be reordered.
+func (m *Model) moveSelectedQueueTrack(direction int) tea.Cmd {
+	info := m.queueOverlay.queueInfo
+	if info == nil || !info.Editable() || m.queueOverlay.loading {
+		return nil
+	}
+
+	// Only upcoming tracks (after the current one) can move
+	minPosition := 0
+	if info.CurrentPosition > 0 {
+		minPosition = info.CurrentPosition
+	}
+	from := m.queueOverlay.selected()
+	to := from + direction
+	if from < minPosition || to < minPosition || to >= len(info.Tracks) {
+		return nil
+	}
+
+	m.queueOverlay.loading = true
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		err := d.MoveQueueTrack(from+1, to+1) // Convert to 1-based
+		return queueEditedMsg{selected: to, err: err}
+	}
+}
+
+// removeSelectedQueueTrack drops the selected upcoming track from the amtui Queue
+func (m *Model) removeSelectedQueueTrack() tea.Cmd {
+	info := m.queueOverlay.queueInfo
+	if info == nil || !info.Editable() || m.queueOverlay.loading {
+		return nil
+	}
+
+	// Only upcoming tracks (after the current one) can be removed
+	selected := m.queueOverlay.selected()
+	if selected < 0 || selected < info.CurrentPosition || selected >= len(info.Tracks) {
+		return nil
+	}
+
+	// Keep the selection on the track that takes its place, or the new last track
+	next := selected
+	if next >= len(info.Tracks)-1 {
+		next = len(info.Tracks) - 2
+	}
+	if next < 0 {
+		next = 0
+	}
+
+	m.queueOverlay.loading = true
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		err := d.RemoveFromQueue(selected + 1) // Convert to 1-based
+		return queueEditedMsg{selected: next, err: err}
+	}
+}
+
+// selectQueueCurrent moves the queue selection to the track after the playing one, at
+// the top of the upcoming tracks
+func (m *Model) selectQueueCurrent() {
+	info := m.queueOverlay.queueInfo
+	if info == nil || info.CurrentPosition < 1 || info.CurrentPosition > len(info.Tracks) {
+		return
+	}
+	m.queueOverlay.selectIndex(info.CurrentPosition)
+}
+
+// clearQueue deletes every upcoming track from the amtui Queue, then selects the queue
+// index selected
+func clearQueue(selected int) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		return queueEditedMsg{selected: selected, err: d.ClearUpcomingQueue()}
+	}
+}
+
+// enqueueAlbum appends the album of track to the amtui Queue
+func enqueueAlbum(track daemon.Track) tea.Cmd {
+	return inFlight("Adding album to queue", func() tea.Msg {
+		d := daemon.Daemon{}
+		count, err := d.AddAlbumToQueue(track.Album, track.Artist)
+		if err != nil {
+			return notifyError("Error adding album to queue: %v", err)
+		}
+		return notify("Added %d tracks from '%s' to queue", count, track.Album)
+	})
+}
+
+// revealTrack shows a downloaded track's file in Finder
+func revealTrack(track daemon.Track) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		path, err := d.TrackLocation(track)
+		if err == nil {
+			err = daemon.RevealInFinder(path)
+		}
+		if errors.Is(err, daemon.ErrNotDownloaded) {
+			return notifyError("'%s' isn't downloaded, there is no file to reveal", track.Name)
+		} else if err != nil {
+			return notifyError("Error revealing track: %v", err)
+		}
+		return nil
+	}
+}
+
+// copyTrackPath copies the path of a downloaded track's file to the clipboard
+func copyTrackPath(track daemon.Track) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		path, err := d.TrackLocation(track)
+		if err == nil {
+			err = daemon.CopyToClipboard(path)
+		}
+		if errors.Is(err, daemon.ErrNotDownloaded) {
+			return notifyError("'%s' isn't downloaded, there is no file path to copy", track.Name)
+		} else if err != nil {
+			return notifyError("Error copying file path: %v", err)
+		}
+		return notify("Copied %s", path)
+	}
+}
+
+// playStation starts an internet radio stream or Apple Music station
+func playStation(station config.StationConfig) tea.Cmd {
+	d := daemon.Daemon{}
+	return attempt(fmt.Sprintf("Error playing station '%s'", station.Name), func() error { return d.PlayURL(station.URL) })
+}
+
+// enqueuePlaylist appends every track of a playlist to the amtui Queue
+func enqueuePlaylist(playlistName string) tea.Cmd {
+	return inFlight("Adding playlist to queue", func() tea.Msg {
+		d := daemon.Daemon{}
+		count, err := d.AddPlaylistToQueue(playlistName)
+		if err != nil {
+			return notifyError("Error adding playlist to queue: %v", err)
+		}
+		return notify("Added %d tracks from '%s' to queue", count, playlistName)
+	})
+}
+
 // executeContextMenuAction executes the selected context menu action
 func (m *Model) executeContextMenuAction() tea.Cmd {
 	// Close context menu first
 	m.contextVisible = false
 	m.contextMenu.visible = false
+	m.rememberMenuAction()
+	return m.runContextMenuOption(m.contextMenu.option())
+}
 
-	// Execute the selected action
-	switch contextMenuOption(m.contextMenu.selectedOption) {
+// runContextMenuOption runs option on the menu's target song
+func (m *Model) runContextMenuOption(option contextMenuOption) tea.Cmd {
+	switch option {
 	case contextPlay:
 		// Play: Clear queue and play the selected song
-		return func() tea.Msg {
-			d := daemon.Daemon{}
-			go func() {
-				err := d.PlaySongAtPosition(m.contextMenu.targetPlaylist, m.contextMenu.targetSongIndex+1)
-				if err != nil {
-					fmt.Printf("Error playing song: %v\n", err)
-				}
-			}()
-			return nil
-		}
+		d := daemon.Daemon{}
+		playlist, position, strategy := m.contextMenu.targetPlaylist, m.contextMenu.targetSongIndex+1, m.queueStrategy
+		return attempt("Error playing song", func() error {
+			return d.PlaySongAtPositionWithStrategy(playlist, position, strategy)
+		})
+	case contextPlayWithStrategy:
+		// Play As: build the queue with the strategy picked in the menu for this invocation only
+		name := daemon.QueueStrategyNames()[m.contextMenu.strategyIndex]
+		strategy, _ := daemon.LookupQueueStrategy(name)
+		playlist, position := m.contextMenu.targetPlaylist, m.contextMenu.targetSongIndex+1
+		d := daemon.Daemon{}
+		return attempt("Error playing song", func() error {
+			return d.PlaySongAtPositionWithStrategy(playlist, position, strategy)
+		})
+	case contextPlayNext:
+		// Play Next: Insert right after the currently playing track
+		d := daemon.Daemon{}
+		song := m.contextMenu.targetSong
+		return attempt("Error adding song to play next", func() error { return d.InsertNextInQueue(song) })
+	case contextAddAlbumToQueue:
+		return enqueueAlbum(m.contextMenu.targetSong)
+	case contextAddPlaylistToQueue:
+		return enqueuePlaylist(m.contextMenu.targetPlaylist)
 	case contextAddToQueue:
-		// Add To Queue: Append to end of queue
-		return func() tea.Msg {
-			d := daemon.Daemon{}
-			go func() {
-				err := d.AddToQueue(m.contextMenu.targetSong)
-				if err != nil {
-					fmt.Printf("Error adding song to queue: %v\n", err)
-				} else {
-					fmt.Printf("✅ Added '%s' by %s to queue\n",
-						m.contextMenu.targetSong.Name, m.contextMenu.targetSong.Artist)
-				}
-			}()
-			return nil
+		// Add To Queue: Append to end of queue, asking which version if it's ambiguous
+		return addToQueue([]daemon.Track{m.contextMenu.targetSong})
+	case contextAddToPlaylist:
+		// Ask which playlist, then duplicate the song into it
+		song := m.contextMenu.targetSong
+		m.openPlaylistPicker(fmt.Sprintf("Add '%s' to playlist", song.Name), func(playlist string) tea.Cmd {
+			// "." adds to the same playlist without asking again
+			again := repeatable{label: "Add To " + playlist, run: func(m *Model, target songTarget) tea.Cmd {
+				return addSongToPlaylist(target.track, playlist)
+			}}
+			return remember(again, addSongToPlaylist(song, playlist))
+		})
+		return nil
+	case contextRevealInFinder:
+		return revealTrack(m.contextMenu.targetSong)
+	case contextCopyFilePath:
+		return copyTrackPath(m.contextMenu.targetSong)
+	case contextEditMetadata:
+		// Load the current tags, the form opens once they arrive
+		return fetchTrackInfo(m.contextMenu.targetSong)
+	case contextRemoveFromPlaylist:
+		// Smart playlists fill themselves, so songs can't be taken out of them
+		if playlist, ok := m.playlistCache[m.contextMenu.targetPlaylist]; ok && playlist.Smart {
+			return m.toast(notifyError("'%s' is a smart playlist, songs can't be removed from it", playlist.Name))
 		}
+		m.confirm = confirmRemoveTracks([]daemon.Track{m.contextMenu.targetSong}, m.contextMenu.targetPlaylist)
+		m.confirmVisible = true
+		return nil
+	case contextDeleteFromLibrary:
+		// Deleting can't be undone, so ask first
+		m.confirm = confirmDeleteTrack(m.contextMenu.targetSong)
+		m.confirmVisible = true
+		return nil
 	default:
 		return nil
 	}
 }
 
 func (m Model) View() string {
+	// Toasts go over whatever is on screen, overlays included
+	start := time.Now()
+	view := m.toasts.render(m.view(), m.lastWidth)
+	m.debug.recordFrame(time.Since(start))
+	if m.themePickerVisible {
+		// Over the screen rather than instead of it, to show off the theme
+		m.themePicker.height = m.lastHeight
+		view = renderPanel(view, m.lastWidth, min(max(m.lastWidth/3, 50), m.lastWidth-2), m.themePicker.lines())
+	}
+	if m.debugVisible {
+		view = m.renderDebug(view)
+	}
+	// Zero width, and written once as long as the first line doesn't change
+	return m.escapes + view
+}
+
+func (m Model) view() string {
 	// Create a temporary model to update focus state
 	tempModel := m
 	tempModel.updateFocus()
+	tempModel.syncViewTabs()
 
 	// Get the base layout from bubbleboxer
 	baseView := tempModel.boxer.View()
 
+	if m.preflightVisible {
+		m.preflight.width = m.lastWidth
+		m.preflight.height = m.lastHeight
+		if preflightView := m.preflight.View(); preflightView != "" {
+			return preflightView
+		}
+	}
+
+	if m.musicDownVisible {
+		m.musicDown.width = m.lastWidth
+		m.musicDown.height = m.lastHeight
+		if musicDownView := m.musicDown.View(); musicDownView != "" {
+			return musicDownView
+		}
+	}
+
+	// Keybinding warnings cover everything until dismissed
+	if m.keyWarningsVisible {
+		m.keyWarnings.width = m.lastWidth
+		m.keyWarnings.height = m.lastHeight
+		if warningsView := m.keyWarnings.View(); warningsView != "" {
+			return warningsView
+		}
+	}
+
+	if m.queueRestoreVisible {
+		m.queueRestore.width = m.lastWidth
+		m.queueRestore.height = m.lastHeight
+		if restoreView := m.queueRestore.View(); restoreView != "" {
+			return restoreView
+		}
+	}
+
+	if m.permissionVisible {
+		m.permission.width = m.lastWidth
+		m.permission.height = m.lastHeight
+		if permissionView := m.permission.View(); permissionView != "" {
+			return permissionView
+		}
+	}
+
+	if m.doctorVisible {
+		m.doctor.width = m.lastWidth
+		m.doctor.height = m.lastHeight
+		if doctorView := m.doctor.View(); doctorView != "" {
+			return doctorView
+		}
+	}
+
+	if m.libraryStatsVisible {
+		m.libraryStats.width = m.lastWidth
+		m.libraryStats.height = m.lastHeight - 1
+		if statsView := m.libraryStats.View(); statsView != "" {
+			return m.withViewTabs(statsView)
+		}
+	}
+
+	if m.historyVisible {
+		m.history.width = m.lastWidth
+		m.history.height = m.lastHeight - 1
+		if historyView := m.history.View(); historyView != "" {
+			return m.withViewTabs(historyView)
+		}
+	}
+
+	if m.helpVisible {
+		if helpView := m.help.View(); helpView != "" {
+			return helpView
+		}
+	}
+
+	if m.paletteVisible {
+		m.palette.width = m.lastWidth
+		m.palette.height = m.lastHeight
+		if paletteView := m.palette.View(); paletteView != "" {
+			return paletteView
+		}
+	}
+
+	if m.metadataFormVisible {
+		m.metadataForm.width = m.lastWidth
+		m.metadataForm.height = m.lastHeight
+		if formView := m.metadataForm.View(); formView != "" {
+			return formView
+		}
+	}
+
+	if m.trackInspectorVisible {
+		m.trackInspector.width = m.lastWidth
+		m.trackInspector.height = m.lastHeight
+		if inspectorView := m.trackInspector.View(); inspectorView != "" {
+			return inspectorView
+		}
+	}
+
+	if m.confirmVisible {
+		m.confirm.width = m.lastWidth
+		m.confirm.height = m.lastHeight
+		if confirmView := m.confirm.View(); confirmView != "" {
+			return confirmView
+		}
+	}
+
+	if m.trackPickerVisible {
+		m.trackPicker.width = m.lastWidth
+		m.trackPicker.height = m.lastHeight
+		if pickerView := m.trackPicker.View(); pickerView != "" {
+			return pickerView
+		}
+	}
+
+	if m.playlistPickerVisible {
+		m.playlistPicker.width = m.lastWidth
+		m.playlistPicker.height = m.lastHeight
+		if pickerView := m.playlistPicker.View(); pickerView != "" {
+			return pickerView
+		}
+	}
+
+	if m.queueSearchVisible {
+		m.queueSearch.width = m.lastWidth
+		m.queueSearch.height = m.lastHeight
+		if searchView := m.queueSearch.View(); searchView != "" {
+			return searchView
+		}
+	}
+
 	// If queue overlay is visible, render it on top
 	if m.queueVisible {
 		// Update the queue overlay dimensions to match current terminal size
-		m.queueOverlay.width = m.lastWidth
-		m.queueOverlay.height = m.lastHeight
+		m.queueOverlay.resize(m.lastWidth, m.lastHeight-1)
 		// Render the queue overlay on top of the base view
 		queueOverlayView := m.queueOverlay.View()
 		if queueOverlayView != "" {
-			// The queue overlay should completely cover the base view
-			return queueOverlayView
+			// The queue overlay should completely cover the base view but the tab bar
+			return m.withViewTabs(queueOverlayView)
+		}
+	}
+
+	if m.nowPlayingVisible {
+		if nowPlayingView := m.renderNowPlaying(); nowPlayingView != "" {
+			return nowPlayingView
 		}
 	}
 
@@ -3201,12 +4352,12 @@ func (m Model) View() string {
 	if m.lyricsVisible {
 		// Update the lyrics overlay dimensions to match current terminal size
 		m.lyricsOverlay.width = m.lastWidth
-		m.lyricsOverlay.height = m.lastHeight
+		m.lyricsOverlay.height = m.lastHeight - 1
 		// Render the lyrics overlay on top of the base view
 		lyricsOverlayView := m.lyricsOverlay.View()
 		if lyricsOverlayView != "" {
-			// The lyrics overlay should completely cover the base view
-			return lyricsOverlayView
+			// The lyrics overlay should completely cover the base view but the tab bar
+			return m.withViewTabs(lyricsOverlayView)
 		}
 	}
 
@@ -3223,6 +4374,10 @@ func (m Model) View() string {
 		}
 	}
 
+	if m.compact() {
+		return m.compactView()
+	}
+
 	// Use bubbleboxer to render the layout
 	return baseStyle.Render(baseView)
 }
@@ -3233,27 +4388,15 @@ func (m contextMenuModel) getContentLine(lineIndex int, maxWidth int) string {
 	// Song information section
 	if lineIndex == 0 {
 		// Song title
-		songTitle := fmt.Sprintf(" 🎵 %s", m.targetSong.Name)
-		if len(songTitle) > maxWidth {
-			songTitle = songTitle[:maxWidth-3] + "..."
-		}
-		return songTitle
+		return layout.Truncate(fmt.Sprintf(" 🎵 %s", m.targetSong.Name), maxWidth, "...")
 	}
 	if lineIndex == 1 {
 		// Artist
-		artistLine := fmt.Sprintf(" 🎤 %s", m.targetSong.Artist)
-		if len(artistLine) > maxWidth {
-			artistLine = artistLine[:maxWidth-3] + "..."
-		}
-		return artistLine
+		return layout.Truncate(fmt.Sprintf(" 🎤 %s", m.targetSong.Artist), maxWidth, "...")
 	}
 	if lineIndex == 2 {
 		// Album
-		albumLine := fmt.Sprintf(" 💿 %s", m.targetSong.Album)
-		if len(albumLine) > maxWidth {
-			albumLine = albumLine[:maxWidth-3] + "..."
-		}
-		return albumLine
+		return layout.Truncate(fmt.Sprintf(" 💿 %s", m.targetSong.Album), maxWidth, "...")
 	}
 	if lineIndex == 3 {
 		// Separator
@@ -3265,7 +4408,7 @@ func (m contextMenuModel) getContentLine(lineIndex int, maxWidth int) string {
 	}
 
 	// Options section
-	options := []string{"Play", "Add To Queue"}
+	options := m.options()
 	optionIndex := lineIndex - 5 // Offset for song info + separator + spacing
 
 	if optionIndex >= 0 && optionIndex < len(options) {
@@ -3283,31 +4426,122 @@ func (m contextMenuModel) getContentLine(lineIndex int, maxWidth int) string {
 	return ""
 }
 
+// Options changes how Run starts the TUI
+type Options struct {
+	// SafeMode ignores the config file and saved state, leaving hooks, notifications
+	// and the native queue off, to tell problems with customization from core bugs.
+	// Searches read the library every time, cover art is off and polling keeps to
+	// the configured interval.
+	SafeMode bool
+}
+
 // Run starts the TUI application
-func Run() error {
+func Run(opts Options) error {
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Printf("PANIC in TUI: %v\n", r)
-			// You can add stack trace here if needed
-			// debug.PrintStack()
+			slog.Error("panic in TUI", "panic", r, "stack", string(debug.Stack()))
+			fmt.Fprintf(os.Stderr, "amtui crashed: %v (stack trace in the log)\n", r)
 			os.Exit(1)
 		}
 	}()
 
-	fmt.Println("Starting TUI application...")
+	slog.Debug("starting TUI")
+
+	// Load user configuration, falling back to defaults on error
+	cfg := config.Default()
+	if opts.SafeMode {
+		slog.Info("safe mode: ignoring config file and saved state")
+	} else if loaded, err := config.Load(); err != nil {
+		slog.Warn("error loading config, using defaults", "err", err)
+	} else {
+		cfg = loaded
+	}
+	if _, err := daemon.LookupQueueStrategy(cfg.Queue.Strategy); err != nil {
+		slog.Warn("invalid queue strategy in config, using auto", "err", err)
+	}
+	if _, err := parseVisualizerMode(cfg.UI.Visualizer); err != nil {
+		slog.Warn("invalid visualizer in config, using bars", "err", err)
+	}
+	if err := daemon.SetQueueBackend(cfg.Queue.Backend); err != nil {
+		slog.Warn("invalid queue backend in config, using playlist", "err", err)
+	}
+	daemon.SetSkipUnavailable(cfg.Queue.SkipUnavailable)
+	daemon.SetSearchCaching(!opts.SafeMode)
+	defaults := config.Default()
+	if cfg.Playback.VolumeStep < 1 || cfg.Playback.VolumeStep > 100 {
+		slog.Warn("invalid volume step in config", "step", cfg.Playback.VolumeStep, "using", defaults.Playback.VolumeStep)
+		cfg.Playback.VolumeStep = defaults.Playback.VolumeStep
+	}
+	if cfg.Playback.FineVolumeStep < 1 || cfg.Playback.FineVolumeStep > 100 {
+		slog.Warn("invalid fine volume step in config", "step", cfg.Playback.FineVolumeStep, "using", defaults.Playback.FineVolumeStep)
+		cfg.Playback.FineVolumeStep = defaults.Playback.FineVolumeStep
+	}
+	if cfg.Playback.PollInterval < minPollInterval {
+		slog.Warn("poll interval in config is too short", "interval", cfg.Playback.PollInterval, "min", minPollInterval, "using", defaults.Playback.PollInterval)
+		cfg.Playback.PollInterval = defaults.Playback.PollInterval
+	}
+	if _, err := tableColumns(cfg.UI.Columns); err != nil {
+		slog.Warn("invalid columns in config, using the defaults", "err", err)
+		cfg.UI.Columns = defaults.UI.Columns
+	}
+	profile, err := detectColorProfile(cfg.UI.Colors, termenv.EnvColorProfile)
+	if err != nil {
+		slog.Warn("invalid colors setting in config", "err", err)
+	}
+	setColorProfile(profile)
+	slog.Debug("drawing with colors", "colors", colorProfileName(profile))
+	lang, err := locale.Detect(cfg.UI.Language, os.Getenv)
+	if err != nil {
+		slog.Warn("invalid language setting in config", "err", err)
+	}
+	locale.Set(lang)
+	slog.Debug("showing text in", "language", lang)
+	protocol, err := detectGraphicsProtocol(cfg.UI.Artwork, os.Getenv)
+	if err != nil {
+		slog.Warn("invalid artwork setting in config", "err", err)
+	}
+	// Half-block art is nothing but colors
+	if protocol == graphicsText && profile == termenv.Ascii {
+		protocol = graphicsOff
+	}
+	// Safe mode leaves out cover art, along with the renderings kept for it
+	if opts.SafeMode {
+		protocol = graphicsOff
+	}
+	artworkProtocol = protocol
+	themes, err := loadThemes(cfg.Themes)
+	if err != nil {
+		slog.Warn("invalid themes in config", "err", err)
+	}
+	if err := themes.selectTheme(cfg.UI.Theme); err != nil {
+		slog.Warn("invalid theme in config", "using", defaultTheme, "err", err)
+		themes.selectTheme(defaultTheme)
+	}
 
 	// Create model with error handling
-	model := NewModel()
-	fmt.Println("Model created successfully")
+	model := NewModel(cfg, opts.SafeMode)
+	model.themes = themes
+	if err := model.applyDefaultView(cfg.UI.DefaultView); err != nil {
+		slog.Warn("invalid default view in config, using playlists", "err", err)
+	}
+	if !opts.SafeMode {
+		model.trackWatcher = daemon.WatchTrackChanges(time.Second)
+		defer model.trackWatcher.Stop()
+	}
+	slog.Debug("model created")
+
+	if cfg.UI.TerminalTitle {
+		fmt.Fprint(os.Stdout, saveWindowTitle)
+		defer fmt.Fprint(os.Stdout, restoreWindowTitle)
+	}
 
 	// Initialize program
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	fmt.Println("Program initialized successfully")
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithReportFocus())
 
 	// Run program
-	_, err := p.Run()
+	_, err = p.Run()
 	if err != nil {
-		fmt.Printf("Program run error: %v\n", err)
+		slog.Error("program run error", "err", err)
 	}
 	return err
 }
@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+)
+
+// upNextLead is how long before the end of a track the "Up next" notice appears
+const upNextLead = 10.0 // seconds
+
+// upNextNotice is the heads-up about the track that plays after the current one
+type upNextNotice struct {
+	forTrack string       // Track the notice was raised for, so it's only raised once per track
+	next     daemon.Track // Upcoming track
+	position int          // 1-based position of the upcoming track in the current playlist
+	inQueue  bool         // The upcoming track is in a queue amtui manages and can be removed
+	visible  bool
+}
+
+// Message carrying the upcoming track once the current one is about to end
+type upNextMsg struct {
+	forTrack string
+	next     daemon.Track
+	position int
+	inQueue  bool
+	ok       bool // False when nothing plays next
+	err      error
+}
+
+// upNextKey identifies the playing track for the notice
+func upNextKey(track daemon.Track) string {
+	return track.Name + "\x00" + track.Artist
+}
+
+// fetchUpNext looks up the track after the current one
+func fetchUpNext(forTrack string) tea.Cmd {
+	return func() tea.Msg {
+		d := daemon.Daemon{}
+		info, err := d.GetQueueInfo()
+		if err != nil {
+			return upNextMsg{forTrack: forTrack, err: err}
+		}
+		if info.CurrentPosition < 1 || info.CurrentPosition >= len(info.Tracks) {
+			return upNextMsg{forTrack: forTrack}
+		}
+		return upNextMsg{
+			forTrack: forTrack,
+			next:     info.Tracks[info.CurrentPosition],
+			position: info.CurrentPosition + 1,
+			inQueue:  info.Editable(),
+			ok:       true,
+		}
+	}
+}
+
+// updateUpNext raises the notice when the playing track is about to end and hides
+// it once another track starts
+func (m *Model) updateUpNext(status daemon.PlaybackStatus) tea.Cmd {
+	if !m.config.Notifications.UpNext {
+		return nil
+	}
+
+	key := upNextKey(status.Track)
+	if m.upNext.visible && m.upNext.forTrack != key {
+		m.hideUpNext()
+	}
+
+	remaining := status.Duration - status.Position
+	if !status.IsPlaying || status.Track.Name == "" || remaining <= 0 || remaining > upNextLead || m.upNext.forTrack == key {
+		return nil
+	}
+	m.upNext.forTrack = key
+	return fetchUpNext(key)
+}
+
+// showUpNext displays the notice and, if configured, a desktop notification
+func (m *Model) showUpNext(msg upNextMsg) tea.Cmd {
+	if msg.err != nil || !msg.ok || msg.forTrack != m.upNext.forTrack {
+		return nil
+	}
+	m.upNext.next = msg.next
+	m.upNext.position = msg.position
+	m.upNext.inQueue = msg.inQueue
+	m.upNext.visible = true
+	m.setUpNextBanner()
+
+	if !m.config.Notifications.Desktop {
+		return nil
+	}
+	next := msg.next
+	d := daemon.Daemon{}
+	return attempt("Error sending notification", func() error {
+		return d.Notify("Up next", fmt.Sprintf("%s — %s", next.Name, next.Artist))
+	})
+}
+
+func (m *Model) hideUpNext() {
+	m.upNext.visible = false
+	m.setUpNextBanner()
+}
+
+// setUpNextBanner mirrors the notice into the instructions area
+func (m *Model) setUpNextBanner() {
+	banner := ""
+	if m.upNext.visible {
+		banner = fmt.Sprintf("♪ Up next: %s — %s • %s skip", m.upNext.next.Name, m.upNext.next.Artist, m.firstKey(actionUpNextSkip))
+		if m.upNext.inQueue {
+			banner += fmt.Sprintf(" • %s remove", m.firstKey(actionUpNextRemove))
+		}
+	}
+	m.boxer.EditLeaf("instructions", func(model tea.Model) (tea.Model, error) {
+		instr := model.(instructionsModel)
+		instr.upNext = banner
+		return instr, nil
+	})
+}
+
+// firstKey returns the first key bound to action, for hints
+func (m *Model) firstKey(action keyAction) string {
+	if keys := m.keys.keys(action); len(keys) > 0 {
+		return displayKey(keys[0])
+	}
+	return "?"
+}
+
+// vetoUpNext skips past or removes the upcoming track before it starts
+func (m *Model) vetoUpNext(action keyAction) tea.Cmd {
+	notice := m.upNext
+	m.hideUpNext()
+
+	switch action {
+	case actionUpNextSkip:
+		return func() tea.Msg {
+			d := daemon.Daemon{}
+			var err error
+			if notice.inQueue {
+				err = d.SkipToQueuePosition(notice.position + 1)
+			} else {
+				// Outside the amtui Queue, skip twice to get past the upcoming track
+				if err = d.NextTrack(); err == nil {
+					err = d.NextTrack()
+				}
+			}
+			if err != nil {
+				return notifyError("Error skipping upcoming track: %v", err)
+			}
+			return nil
+		}
+	case actionUpNextRemove:
+		if !notice.inQueue {
+			return nil
+		}
+		d := daemon.Daemon{}
+		return attempt("Error removing upcoming track", func() error { return d.RemoveFromQueue(notice.position) })
+	}
+	return nil
+}
+
+// renderUpNextBanner renders the "Up next" notice to fit the instructions area
+func (m instructionsModel) renderUpNextBanner() string {
+	return upNextStyle.Render(layout.Fit(m.upNext, m.width, "..."))
+}
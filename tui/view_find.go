@@ -0,0 +1,204 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/locale"
+)
+
+// findHelpKeys are the keys of finding in the song list after the search key, which
+// can't be remapped
+var findHelpKeys = [][2]string{
+	{"n/N", "next/previous match"},
+	{"enter", "keep the pattern and go back to the list"},
+	{"esc", "clear the pattern"},
+}
+
+// findMatches reports whether track has find in its name, artist or album, ignoring case
+func (m mainContentModel) findMatches(track daemon.Track) bool {
+	return m.find != "" && strings.Contains(strings.ToLower(daemon.TrackSearchText(track)), strings.ToLower(m.find))
+}
+
+// findRows returns the rows of the song list whose tracks match find, in order
+func (m mainContentModel) findRows(tracks []daemon.Track) []int {
+	var rows []int
+	order := m.rowOrder(tracks)
+	for row, count := 0, m.rowCount(tracks); row < count; row++ {
+		i := row
+		if order != nil {
+			i = order[row]
+		}
+		if m.findMatches(tracks[i]) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// findLabel is the pattern in the song list's title, with where the selection is among
+// the matches once typing is done, e.g. " · find: love (2/7)"
+func (m mainContentModel) findLabel(tracks []daemon.Track) string {
+	if !m.finding && m.find == "" {
+		return ""
+	}
+	if m.finding {
+		return " · " + locale.T("songs.find", m.prompt.View())
+	}
+	label := " · " + locale.T("songs.find", m.find)
+	rows := m.findRows(tracks)
+	if len(rows) == 0 {
+		return label + " (" + locale.T("songs.no_find_match") + ")"
+	}
+	current := 0
+	for n, row := range rows {
+		if row <= m.selectedSong {
+			current = n + 1
+		}
+	}
+	if current == 0 || rows[current-1] != m.selectedSong {
+		return label + fmt.Sprintf(" (%d)", len(rows))
+	}
+	return label + fmt.Sprintf(" (%d/%d)", current, len(rows))
+}
+
+// highlightFind marks where find occurs in a row of plain text. A track matching in a
+// column that isn't shown is marked as a whole.
+func highlightFind(row, find string) string {
+	lower, pattern := strings.ToLower(row), strings.ToLower(find)
+	if len(lower) != len(row) || !strings.Contains(lower, pattern) {
+		// Lowercasing moved the bytes around, or the match isn't in view
+		return findMatchStyle.Render(row)
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, pattern)
+		if i < 0 {
+			break
+		}
+		b.WriteString(row[:i])
+		b.WriteString(findMatchStyle.Render(row[i : i+len(pattern)]))
+		row, lower = row[i+len(pattern):], lower[i+len(pattern):]
+	}
+	b.WriteString(row)
+	return b.String()
+}
+
+// openFind starts typing a pattern to find in the open playlist's songs. Unlike the
+// filter, every song stays in the list; the matches are highlighted and n/N move
+// between them.
+func (m *Model) openFind() bool {
+	if m.currentFocus != focusMain || m.selectedPlaylist == "" {
+		return false
+	}
+	open := false
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		if !main.showsResults() && main.currentPlaylist != "" {
+			main.finding = true
+			main.find = ""
+			main.prompt = lineInput{}
+			main.findOrigin = main.selectedSong
+			open = true
+		}
+		return main, nil
+	})
+	m.finding = open
+	return open
+}
+
+// updateFind edits the pattern as it's typed, selecting the first match from where
+// typing began on every key. Enter keeps the pattern for n/N, Esc clears it and goes
+// back to the song selected before.
+func (m *Model) updateFind(msg tea.KeyMsg) {
+	var prompt lineInput
+	var origin int
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		prompt, origin = main.prompt, main.findOrigin
+		return model, nil
+	})
+
+	switch msg.String() {
+	case "esc":
+		m.finding = false
+		m.setFind("")
+		m.selectSongRow(origin)
+		return
+	case "enter":
+		m.finding = false
+		m.setFind(prompt.text)
+		return
+	}
+	prompt.update(msg)
+	m.setPrompt(prompt)
+	m.setFind(prompt.text)
+	m.selectSongRow(origin)
+	if prompt.text != "" {
+		m.findNext(0)
+	}
+}
+
+// setFind sets the pattern highlighted in the song list
+func (m *Model) setFind(find string) {
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		main.find = find
+		main.finding = m.finding
+		return main, nil
+	})
+}
+
+// findNext selects the next row matching the pattern, wrapping around at the end of
+// the list, or the previous one when direction is negative. A direction of 0 stays on
+// the selected row if it matches. It reports whether there was a pattern to find.
+func (m *Model) findNext(direction int) bool {
+	var rows []int
+	var current int
+	finding := false
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		current = main.selectedSong
+		if main.find != "" && !main.showsResults() && main.playlistCache != nil {
+			if playlist, ok := (*main.playlistCache)[main.currentPlaylist]; ok {
+				rows, finding = main.findRows(playlist.Tracks), true
+			}
+		}
+		return main, nil
+	})
+	if !finding || len(rows) == 0 {
+		return finding
+	}
+
+	var target int
+	if direction < 0 {
+		target = rows[len(rows)-1]
+		for _, row := range rows {
+			if row < current {
+				target = row
+			}
+		}
+	} else {
+		target = rows[0]
+		for _, row := range rows {
+			if row > current || (direction == 0 && row == current) {
+				target = row
+				break
+			}
+		}
+	}
+	m.selectSongRow(target)
+	return true
+}
+
+// selectSongRow selects a row of the song list, scrolling it into view
+func (m *Model) selectSongRow(row int) {
+	current, count, _ := m.songListPosition()
+	if count == 0 {
+		return
+	}
+	m.updateSongSelection(min(max(row, 0), count-1) - current)
+}
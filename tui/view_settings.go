@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"main/config"
+)
+
+// playlistView is how a playlist was last arranged in the main pane
+type playlistView struct {
+	Sort         trackSort `json:"sort"`
+	ShowAdded    bool      `json:"show_added"`
+	Columns      []string  `json:"columns,omitempty"` // Picked with :columns, empty for the configured ones
+	SelectedSong int       `json:"selected_song"`
+	ScrollOffset int       `json:"scroll_offset"`
+}
+
+// viewSettings maps playlist names to their last arrangement
+type viewSettings map[string]playlistView
+
+// viewSettingsPath is where per-playlist view settings are kept between runs
+func viewSettingsPath() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "views.json"), nil
+}
+
+// loadViewSettings reads the saved view settings. A missing or unreadable file
+// yields empty settings, so every playlist opens with the defaults.
+func loadViewSettings() viewSettings {
+	views := viewSettings{}
+	path, err := viewSettingsPath()
+	if err != nil {
+		return views
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return views
+	}
+	if err := json.Unmarshal(data, &views); err != nil {
+		return viewSettings{}
+	}
+	return views
+}
+
+// save writes the view settings to disk
+func (v viewSettings) save() error {
+	path, err := viewSettingsPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode view settings: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write view settings: %w", err)
+	}
+	return nil
+}
+
+// remember records how the main pane currently shows its playlist
+func (v viewSettings) remember(main mainContentModel) {
+	if main.currentPlaylist == "" || main.showsResults() {
+		return
+	}
+	view := playlistView{
+		Sort:         main.sortMode,
+		ShowAdded:    main.showAdded,
+		SelectedSong: main.selectedSong,
+		ScrollOffset: main.scrollOffset,
+	}
+	if main.customColumns {
+		for _, c := range main.columns {
+			view.Columns = append(view.Columns, c.name)
+		}
+	}
+	v[main.currentPlaylist] = view
+}
+
+// apply opens playlistName in the main pane the way it was last arranged, falling
+// back to playlist order from the top and the columns configured in ui
+func (v viewSettings) apply(main *mainContentModel, playlistName string, ui config.UIConfig) {
+	view, ok := v[playlistName]
+	if !ok {
+		view = playlistView{Sort: sortPlaylistOrder, ShowAdded: ui.AddedColumn}
+	}
+	main.currentPlaylist = playlistName
+	main.filter = "" // Filters, found patterns and marked rows don't carry over to other playlists
+	main.find = ""
+	main.visual = false
+	main.sortMode = view.Sort
+	main.showAdded = view.ShowAdded
+	// Columns that are no longer known fall back to the configured ones
+	configured, err := tableColumns(ui.Columns)
+	if err != nil {
+		configured, _ = tableColumns(nil)
+	}
+	main.columns, main.customColumns = configured, false
+	if len(view.Columns) > 0 {
+		if columns, err := pickColumns(view.Columns, configured); err == nil {
+			main.columns, main.customColumns = columns, true
+		}
+	}
+	main.selectedSong = max(view.SelectedSong, 0)
+	main.scrollOffset = max(view.ScrollOffset, 0)
+
+	// The playlist may have shrunk since it was last open
+	if main.playlistCache != nil {
+		if playlist, exists := (*main.playlistCache)[playlistName]; exists {
+			last := max(len(playlist.Tracks)-1, 0)
+			main.selectedSong = min(main.selectedSong, last)
+			main.scrollOffset = min(main.scrollOffset, main.selectedSong)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/layout"
+	"main/locale"
+)
+
+// viewTab is one of the views listed in the tab bar along the top
+type viewTab int
+
+const (
+	viewPlaylists viewTab = iota
+	viewSearch
+	viewQueue
+	viewLyrics
+	viewHistory
+	viewStats
+	viewTabCount
+)
+
+// viewTabIDs are the message IDs of the tab names
+var viewTabIDs = [viewTabCount]string{"view.playlists", "view.search", "view.queue", "view.lyrics", "view.history", "view.stats"}
+
+func (v viewTab) String() string {
+	return locale.T(viewTabIDs[v])
+}
+
+// viewActions are the actions that open each view
+var viewActions = map[keyAction]viewTab{
+	actionViewPlaylists: viewPlaylists,
+	actionViewSearch:    viewSearch,
+	actionViewQueue:     viewQueue,
+	actionViewLyrics:    viewLyrics,
+	actionViewHistory:   viewHistory,
+	actionViewStats:     viewStats,
+}
+
+// viewTabsModel is the tab bar along the top
+type viewTabsModel struct {
+	width   int
+	current viewTab
+}
+
+func (m viewTabsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m viewTabsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = msg.Width
+	}
+	return m, nil
+}
+
+// labels are the tabs as drawn, numbered like their default keys
+func (m viewTabsModel) labels() []string {
+	labels := make([]string, viewTabCount)
+	for v := range viewTabCount {
+		labels[v] = fmt.Sprintf(" %d %s ", v+1, v)
+	}
+	return labels
+}
+
+func (m viewTabsModel) View() string {
+	var bar string
+	for v, label := range m.labels() {
+		if viewTab(v) == m.current {
+			bar += selectedItemStyle.Render(label)
+		} else {
+			bar += playlistStatsStyle.Render(label)
+		}
+	}
+	return layout.Fit(bar, m.width, "…")
+}
+
+// tabAt returns the tab drawn at column col
+func (m viewTabsModel) tabAt(col int) (viewTab, bool) {
+	for v, label := range m.labels() {
+		width := layout.Width(label)
+		if col < width {
+			return viewTab(v), true
+		}
+		col -= width
+	}
+	return 0, false
+}
+
+// activeView is the view on screen: an open view overlay, or else what the song list shows
+func (m *Model) activeView() viewTab {
+	switch {
+	case m.queueVisible:
+		return viewQueue
+	case m.lyricsVisible:
+		return viewLyrics
+	case m.historyVisible:
+		return viewHistory
+	case m.libraryStatsVisible:
+		return viewStats
+	}
+	view := viewPlaylists
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		view = model.(mainContentModel).view
+		return model, nil
+	})
+	return view
+}
+
+// syncViewTabs highlights the active view in the tab bar
+func (m *Model) syncViewTabs() {
+	current := m.activeView()
+	m.boxer.EditLeaf("tabs", func(model tea.Model) (tea.Model, error) {
+		tabs := model.(viewTabsModel)
+		tabs.current = current
+		return tabs, nil
+	})
+}
+
+// runViewAction switches views for the view actions, reporting whether action was one
+func (m *Model) runViewAction(action keyAction) (tea.Cmd, bool) {
+	switch action {
+	case actionNextView:
+		return m.switchView((m.activeView() + 1) % viewTabCount), true
+	case actionPrevView:
+		return m.switchView((m.activeView() + viewTabCount - 1) % viewTabCount), true
+	}
+	view, ok := viewActions[action]
+	if !ok {
+		return nil, false
+	}
+	return m.switchView(view), true
+}
+
+// switchView closes the view on screen and opens view
+func (m *Model) switchView(view viewTab) tea.Cmd {
+	if view == m.activeView() {
+		return nil
+	}
+	m.closeQueue()
+	m.closeLyrics()
+	m.historyVisible = false
+	m.libraryStatsVisible = false
+	m.nowPlayingVisible = false
+
+	switch view {
+	case viewPlaylists, viewSearch:
+		m.showSongListView(view)
+	case viewQueue:
+		return m.openQueue()
+	case viewLyrics:
+		return m.openLyrics()
+	case viewHistory:
+		m.openHistory()
+	case viewStats:
+		return m.openLibraryStats()
+	}
+	return nil
+}
+
+// showSongListView shows the playlist or the search results in the song list. Search
+// results come back as they were left, and the search box takes focus.
+func (m *Model) showSongListView(view viewTab) {
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		if main.view == view {
+			return main, nil
+		}
+		// Albums and artists opened from the other view go with it
+		main.view, main.collection = view, ""
+		main.searchResults = main.searchHits.Songs
+		main.selectedSong, main.scrollOffset = 0, 0
+		main.visual = false
+		return main, nil
+	})
+	switch {
+	case view == viewSearch:
+		m.currentFocus = focusSearch
+	case m.split.hidden:
+		m.currentFocus = focusMain
+	default:
+		m.currentFocus = focusPlaylists
+	}
+	m.updateFocus()
+}
+
+// openQueue opens the queue and keeps it current while it's open
+func (m *Model) openQueue() tea.Cmd {
+	m.queueVisible = true
+	m.queueOverlay.visible = true
+	m.queueOverlay.resize(m.lastWidth, m.lastHeight-1)
+	m.queueOverlay.loading = true
+	return tea.Batch(fetchQueueInfo(), m.startQueueRefresh())
+}
+
+func (m *Model) closeQueue() {
+	m.queueVisible = false
+	m.queueOverlay.visible = false
+	m.queueOverlay.reset()
+}
+
+// openLyrics opens the lyrics of the playing track and starts loading them
+func (m *Model) openLyrics() tea.Cmd {
+	m.lyricsVisible = true
+	m.lyricsOverlay.visible = true
+	m.lyricsOverlay.width = m.lastWidth
+	m.lyricsOverlay.height = m.lastHeight - 1
+
+	d := daemon.Daemon{}
+	currentTrack, err := d.GetCurrentTrack()
+	if err != nil {
+		m.lyricsOverlay.lastError = fmt.Errorf("no track currently playing")
+		m.lyricsOverlay.loading = false
+		return nil
+	}
+	m.lyricsOverlay.loading = true
+	m.lyricsOverlay.trackName = currentTrack.Name
+	m.lyricsOverlay.artistName = currentTrack.Artist
+	m.lyricsOverlay.lastError = nil
+	return fetchLyrics(currentTrack.Name, currentTrack.Artist)
+}
+
+func (m *Model) closeLyrics() {
+	m.lyricsVisible = false
+	m.lyricsOverlay.visible = false
+}
+
+// openLibraryStats counts the whole library; the overlay shows progress until the counts arrive
+func (m *Model) openLibraryStats() tea.Cmd {
+	m.libraryStatsVisible = true
+	m.libraryStats.loading = true
+	return fetchLibraryStats
+}
+
+// withViewTabs puts the tab bar under view, a view drawn over the screen but its last row
+func (m *Model) withViewTabs(view string) string {
+	var bar string
+	m.boxer.EditLeaf("tabs", func(model tea.Model) (tea.Model, error) {
+		tabs := model.(viewTabsModel)
+		tabs.width = m.lastWidth
+		bar = tabs.View()
+		return model, nil
+	})
+	return view + "\n" + bar
+}
+
+// clickViewTab switches to the tab clicked in the tab bar under a view overlay,
+// reporting whether the click landed on it
+func (m *Model) clickViewTab(msg tea.MouseMsg) (tea.Cmd, bool) {
+	if msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionPress || msg.Y != m.lastHeight-1 {
+		return nil, false
+	}
+	view, found := viewTab(0), false
+	m.boxer.EditLeaf("tabs", func(model tea.Model) (tea.Model, error) {
+		view, found = model.(viewTabsModel).tabAt(msg.X)
+		return model, nil
+	})
+	if !found {
+		return nil, true
+	}
+	return m.switchView(view), true
+}
@@ -0,0 +1,305 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+	"main/locale"
+)
+
+// visualRange is the first and last row marked in visual mode
+func (m mainContentModel) visualRange() (int, int) {
+	return min(m.visualAnchor, m.selectedSong), max(m.visualAnchor, m.selectedSong)
+}
+
+// marked reports whether row is marked in visual mode
+func (m mainContentModel) marked(row int) bool {
+	first, last := m.visualRange()
+	return m.visual && row >= first && row <= last
+}
+
+// markRow flags row with a bar in place of its leading space while it's marked
+func (m mainContentModel) markRow(row string, i int) string {
+	if !m.marked(i) || !m.focused || !strings.HasPrefix(row, " ") {
+		return row
+	}
+	return "▌" + row[1:]
+}
+
+// visualLabel is added to the song list's title while marking rows
+func (m mainContentModel) visualLabel() string {
+	if !m.visual {
+		return ""
+	}
+	first, last := m.visualRange()
+	return " · " + locale.T("songs.visual", last-first+1)
+}
+
+// showsSongs reports whether the main pane lists songs that can be marked: a playlist,
+// or songs found by a search or opened from a library tab
+func (m mainContentModel) showsSongs() bool {
+	if m.showsResults() {
+		return !m.showsSearchHits()
+	}
+	return m.currentPlaylist != ""
+}
+
+// markedTracks returns the tracks of the rows marked in visual mode, in list order
+func (m mainContentModel) markedTracks() []daemon.Track {
+	if !m.visual {
+		return nil
+	}
+	first, last := m.visualRange()
+	var tracks []daemon.Track
+	for row := first; row <= last; row++ {
+		if track, ok := m.trackAtRow(row); ok {
+			tracks = append(tracks, track)
+		}
+	}
+	return tracks
+}
+
+// trackAtRow returns the track listed on row of the open playlist or of the songs found
+// by a search
+func (m mainContentModel) trackAtRow(row int) (daemon.Track, bool) {
+	var rows []daemon.Track
+	i := row
+	switch {
+	case m.showsResults():
+		if m.showsSearchHits() {
+			return daemon.Track{}, false
+		}
+		rows = m.searchResults
+	case m.playlistCache != nil:
+		rows = (*m.playlistCache)[m.currentPlaylist].Tracks
+		i = m.trackIndex(row)
+	}
+	if i < 0 || i >= len(rows) || !loaded(rows[i]) {
+		return daemon.Track{}, false
+	}
+	return rows[i], true
+}
+
+// inVisual reports whether rows are being marked in the main pane
+func (m *Model) inVisual() bool {
+	visual := false
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		visual = model.(mainContentModel).visual
+		return model, nil
+	})
+	return visual
+}
+
+// toggleVisual starts marking rows from the selected song, or stops
+func (m *Model) toggleVisual() {
+	if m.currentFocus != focusMain {
+		return
+	}
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		main.visual = !main.visual && main.showsSongs()
+		main.visualAnchor = main.selectedSong
+		return main, nil
+	})
+}
+
+// exitVisual stops marking rows
+func (m *Model) exitVisual() {
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main := model.(mainContentModel)
+		main.visual = false
+		return main, nil
+	})
+}
+
+// updateVisual runs the bulk action for key on the marked songs: a adds them to the
+// queue, p to a playlist, d removes them from the open playlist and 0-5 rates them.
+// Other keys, like moving the selection to mark more rows, aren't handled.
+func (m *Model) updateVisual(msg tea.KeyMsg) (tea.Cmd, bool) {
+	var main mainContentModel
+	m.boxer.EditLeaf("main", func(model tea.Model) (tea.Model, error) {
+		main = model.(mainContentModel)
+		return main, nil
+	})
+
+	key := msg.String()
+	switch key {
+	case "esc", "v":
+		m.exitVisual()
+		return nil, true
+	case "a", "p", "d", "x", "0", "1", "2", "3", "4", "5":
+	default:
+		return nil, false
+	}
+
+	tracks := main.markedTracks()
+	m.exitVisual()
+	if len(tracks) == 0 {
+		return nil, true
+	}
+	switch key {
+	case "a":
+		return addToQueue(tracks), true
+	case "p":
+		m.openPlaylistPicker(fmt.Sprintf("Add %d songs to playlist", len(tracks)), func(playlist string) tea.Cmd {
+			return addTracksToPlaylist(tracks, playlist)
+		})
+	case "d", "x":
+		// Only songs of a playlist can be removed from it, and smart playlists fill themselves
+		playlist, ok := m.playlistCache[main.currentPlaylist]
+		if main.showsResults() || !ok || playlist.Smart {
+			return m.toast(notifyError("Songs can only be removed from a playlist that isn't smart")), true
+		}
+		m.confirm = confirmRemoveTracks(tracks, main.currentPlaylist)
+		m.confirmVisible = true
+	default:
+		stars := int(key[0] - '0')
+		return rateTracks(tracks, stars*20), true
+	}
+	return nil, true
+}
+
+// Message sent after songs were added to or removed from a playlist, or rated
+type tracksEditedMsg struct {
+	tracks   []daemon.Track
+	playlist string // Playlist songs were added to or removed from, "" for ratings
+	removed  bool
+	rating   int
+	count    int // How many of tracks Music found and edited
+	err      error
+}
+
+// addTracksToPlaylist duplicates tracks into playlist
+func addTracksToPlaylist(tracks []daemon.Track, playlist string) tea.Cmd {
+	return inFlight("Adding to "+playlist, func() tea.Msg {
+		d := daemon.Daemon{}
+		count, err := d.AddTracksToPlaylist(tracks, playlist)
+		return tracksEditedMsg{tracks: tracks, playlist: playlist, count: count, err: err}
+	})
+}
+
+// addSongToPlaylist duplicates a single song into playlist
+func addSongToPlaylist(song daemon.Track, playlist string) tea.Cmd {
+	return inFlight("Adding to "+playlist, func() tea.Msg {
+		d := daemon.Daemon{}
+		err := d.AddSongToPlaylist(song, daemon.Playlist{Name: playlist})
+		count := 1
+		if err != nil {
+			count = 0
+		}
+		return tracksEditedMsg{tracks: []daemon.Track{song}, playlist: playlist, count: count, err: err}
+	})
+}
+
+// removeTracksFromPlaylist takes tracks out of playlist, keeping them in the library
+func removeTracksFromPlaylist(tracks []daemon.Track, playlist string) tea.Cmd {
+	return inFlight("Removing from "+playlist, func() tea.Msg {
+		d := daemon.Daemon{}
+		count, err := d.RemoveTracksFromPlaylist(tracks, playlist)
+		return tracksEditedMsg{tracks: tracks, playlist: playlist, removed: true, count: count, err: err}
+	})
+}
+
+// removeSongFromPlaylist takes a single song out of playlist
+func removeSongFromPlaylist(song daemon.Track, playlist string) tea.Cmd {
+	return inFlight("Removing from "+playlist, func() tea.Msg {
+		d := daemon.Daemon{}
+		err := d.RemoveSongFromPlaylist(song, daemon.Playlist{Name: playlist})
+		count := 1
+		if err != nil {
+			count = 0
+		}
+		return tracksEditedMsg{tracks: []daemon.Track{song}, playlist: playlist, removed: true, count: count, err: err}
+	})
+}
+
+// rateTracks sets the rating of tracks, 0-100 with 20 per star
+func rateTracks(tracks []daemon.Track, rating int) tea.Cmd {
+	return inFlight("Rating songs", func() tea.Msg {
+		d := daemon.Daemon{}
+		count, err := d.RateTracks(tracks, rating)
+		return tracksEditedMsg{tracks: tracks, rating: rating, count: count, err: err}
+	})
+}
+
+// confirmRemoveTracks asks before removing tracks from playlist
+func confirmRemoveTracks(tracks []daemon.Track, playlist string) confirmModel {
+	if len(tracks) == 1 {
+		return confirmModel{
+			title:     fmt.Sprintf("Remove from %s?", playlist),
+			lines:     []string{tracks[0].Name + " — " + tracks[0].Artist, "It stays in your library."},
+			onConfirm: removeSongFromPlaylist(tracks[0], playlist),
+		}
+	}
+	return confirmModel{
+		title: fmt.Sprintf("Remove %d songs from %s?", len(tracks), playlist),
+		lines: []string{
+			fmt.Sprintf("%s — %s and %d more", tracks[0].Name, tracks[0].Artist, len(tracks)-1),
+			"They stay in your library.",
+		},
+		onConfirm: removeTracksFromPlaylist(tracks, playlist),
+	}
+}
+
+// summary describes the outcome of the edit, e.g. "Added 3 songs to Road Trip"
+func (msg tracksEditedMsg) summary() string {
+	songs := fmt.Sprintf("%d songs", msg.count)
+	if msg.count != len(msg.tracks) {
+		songs = fmt.Sprintf("%d of %d songs", msg.count, len(msg.tracks))
+	}
+	switch {
+	case msg.playlist == "":
+		return fmt.Sprintf("Rated %s %s", songs, formatRating(msg.rating))
+	case msg.removed:
+		return fmt.Sprintf("Removed %s from %s", songs, msg.playlist)
+	}
+	return fmt.Sprintf("Added %s to %s", songs, msg.playlist)
+}
+
+// applyTrackEdit updates the cached playlists after msg's edit succeeded, so the change
+// shows without reloading them
+func applyTrackEdit(cache map[string]daemon.Playlist, msg tracksEditedMsg) {
+	if msg.playlist == "" {
+		rated := make(map[string]bool, len(msg.tracks))
+		for _, track := range msg.tracks {
+			rated[track.Id] = track.Id != ""
+		}
+		for _, playlist := range cache {
+			for i := range playlist.Tracks {
+				if rated[playlist.Tracks[i].Id] {
+					playlist.Tracks[i].Rating = msg.rating
+				}
+			}
+		}
+		return
+	}
+
+	// Which songs Music skipped isn't known, so partial edits show once it's reloaded
+	playlist, ok := cache[msg.playlist]
+	if !ok || msg.count != len(msg.tracks) {
+		return
+	}
+	if !msg.removed {
+		playlist.Tracks = append(playlist.Tracks[:len(playlist.Tracks):len(playlist.Tracks)], msg.tracks...)
+		cache[msg.playlist] = playlist
+		return
+	}
+	// Remove one entry per track, like Music does
+	remove := make(map[daemon.Track]int, len(msg.tracks))
+	for _, track := range msg.tracks {
+		remove[track]++
+	}
+	kept := playlist.Tracks[:0:0]
+	for _, track := range playlist.Tracks {
+		if remove[track] > 0 {
+			remove[track]--
+			continue
+		}
+		kept = append(kept, track)
+	}
+	playlist.Tracks = kept
+	cache[msg.playlist] = playlist
+}
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"main/daemon"
+)
+
+// Escape sequences pushing the terminal's title on its title stack and popping it back,
+// so the title amtui sets goes away on exit. xterm's, also understood by tmux and most
+// terminals that let programs set the title.
+const (
+	saveWindowTitle    = "\x1b[22;0t"
+	restoreWindowTitle = "\x1b[23;0t"
+)
+
+// windowTitleFor is the terminal title while track plays, "amtui" when nothing does
+func windowTitleFor(track daemon.Track) string {
+	switch {
+	case track.Name == "":
+		return "amtui"
+	case track.Artist == "":
+		return "♪ " + track.Name
+	}
+	return "♪ " + track.Name + " – " + track.Artist
+}
+
+// updateWindowTitle shows track in the terminal's title when [ui] terminal_title is on
+func (m *Model) updateWindowTitle(track daemon.Track) tea.Cmd {
+	if !m.config.UI.TerminalTitle {
+		return nil
+	}
+	title := windowTitleFor(track)
+	if title == m.windowTitle {
+		return nil
+	}
+	m.windowTitle = title
+	return tea.SetWindowTitle(title)
+}